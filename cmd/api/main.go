@@ -5,9 +5,16 @@ import (
 	_ "golang-api/docs"
 	"golang-api/internal/config"
 	"golang-api/internal/middleware"
+	"golang-api/internal/migrations"
 	"golang-api/internal/routes"
+	"golang-api/internal/scheduler"
+	"golang-api/internal/tokencache"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"strings"
 
@@ -39,6 +46,10 @@ func main() {
 	config.LoadEnv()
 
 	config.ConnectDatabase()
+	migrations.Run(config.GetDB())
+
+	tokencache.Tokens.SetTTL(config.TokenCacheTTL)
+	tokencache.Start(config.GetDB(), time.Minute)
 
 	app := fiber.New(fiber.Config{
 		AppName: os.Getenv("APP_NAME"),
@@ -57,9 +68,17 @@ func main() {
 	app.Use(recover.New())
 	app.Use(middleware.Logger())
 	app.Use(middleware.CORS())
+	app.Use(middleware.Locale())
 
 	routes.SetupRoutes(app)
 
+	var jobs *scheduler.Scheduler
+	if config.SchedulerEnabled {
+		jobs = scheduler.New()
+		scheduler.RegisterJobs(jobs, config.GetDB())
+		jobs.Start()
+	}
+
 	if config.AppURL != "" {
 		host := config.AppURL
 		host = strings.Replace(host, "http://", "", 1)
@@ -71,6 +90,29 @@ func main() {
 
 	addr := config.AppIP + ":" + config.AppPort
 
-	log.Printf("Server starting on %s...\n", addr)
-	log.Fatal(app.Listen(addr))
+	go func() {
+		log.Printf("Server starting on %s...\n", addr)
+		if err := app.Listen(addr); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	// Finish the running requests and jobs first, then write the last_used_at updates they left pending in memory.
+	log.Println("Shutting down server...")
+
+	if err := app.ShutdownWithTimeout(30 * time.Second); err != nil {
+		log.Println("Failed to shut down server: ", err)
+	}
+
+	if jobs != nil {
+		jobs.Stop()
+	}
+
+	tokencache.LastUsed.Flush(config.GetDB())
+
+	log.Println("Server stopped")
 }
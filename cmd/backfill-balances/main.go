@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"golang-api/internal/config"
+	"golang-api/internal/migrations"
+	"golang-api/internal/service/payment_service"
+	"log"
+)
+
+// Reconstructs the balance ledger of payment accounts from their booked payments.
+//
+//	go run ./cmd/backfill-balances                # every account without a ledger
+//	go run ./cmd/backfill-balances -account=4     # a single account
+//	go run ./cmd/backfill-balances -force         # rebuild ledgers that already exist
+func main() {
+	account := flag.Uint("account", 0, "only backfill this payment account ID")
+	force := flag.Bool("force", false, "rebuild accounts that already have a balance ledger")
+	flag.Parse()
+
+	config.LoadEnv()
+	config.ConnectDatabase()
+	migrations.Run(config.GetDB())
+
+	results, err := payment_service.NewBalanceHistoryService(config.GetDB()).Backfill(uint(*account), *force)
+	if err != nil {
+		log.Fatal("Failed to backfill balance history: ", err)
+	}
+
+	log.Printf("Balance history: %d accounts, %d backfilled, %d skipped, %d failed\n", results["total"], results["backfilled"], results["skipped"], results["failed"])
+}
@@ -201,9 +201,49 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Email a single use password reset link. The response does not reveal whether the email address is registered, and each address gets at most one email per minute",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Request a password reset link",
+                "parameters": [
+                    {
+                        "description": "Email address",
+                        "name": "forgot-password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
-                "description": "Login with email and password to receive a personal access token",
+                "description": "Login with email and password to receive a personal access token. Users with two-factor authentication receive a challenge token instead, to be completed at /auth/two-factor/challenge. Pass abilities to issue a token limited to them, e.g. [\"payments:read\"] for a read-only integration; by default the token has every ability",
                 "consumes": [
                     "application/json"
                 ],
@@ -255,6 +295,77 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the login attempts of the authenticated user, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get login history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 15,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LoginAttempt"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
                     }
                 }
             }
@@ -266,7 +377,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout user and revoke current access token",
+                "description": "Logout user and revoke current access token together with its refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -300,7 +411,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user profile with name and email",
+                "description": "Update user profile with name and email, and the locale and timezone responses are formatted with. An empty locale or timezone clears the preference. A new email address has to be verified again, until then the tokens of the user only keep the unverified abilities.",
                 "consumes": [
                     "application/json"
                 ],
@@ -350,14 +461,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/validate-token": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Validate the personal access token and return user information",
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing a rotated refresh token revokes every token of that login session",
                 "consumes": [
                     "application/json"
                 ],
@@ -367,7 +473,18 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Validate authentication token",
+                "summary": "Refresh an access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -380,7 +497,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.ValidateTokenResponse"
+                                            "$ref": "#/definitions/dto.LoginResponse"
                                         }
                                     }
                                 }
@@ -392,18 +509,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/files/d/{uid}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get list of files for a specific file download by UID",
+        "/auth/register": {
+            "post": {
+                "description": "Create an account and send a signed email verification link. Until the email address is verified, login only grants the profile abilities",
                 "consumes": [
                     "application/json"
                 ],
@@ -411,21 +529,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "auth"
                 ],
-                "summary": "Get files by file download UID",
+                "summary": "Register a user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "File Download UID",
-                        "name": "uid",
-                        "in": "path",
-                        "required": true
+                        "description": "Registration",
+                        "name": "register",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -435,33 +555,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/controllers.FileSwagger"
-                                            }
+                                            "$ref": "#/definitions/models.User"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/notifications/settings": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update user notification allowance and Expo push token",
+        "/auth/reset-password": {
+            "post": {
+                "description": "Set a new password with the token from the reset link. The token can be used once and every access token of the user is revoked",
                 "consumes": [
                     "application/json"
                 ],
@@ -469,17 +581,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "auth"
                 ],
-                "summary": "Update notification settings",
+                "summary": "Reset password",
                 "parameters": [
                     {
-                        "description": "Notification settings",
-                        "name": "settings",
+                        "description": "Reset password",
+                        "name": "reset-password",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.UpdateNotificationSettingsRequest"
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
                         }
                     }
                 ],
@@ -487,13 +599,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.SimpleResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
                         }
                     },
                     "422": {
@@ -501,24 +613,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
                     }
                 }
             }
         },
-        "/payment-accounts": {
+        "/auth/tokens": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of payment accounts",
+                "description": "List the access tokens of the authenticated user, flagging the token that made the request",
                 "consumes": [
                     "application/json"
                 ],
@@ -526,32 +632,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payment_accounts"
-                ],
-                "summary": "List payment accounts",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "per_page",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "List access tokens",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                    "$ref": "#/definitions/utils.Response"
                                 },
                                 {
                                     "type": "object",
@@ -559,7 +649,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/controllers.PaymentAccountSwagger"
+                                                "$ref": "#/definitions/dto.TokenResponse"
                                             }
                                         }
                                     }
@@ -567,35 +657,21 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/payment-goals": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of payment goals for the authenticated user",
+                "description": "Create a named API token with the chosen abilities and an optional expiry date. The abilities cannot exceed the abilities of the current token, and the plain token is only returned once",
                 "consumes": [
                     "application/json"
                 ],
@@ -603,9 +679,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payment-goals"
+                    "auth"
+                ],
+                "summary": "Create an API token",
+                "parameters": [
+                    {
+                        "description": "Token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StoreTokenRequest"
+                        }
+                    }
                 ],
-                "summary": "List payment goals",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -618,33 +705,34 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/controllers.PaymentGoalSwagger"
-                                            }
+                                            "$ref": "#/definitions/dto.StoreTokenResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/payment-goals/overview": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get overview statistics of payment goals for the authenticated user",
+                "description": "Revoke every access token of the authenticated user except the current one",
                 "consumes": [
                     "application/json"
                 ],
@@ -652,45 +740,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payment-goals"
+                    "auth"
                 ],
-                "summary": "Get payment goals overview",
+                "summary": "Revoke other access tokens",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/controllers.OverviewResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.SimpleResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
                     }
                 }
             }
         },
-        "/payment-goals/{id}": {
-            "get": {
+        "/auth/tokens/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific payment goal",
+                "description": "Revoke one access token of the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -698,13 +774,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payment-goals"
+                    "auth"
                 ],
-                "summary": "Get payment goal details",
+                "summary": "Revoke an access token",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Payment Goal ID",
+                        "description": "Token ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -714,44 +790,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/controllers.PaymentGoalSwagger"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.SimpleResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/payment-types": {
-            "get": {
+        "/auth/two-factor": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of payment types",
+                "description": "Generate a TOTP secret, its otpauth URI and recovery codes. Two-factor authentication is enforced once a first code is confirmed",
                 "consumes": [
                     "application/json"
                 ],
@@ -759,41 +823,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payment_types"
-                ],
-                "summary": "List payment types",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "per_page",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "Set up two-factor authentication",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                    "$ref": "#/definitions/utils.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/controllers.PaymentTypeSwagger"
-                                            }
+                                            "$ref": "#/definitions/dto.TwoFactorSetupResponse"
                                         }
                                     }
                                 }
@@ -811,24 +856,16 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/payments": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of payments with optional filters",
+                "description": "Disable two-factor authentication, the current password is required",
                 "consumes": [
                     "application/json"
                 ],
@@ -836,92 +873,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "auth"
                 ],
-                "summary": "List payments",
+                "summary": "Disable two-factor authentication",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Type ID (1: Expense, 2: Income, 3: Transfer, 4: Withdrawal)",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Account ID",
-                        "name": "account_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "date_from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "date_to",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search query",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Current password",
+                        "name": "disable",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisableTwoFactorRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.PaginatedResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/controllers.PaymentSwagger"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.SimpleResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/two-factor/challenge": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new payment",
+                "description": "Exchange the challenge token returned by login and a TOTP code or a recovery code for an access token",
                 "consumes": [
                     "application/json"
                 ],
@@ -929,17 +919,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "auth"
                 ],
-                "summary": "Create payment",
+                "summary": "Complete a two-factor login",
                 "parameters": [
                     {
-                        "description": "Payment data",
-                        "name": "body",
+                        "description": "Two-factor challenge",
+                        "name": "challenge",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.StorePaymentRequest"
+                            "$ref": "#/definitions/dto.TwoFactorChallengeRequest"
                         }
                     }
                 ],
@@ -955,19 +945,13 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.PaymentSwagger"
+                                            "$ref": "#/definitions/dto.LoginResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -979,24 +963,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/payments/generate-report": {
+        "/auth/two-factor/confirm": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate a payment report (daily, monthly, or date range)",
+                "description": "Confirm the setup with a first code from the authenticator app",
                 "consumes": [
                     "application/json"
                 ],
@@ -1004,17 +982,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "auth"
                 ],
-                "summary": "Generate payment report",
+                "summary": "Confirm two-factor authentication",
                 "parameters": [
                     {
-                        "description": "Report parameters",
-                        "name": "body",
+                        "description": "TOTP code",
+                        "name": "confirm",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.GenerateReportRequest"
+                            "$ref": "#/definitions/dto.TwoFactorCodeRequest"
                         }
                     }
                 ],
@@ -1042,24 +1020,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/payments/summary": {
+        "/auth/two-factor/recovery-codes": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get summary of payments within a date range",
+                "description": "Get the unused two-factor recovery codes",
                 "consumes": [
                     "application/json"
                 ],
@@ -1067,23 +1039,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
-                ],
-                "summary": "Get payment summary",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "startDate",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "endDate",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "Get two-factor recovery codes",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1096,30 +1054,34 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.SummaryResponse"
+                                            "$ref": "#/definitions/dto.TwoFactorRecoveryCodesResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
                     }
                 }
-            }
-        },
-        "/payments/{id}": {
-            "get": {
-                "security": [
+            },
+            "post": {
+                "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific payment",
+                "description": "Replace every two-factor recovery code with a new set",
                 "consumes": [
                     "application/json"
                 ],
@@ -1127,18 +1089,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
-                ],
-                "summary": "Get payment details",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Regenerate two-factor recovery codes",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1151,7 +1104,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.PaymentSwagger"
+                                            "$ref": "#/definitions/dto.TwoFactorRecoveryCodesResponse"
                                         }
                                     }
                                 }
@@ -1161,26 +1114,26 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
                     }
                 }
             }
         },
-        "/payments/{id}/attachments": {
+        "/auth/validate-token": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of attachments for a specific payment",
+                "description": "Validate the personal access token and return user information",
                 "consumes": [
                     "application/json"
                 ],
@@ -1188,18 +1141,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
-                ],
-                "summary": "Get payment attachments",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Validate authentication token",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1212,33 +1156,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/controllers.AttachmentResponse"
-                                            }
+                                            "$ref": "#/definitions/dto.ValidateTokenResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
                     }
                 }
             }
         },
-        "/payments/{id}/items/attach-multiple": {
+        "/auth/verify-email": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Attach multiple items to a specific payment and update payment amount and name",
+                "description": "Verify the email address with the id, hash, expires and signature query parameters of the emailed link",
                 "consumes": [
                     "application/json"
                 ],
@@ -1246,24 +1182,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "auth"
                 ],
-                "summary": "Attach multiple items to a payment",
+                "summary": "Verify email address",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Items to attach",
-                        "name": "body",
+                        "description": "Verification link parameters",
+                        "name": "verify",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AttachMultipleItemsRequest"
+                            "$ref": "#/definitions/dto.VerifyEmailRequest"
                         }
                     }
                 ],
@@ -1274,41 +1203,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.SimpleResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.SimpleErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/utils.UnauthorizedResponse"
-                        }
-                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/payments/{id}/items/attached": {
-            "get": {
+        "/auth/verify-email/resend": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of items attached to a specific payment",
+                "description": "Send a new email verification link to the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1316,52 +1233,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
-                ],
-                "summary": "Get attached items for a payment",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "per_page",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "Resend verification email",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.PaginatedResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/controllers.PaymentItemAttachedSwagger"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.SimpleResponse"
                         }
                     },
                     "400": {
@@ -1375,24 +1254,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/payments/{id}/items/not-attached": {
+        "/budgets": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of items not attached to a specific payment",
+                "description": "Get the monthly budgets of the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1400,45 +1273,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
-                ],
-                "summary": "Get not attached items for a payment",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by name or code",
-                        "name": "search",
-                        "in": "query"
-                    }
+                    "budgets"
                 ],
+                "summary": "List budgets",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                    "$ref": "#/definitions/utils.Response"
                                 },
                                 {
                                     "type": "object",
@@ -1446,7 +1290,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/controllers.ItemNotAttachedSwagger"
+                                                "$ref": "#/definitions/models.Budget"
                                             }
                                         }
                                     }
@@ -1454,12 +1298,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1473,16 +1311,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/payments/{id}/items/summary": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get summary of items attached to a specific payment",
+                "description": "Create a monthly budget for a category, including its subcategories, or for a payment account. Account budgets use the currency of the account, category budgets IDR unless a currency is given",
                 "consumes": [
                     "application/json"
                 ],
@@ -1490,21 +1326,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "budgets"
                 ],
-                "summary": "Get payment items summary",
+                "summary": "Create a budget",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Budget data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StoreBudgetRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -1514,7 +1352,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.PaymentItemSummarySwagger"
+                                            "$ref": "#/definitions/models.Budget"
                                         }
                                     }
                                 }
@@ -1533,23 +1371,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/uptime-monitor-logs": {
+        "/budgets/status": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of uptime monitor logs",
+                "description": "Get the spent and remaining amount of every budget in a month, the current month in the user's timezone by default. Only booked expenses count, converted into the budget currency",
                 "consumes": [
                     "application/json"
                 ],
@@ -1557,28 +1395,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitor_logs"
+                    "budgets"
                 ],
-                "summary": "List uptime monitor logs",
+                "summary": "Get budget status",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter by monitor ID",
-                        "name": "monitor_id",
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "month",
                         "in": "query"
                     }
                 ],
@@ -1588,7 +1412,7 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                    "$ref": "#/definitions/utils.Response"
                                 },
                                 {
                                     "type": "object",
@@ -1596,23 +1420,43 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/controllers.UptimeMonitorLogSwagger"
+                                                "$ref": "#/definitions/dto.BudgetStatusResponse"
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/budgets/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Store a new uptime monitor log",
+                "description": "Get a budget of the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1620,23 +1464,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitor_logs"
+                    "budgets"
                 ],
-                "summary": "Store a new uptime monitor log",
+                "summary": "Get budget details",
                 "parameters": [
                     {
-                        "description": "Log data",
-                        "name": "log",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.StoreUptimeMonitorLogRequest"
-                        }
+                        "type": "integer",
+                        "description": "Budget ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1646,24 +1488,34 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.UptimeMonitorLogSwagger"
+                                            "$ref": "#/definitions/models.Budget"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/uptime-monitor-logs/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a single uptime monitor log by ID",
+                "description": "Rename a budget or change its amount, currency or rollover. The category or account of a budget cannot change, the currency only for category budgets",
                 "consumes": [
                     "application/json"
                 ],
@@ -1671,16 +1523,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitor_logs"
+                    "budgets"
                 ],
-                "summary": "Get uptime monitor log details",
+                "summary": "Update a budget",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Log ID",
+                        "description": "Budget ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Budget data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateBudgetRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1695,12 +1556,36 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.UptimeMonitorLogSwagger"
+                                            "$ref": "#/definitions/models.Budget"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
                     }
                 }
             },
@@ -1710,7 +1595,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an existing uptime monitor log",
+                "description": "Delete a budget of the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1718,13 +1603,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitor_logs"
+                    "budgets"
                 ],
-                "summary": "Delete an uptime monitor log",
+                "summary": "Delete a budget",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Log ID",
+                        "description": "Budget ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1734,20 +1619,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/uptime-monitors": {
+        "/exchange-rates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of uptime monitors",
+                "description": "Get a paginated list of exchange rates, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1755,9 +1658,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitors"
+                    "exchange_rates"
                 ],
-                "summary": "List uptime monitors",
+                "summary": "List exchange rates",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1775,8 +1678,14 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search by name or code",
-                        "name": "search",
+                        "description": "Base currency code",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote currency code",
+                        "name": "quote",
                         "in": "query"
                     }
                 ],
@@ -1794,13 +1703,25 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/controllers.UptimeMonitorSwagger"
+                                                "$ref": "#/definitions/models.ExchangeRate"
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
                     }
                 }
             },
@@ -1810,7 +1731,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Store a new uptime monitor",
+                "description": "Store the price of one unit of the base currency in the quote currency on a day, replacing the rate the pair already has on that day",
                 "consumes": [
                     "application/json"
                 ],
@@ -1818,17 +1739,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitors"
+                    "exchange_rates"
                 ],
-                "summary": "Store a new uptime monitor",
+                "summary": "Store an exchange rate",
                 "parameters": [
                     {
-                        "description": "Monitor data",
-                        "name": "monitor",
+                        "description": "Exchange rate",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.StoreUptimeMonitorRequest"
+                            "$ref": "#/definitions/dto.StoreExchangeRateRequest"
                         }
                     }
                 ],
@@ -1844,52 +1765,121 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.UptimeMonitorSwagger"
+                                            "$ref": "#/definitions/models.ExchangeRate"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/uptime-monitors/run-checks": {
+        "/exchange-rates/import": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Run uptime monitor checks for all active monitors that are due",
+                "description": "Import exchange rates from a CSV file with the columns base_currency, quote_currency, rate and date (yyyy-mm-dd), the header row is optional. Rates a pair already has on the same day are replaced. Nothing is imported when a row is invalid",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitors"
+                    "exchange_rates"
+                ],
+                "summary": "Import exchange rates",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file, up to 1 MB",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "Run uptime monitor checks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportExchangeRatesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/uptime-monitors/{id}": {
-            "get": {
+        "/exchange-rates/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a single uptime monitor by ID",
+                "description": "Delete an exchange rate. Payments already converted with it keep their amounts",
                 "consumes": [
                     "application/json"
                 ],
@@ -1897,13 +1887,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitors"
+                    "exchange_rates"
                 ],
-                "summary": "Get uptime monitor details",
+                "summary": "Delete an exchange rate",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Monitor ID",
+                        "description": "Exchange rate ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1913,30 +1903,44 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/controllers.UptimeMonitorSwagger"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/files/d/{uid}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing uptime monitor",
+                "description": "Get list of files for a specific file download by UID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1944,25 +1948,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitors"
+                    "files"
                 ],
-                "summary": "Update an uptime monitor",
+                "summary": "Get files by file download UID",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Monitor ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Monitor data",
-                        "name": "monitor",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateUptimeMonitorRequest"
-                        }
+                        "type": "string",
+                        "description": "File Download UID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1977,22 +1972,79 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.UptimeMonitorSwagger"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.FileSwagger"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/files/{code}/download": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an existing uptime monitor",
+                "description": "Download a generated file, such as a payment report, of the authenticated user. Files are only available until their scheduled deletion time",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Download a generated file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/settings": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user notification allowance and Expo push token",
                 "consumes": [
                     "application/json"
                 ],
@@ -2000,16 +2052,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "uptime_monitors"
+                    "notifications"
                 ],
-                "summary": "Delete an uptime monitor",
+                "summary": "Update notification settings",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Monitor ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Notification settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UpdateNotificationSettingsRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2018,18 +2072,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.Response"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
                     }
                 }
             }
         },
-        "/users": {
+        "/payment-accounts": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of users",
+                "description": "Get a paginated list of payment accounts. Archived accounts are left out unless include_archived is set",
                 "consumes": [
                     "application/json"
                 ],
@@ -2037,9 +2109,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "payment_accounts"
                 ],
-                "summary": "List users",
+                "summary": "List payment accounts",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2050,10 +2122,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 15,
+                        "default": 10,
                         "description": "Items per page",
                         "name": "per_page",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include archived accounts",
+                        "name": "include_archived",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2070,7 +2149,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/controllers.UserSwagger"
+                                                "$ref": "#/definitions/controllers.PaymentAccountSwagger"
                                             }
                                         }
                                     }
@@ -2090,12 +2169,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2103,16 +2176,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/me": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about the authenticated user",
+                "description": "Create a payment account, the deposit is its opening balance. Credit card and loan accounts may open with a negative balance, their debt. Amounts are in minor units of the account currency, IDR by default",
                 "consumes": [
                     "application/json"
                 ],
@@ -2120,12 +2191,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "payment_accounts"
+                ],
+                "summary": "Create a payment account",
+                "parameters": [
+                    {
+                        "description": "Payment account data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorePaymentAccountRequest"
+                        }
+                    }
                 ],
-                "summary": "Get current user details",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -2135,7 +2217,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.UserSwagger"
+                                            "$ref": "#/definitions/controllers.PaymentAccountSwagger"
                                         }
                                     }
                                 }
@@ -2154,29 +2236,82 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.UnauthorizedResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a payment account of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Get payment account details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentAccountSwagger"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/utils.SimpleErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific user",
+                "description": "Update the name, type, currency, logo, balance or credit details of a payment account. Balance changes are recorded in the activity log, the currency can only change while no payments are booked on the account",
                 "consumes": [
                     "application/json"
                 ],
@@ -2184,16 +2319,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "payment_accounts"
                 ],
-                "summary": "Get user details",
+                "summary": "Update a payment account",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Payment account ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Payment account data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePaymentAccountRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2208,7 +2352,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.UserSwagger"
+                                            "$ref": "#/definitions/controllers.PaymentAccountSwagger"
                                         }
                                     }
                                 }
@@ -2233,267 +2377,7197 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.SimpleErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "controllers.AccountInfoSwagger": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "controllers.ActivityLogSwagger": {
-            "type": "object",
-            "properties": {
-                "batch_uuid": {
-                    "type": "string"
-                },
-                "causer_id": {
-                    "type": "integer"
-                },
-                "causer_type": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a payment account. Accounts that payments or recurring payments still book on cannot be deleted, archive them instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Delete a payment account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hide a payment account from the account list. Its payments and balance are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Archive a payment account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentAccountSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/balance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the balance of a payment account at the end of the given day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Get payment account balance on a date",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (yyyy-mm-dd), defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BalanceAtResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/balance-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the closing balance of a payment account for every day, week or month between from and to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Get payment account balance history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (yyyy-mm-dd), defaults to 30 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (yyyy-mm-dd), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Interval",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BalanceHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Parse a CSV, OFX or QIF bank statement into a preview of payments with likely duplicates flagged. CSV files use the mapping sent with the upload or the saved mapping of the account.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Import a bank statement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Bank statement file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ofx",
+                            "qif"
+                        ],
+                        "type": "string",
+                        "description": "File format, guessed from the extension when empty",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date format for CSV and QIF files, e.g. DD/MM/YYYY",
+                        "name": "date_format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": ",",
+                        "description": "CSV delimiter",
+                        "name": "delimiter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Whether the CSV file has a header row",
+                        "name": "has_header",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV date column (header name or zero based index)",
+                        "name": "date_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV description column",
+                        "name": "name_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV signed amount column",
+                        "name": "amount_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV debit column, used when there is no amount column",
+                        "name": "debit_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV credit column, used when there is no amount column",
+                        "name": "credit_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Save the CSV mapping for the next imports",
+                        "name": "save_mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaymentImportPreviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/import-mapping": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the saved CSV column mapping of a payment account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Get the CSV import mapping",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentImportMapping"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save the CSV column mapping of a payment account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Save the CSV import mapping",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Column mapping",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentImportMappingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentImportMapping"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/import/{importId}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Book the selected rows of an import preview as payments in one transaction. Without rows, every row not flagged as a duplicate is booked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Confirm a bank statement import",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Import ID",
+                        "name": "importId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rows to import",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmPaymentImportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaymentImportPreviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transfer money from a funding account into a credit card or loan account, lowering its debt. The amount defaults to the whole outstanding balance and may not exceed it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Pay a credit card or loan account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Credit card or loan account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Funding account, amount and date",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayCreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/reconcile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the balance of a payment account, its last reconciliation and the payments not cleared yet. With statement_balance the difference to the book balance is included",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Preview a payment account reconciliation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Balance on the actual statement",
+                        "name": "statement_balance",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReconciliationPreviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record the balance of the actual statement and mark the selected payments as cleared. With create_adjustment an income or expense payment is booked for the difference and cleared as well. Later changes to cleared payments flag the reconciliation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Reconcile a payment account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reconciliation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReconcileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReconcileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/reconciliations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the reconciliations of a payment account, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "List payment account reconciliations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PaymentReconciliation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-accounts/{id}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Show an archived payment account in the account list again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_accounts"
+                ],
+                "summary": "Unarchive a payment account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentAccountSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the payment categories of the authenticated user as a tree, or as a flat list with flat=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_categories"
+                ],
+                "summary": "List payment categories",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Return a flat list instead of a tree",
+                        "name": "flat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PaymentCategory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a payment category, nested under parent_id when given. Categories can be nested up to three levels deep",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_categories"
+                ],
+                "summary": "Create a payment category",
+                "parameters": [
+                    {
+                        "description": "Payment category data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorePaymentCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentCategory"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-categories/suggest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suggest categories for a payment name from the categories of past payments with a similar name, best match first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_categories"
+                ],
+                "summary": "Suggest a payment category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CategorySuggestionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-categories/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename, recolor or move a payment category. A parent_id of 0 moves it to the top level",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_categories"
+                ],
+                "summary": "Update a payment category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment category data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePaymentCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentCategory"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a payment category without subcategories, its payments become uncategorized and its budgets are deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_categories"
+                ],
+                "summary": "Delete a payment category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-goals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of payment goals for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-goals"
+                ],
+                "summary": "List payment goals",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.PaymentGoalSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a savings goal. The amount already saved is optional, progress and status follow from it and the target date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-goals"
+                ],
+                "summary": "Create a payment goal",
+                "parameters": [
+                    {
+                        "description": "Payment goal data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorePaymentGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentGoalSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-goals/overview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get overview statistics of payment goals for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-goals"
+                ],
+                "summary": "Get payment goals overview",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.OverviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-goals/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific payment goal",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-goals"
+                ],
+                "summary": "Get payment goal details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentGoalSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the name, description, target or dates of a payment goal. The saved amount only changes through contributions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-goals"
+                ],
+                "summary": "Update a payment goal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment goal data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePaymentGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentGoalSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a payment goal. The payments contributed to it are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-goals"
+                ],
+                "summary": "Delete a payment goal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-goals/{id}/contributions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the contributions to a payment goal with the payments that moved the money, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-goals"
+                ],
+                "summary": "List payment goal contributions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PaymentGoalContribution"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move money from a payment account toward a payment goal. The contribution is booked as an expense, or as a transfer when payment_account_to_id names the account the money is saved on, and completes the goal once its target is reached",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-goals"
+                ],
+                "summary": "Contribute to a payment goal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contribution data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContributePaymentGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ContributePaymentGoalResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the payment tags of the authenticated user with the number of payments using each tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_tags"
+                ],
+                "summary": "List payment tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by tag name",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PaymentTag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a payment tag. Tag names are stored in lowercase and are unique per user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_tags"
+                ],
+                "summary": "Create a payment tag",
+                "parameters": [
+                    {
+                        "description": "Payment tag data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorePaymentTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentTag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-tags/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a payment tag, the payments using it keep the tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_tags"
+                ],
+                "summary": "Rename a payment tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment tag data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorePaymentTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PaymentTag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a payment tag and remove it from every payment using it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_tags"
+                ],
+                "summary": "Delete a payment tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-types": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of payment types",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment_types"
+                ],
+                "summary": "List payment types",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.PaymentTypeSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of payments with optional filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "List payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Type ID (1: Expense, 2: Income, 3: Transfer, 4: Withdrawal)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID, also matches its subcategories",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, matches payments with any of the tags",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only scheduled payments the scheduler failed to publish",
+                        "name": "publish_failed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.PaymentSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Create payment",
+                "parameters": [
+                    {
+                        "description": "Payment data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the filtered payments as a CSV or XLSX file",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Export payments",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Type ID (1: Expense, 2: Income, 3: Transfer, 4: Withdrawal)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID, also matches its subcategories",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names, matches payments with any of the tags",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/generate-report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a payment report (daily, monthly, or date range) as PDF and CSV files. The returned UID lists the files through /files/d/{uid}.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Generate payment report",
+                "parameters": [
+                    {
+                        "description": "Report parameters",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GenerateReportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.GenerateReportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get summary of payments within a date range. Amounts of other currencies are converted into the chosen currency, payments at the latest exchange rate on the end date and balances at the latest rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get payment summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "IDR",
+                        "description": "Currency to report in",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.SummaryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get payment details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a payment and re-apply its effect on the payment account balances",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Update payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a payment and reverse its effect on the payment account balances",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Delete payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of attachments for a specific payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get payment attachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.AttachmentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/items/attach-multiple": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach multiple items to a specific payment and update payment amount and name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Attach multiple items to a payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Items to attach",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachMultipleItemsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/items/attached": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of items attached to a specific payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get attached items for a payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.PaymentItemAttachedSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/items/not-attached": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of items not attached to a specific payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get not attached items for a payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or code",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.ItemNotAttachedSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/items/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get summary of items attached to a specific payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get payment items summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentItemSummarySwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn a draft or scheduled payment into a real transaction and update the account balances",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Publish payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.PaymentSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of recurring payment rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring_payments"
+                ],
+                "summary": "List recurring payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.RecurringPaymentSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a recurring payment rule. Upcoming occurrences are generated as scheduled payments by the scheduler.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring_payments"
+                ],
+                "summary": "Create a recurring payment",
+                "parameters": [
+                    {
+                        "description": "Recurring payment data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StoreRecurringPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.RecurringPaymentSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-payments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single recurring payment rule by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring_payments"
+                ],
+                "summary": "Get recurring payment details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.RecurringPaymentSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the template, end date, count or active state of a recurring payment rule. The schedule itself cannot be changed; create a new rule instead. Changing the type to income or expense clears the destination account. Payments that were already generated are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring_payments"
+                ],
+                "summary": "Update a recurring payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recurring payment data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateRecurringPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.RecurringPaymentSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a recurring payment rule together with the generated payments that are still scheduled. Booked payments are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring_payments"
+                ],
+                "summary": "Delete a recurring payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-payments/{id}/occurrences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the upcoming occurrences of a recurring payment rule, with any skip or override applied",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring_payments"
+                ],
+                "summary": "Preview upcoming occurrences",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of occurrences",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RecurringPaymentOccurrenceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Skip a single upcoming occurrence, or override its name, amount or payment account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring_payments"
+                ],
+                "summary": "Skip or override a single occurrence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Occurrence exception",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecurringPaymentOccurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RecurringPaymentOccurrence"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-payments/{id}/occurrences/{occurrenceId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a skip or override so the occurrence follows the rule again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring_payments"
+                ],
+                "summary": "Remove an occurrence exception",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recurring payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Occurrence exception ID",
+                        "name": "occurrenceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uptime-monitor-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of uptime monitor logs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitor_logs"
+                ],
+                "summary": "List uptime monitor logs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by monitor ID",
+                        "name": "monitor_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.UptimeMonitorLogSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Store a new uptime monitor log",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitor_logs"
+                ],
+                "summary": "Store a new uptime monitor log",
+                "parameters": [
+                    {
+                        "description": "Log data",
+                        "name": "log",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StoreUptimeMonitorLogRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UptimeMonitorLogSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/uptime-monitor-logs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single uptime monitor log by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitor_logs"
+                ],
+                "summary": "Get uptime monitor log details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UptimeMonitorLogSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an existing uptime monitor log",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitor_logs"
+                ],
+                "summary": "Delete an uptime monitor log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/uptime-monitors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of uptime monitors",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitors"
+                ],
+                "summary": "List uptime monitors",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or code",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.UptimeMonitorSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Store a new uptime monitor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitors"
+                ],
+                "summary": "Store a new uptime monitor",
+                "parameters": [
+                    {
+                        "description": "Monitor data",
+                        "name": "monitor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StoreUptimeMonitorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UptimeMonitorSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/uptime-monitors/run-checks": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run uptime monitor checks for all active monitors that are due",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitors"
+                ],
+                "summary": "Run uptime monitor checks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/uptime-monitors/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single uptime monitor by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitors"
+                ],
+                "summary": "Get uptime monitor details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Monitor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UptimeMonitorSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing uptime monitor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitors"
+                ],
+                "summary": "Update an uptime monitor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Monitor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Monitor data",
+                        "name": "monitor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUptimeMonitorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UptimeMonitorSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an existing uptime monitor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uptime_monitors"
+                ],
+                "summary": "Delete an uptime monitor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Monitor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of users. Admins see every user, members only see themselves",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 15,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/controllers.UserSwagger"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a user with a verified email address. Requires an admin account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StoreUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UserSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get current user details",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UserSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific user. Members can only get themselves",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UserSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the name, email, password or role of a user. Empty fields are left unchanged, a new password signs the user out everywhere. Requires an admin account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UserSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a user and revoke every token of the user. Requires an admin account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allow a deactivated user to sign in again. Requires an admin account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Activate a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UserSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Block a user from signing in and revoke every token of the user. Requires an admin account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Deactivate a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.UserSwagger"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clear the failed login attempts and the login lock of a user. Requires an admin account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Unlock a user account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.SimpleErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.AccountInfoSwagger": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ActivityLogSwagger": {
+            "type": "object",
+            "properties": {
+                "batch_uuid": {
+                    "type": "string"
+                },
+                "causer_id": {
+                    "type": "integer"
+                },
+                "causer_type": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "geolocation": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "log_name": {
+                    "type": "string"
+                },
+                "postal": {
+                    "type": "string"
+                },
+                "prev_properties": {
+                    "type": "string"
+                },
+                "properties": {
+                    "type": "string"
+                },
+                "referer": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "subject_id": {
+                    "type": "integer"
+                },
+                "subject_type": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "extension": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "filepath": {
+                    "type": "string"
+                },
+                "formatted_size": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "original": {
+                    "$ref": "#/definitions/controllers.OriginalAttachment"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.FileSwagger": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "download_url": {
+                    "type": "string"
+                },
+                "file_alias": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.FormattedAccountSwagger": {
+            "type": "object",
+            "properties": {
+                "deposit": {
+                    "type": "string"
+                },
+                "logo": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.FormattedGoalSwagger": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "string"
+                },
+                "target_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ItemNotAttachedSwagger": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "formatted_amount": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "type_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.OriginalAttachment": {
+            "type": "object",
+            "properties": {
+                "formatted_size": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.OverviewResponse": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "success_rate": {
+                    "type": "string"
+                },
+                "total_goals": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.PaymentAccountSwagger": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "credit": {
+                    "$ref": "#/definitions/models.CreditSummary"
+                },
+                "credit_limit": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "deposit": {
+                    "type": "integer"
+                },
+                "due_day": {
+                    "type": "integer"
+                },
+                "formatted": {
+                    "$ref": "#/definitions/controllers.FormattedAccountSwagger"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "statement_day": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "bank"
+                }
+            }
+        },
+        "controllers.PaymentGoalSwagger": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "formatted": {
+                    "$ref": "#/definitions/controllers.FormattedGoalSwagger"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "progress_percent": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_id": {
+                    "type": "integer"
+                },
+                "target_amount": {
+                    "type": "integer"
+                },
+                "target_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.PaymentItemAttachedSwagger": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "formatted_price": {
+                    "type": "string"
+                },
+                "formatted_total": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "type_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.PaymentItemSummarySwagger": {
+            "type": "object",
+            "properties": {
+                "formatted_amount": {
+                    "type": "string"
+                },
+                "payment_code": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.PaymentSwagger": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/controllers.AccountInfoSwagger"
+                },
+                "account_to": {
+                    "$ref": "#/definitions/controllers.AccountInfoSwagger"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_to": {
+                    "type": "integer"
+                },
+                "attachments_count": {
+                    "type": "integer"
+                },
+                "category": {
+                    "$ref": "#/definitions/models.PaymentCategory"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "number"
+                },
+                "formatted_amount": {
+                    "type": "string"
+                },
+                "formatted_amount_to": {
+                    "type": "string"
+                },
+                "formatted_date": {
+                    "type": "string"
+                },
+                "formatted_updated_at": {
+                    "type": "string"
+                },
+                "has_items": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_draft": {
+                    "type": "boolean"
+                },
+                "is_scheduled": {
+                    "type": "boolean"
+                },
+                "items_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "payment_account_to_id": {
+                    "type": "integer"
+                },
+                "publish_attempts": {
+                    "type": "integer"
+                },
+                "publish_error": {
+                    "type": "string",
+                    "example": "Insufficient balance for this payment account (e01)"
+                },
+                "publish_failed_at": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentTag"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "type_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.PaymentTypeSwagger": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.RecurringPaymentItemSwagger": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.RecurringPaymentSwagger": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "day_of_month": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "formatted_amount": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "generated_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.RecurringPaymentItemSwagger"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_date": {
+                    "type": "string"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "payment_account_to_id": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "type_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.SummaryCategory": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.SummaryCategory"
+                    }
+                },
+                "expenses": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "income": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "Food \u003e Groceries"
+                }
+            }
+        },
+        "controllers.SummaryPercents": {
+            "type": "object",
+            "properties": {
+                "expenses": {
+                    "type": "number"
+                },
+                "income": {
+                    "type": "number"
+                },
+                "transfer": {
+                    "type": "number"
+                },
+                "withdrawal": {
+                    "type": "number"
+                }
+            }
+        },
+        "controllers.SummaryPeriod": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.SummaryResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.SummaryCategory"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "exchange_rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "expenses": {
+                    "type": "integer"
+                },
+                "income": {
+                    "type": "integer"
+                },
+                "initial_balance": {
+                    "type": "integer"
+                },
+                "percents": {
+                    "$ref": "#/definitions/controllers.SummaryPercents"
+                },
+                "period": {
+                    "$ref": "#/definitions/controllers.SummaryPeriod"
+                },
+                "scheduled_expense": {
+                    "type": "integer"
+                },
+                "total_after_scheduled": {
+                    "type": "integer"
+                },
+                "total_balance": {
+                    "type": "integer"
+                },
+                "transfer": {
+                    "type": "integer"
+                },
+                "withdrawal": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.UpdateNotificationSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "has_allow_notification": {
+                    "type": "integer"
+                },
+                "notification_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.UptimeMonitorLogSwagger": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_healthy": {
+                    "type": "boolean"
+                },
+                "response_time_ms": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "uptime_monitor_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.UptimeMonitorSwagger": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "healthy_checks": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_checked_at": {
+                    "type": "string"
+                },
+                "last_healthy_at": {
+                    "type": "string"
+                },
+                "last_unhealthy_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_check_at": {
+                    "type": "string"
+                },
+                "total_checks": {
+                    "type": "integer"
+                },
+                "unhealthy_checks": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.UserSwagger": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deactivated_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "has_allow_notification": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "id-ID"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "member"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Jakarta"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AttachMultipleItemsItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AttachMultipleItemsRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AttachMultipleItemsItem"
+                    }
+                },
+                "totalAmount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BalanceAtResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-03-01"
+                },
+                "formatted_balance": {
+                    "type": "string"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BalanceHistoryPoint": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "change": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-03-01"
+                },
+                "formatted_balance": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BalanceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "closing_balance": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-03-01"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "opening_balance": {
+                    "type": "integer"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BalanceHistoryPoint"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-03-31"
+                }
+            }
+        },
+        "dto.BudgetStatusResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "budget_id": {
+                    "type": "integer"
+                },
+                "carryover": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-10-31"
+                },
+                "formatted_amount": {
+                    "type": "string"
+                },
+                "formatted_available": {
+                    "type": "string"
+                },
+                "formatted_remaining": {
+                    "type": "string"
+                },
+                "formatted_spent": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 82.5
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2026-10"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "category"
+                },
+                "spent": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-10-01"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "on_track",
+                        "warning",
+                        "exceeded"
+                    ],
+                    "example": "warning"
+                }
+            }
+        },
+        "dto.CategorySuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "confidence": {
+                    "type": "number",
+                    "example": 0.75
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "Food \u003e Groceries"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password",
+                "new_password_confirmation"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "new_password_confirmation": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.ConfirmPaymentImportRequest": {
+            "type": "object",
+            "properties": {
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.ContributePaymentGoalRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 500000
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-18"
+                },
+                "payment_account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "payment_account_to_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.ContributePaymentGoalResponse": {
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "$ref": "#/definitions/models.PaymentGoalContribution"
+                },
+                "goal": {
+                    "$ref": "#/definitions/models.PaymentGoal"
+                },
+                "payment": {
+                    "$ref": "#/definitions/models.Payment"
+                }
+            }
+        },
+        "dto.DisableTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GenerateReportRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "periode": {
+                    "type": "string"
+                },
+                "report_type": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GenerateReportResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImportExchangeRatesResponse": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "abilities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "payments:read"
+                    ]
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "token_name": {
+                    "type": "string",
+                    "example": "auth_token"
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PayCreditRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-18"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PaymentImportMappingRequest": {
+            "type": "object",
+            "properties": {
+                "amount_column": {
+                    "type": "string"
+                },
+                "credit_column": {
+                    "type": "string"
+                },
+                "date_column": {
+                    "type": "string"
+                },
+                "date_format": {
+                    "type": "string"
+                },
+                "debit_column": {
+                    "type": "string"
+                },
+                "delimiter": {
+                    "type": "string"
+                },
+                "has_header": {
+                    "type": "boolean"
+                },
+                "name_column": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PaymentImportPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "duplicate_rows": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "imported_rows": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentImportRow"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReconcileRequest": {
+            "type": "object",
+            "properties": {
+                "cleared_payment_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "create_adjustment": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-18"
+                },
+                "statement_balance": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReconcileResponse": {
+            "type": "object",
+            "properties": {
+                "adjustment_payment": {
+                    "$ref": "#/definitions/models.Payment"
+                },
+                "reconciliation": {
+                    "$ref": "#/definitions/models.PaymentReconciliation"
+                }
+            }
+        },
+        "dto.ReconciliationPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "book_balance": {
+                    "type": "integer"
+                },
+                "difference": {
+                    "type": "integer"
+                },
+                "last_reconciliation": {
+                    "$ref": "#/definitions/models.PaymentReconciliation"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "statement_balance": {
+                    "type": "integer"
+                },
+                "unreconciled_payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Payment"
+                    }
+                }
+            }
+        },
+        "dto.RecurringPaymentOccurrenceRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RecurringPaymentOccurrenceResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "exception_id": {
+                    "type": "integer"
+                },
+                "formatted_amount": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password",
+                "password_confirmation"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "password_confirmation": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "password_confirmation",
+                "token"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "password_confirmation": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StoreActivityLogRequest": {
+            "type": "object",
+            "properties": {
+                "batch_uuid": {
+                    "type": "string"
+                },
+                "causer_id": {
+                    "type": "integer"
+                },
+                "causer_type": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "geolocation": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "log_name": {
+                    "type": "string"
+                },
+                "postal": {
+                    "type": "string"
+                },
+                "prev_properties": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "properties": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "referer": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "subject_id": {
+                    "type": "integer"
+                },
+                "subject_type": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StoreBudgetRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 2000000
+                },
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                },
+                "payment_account_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rollover": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "category",
+                        "account"
+                    ],
+                    "example": "category"
+                }
+            }
+        },
+        "dto.StoreExchangeRateRequest": {
+            "type": "object",
+            "properties": {
+                "base_currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-18"
+                },
+                "quote_currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 16250
+                }
+            }
+        },
+        "dto.StorePaymentAccountRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "credit_limit": {
+                    "type": "integer",
+                    "example": 10000000
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "deposit": {
+                    "type": "integer"
+                },
+                "due_day": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "logo": {
+                    "type": "string",
+                    "example": "images/payment_account/bca.png"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "statement_day": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "bank",
+                        "e_wallet",
+                        "credit_card",
+                        "loan"
+                    ],
+                    "example": "bank"
+                }
+            }
+        },
+        "dto.StorePaymentCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#4CAF50"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.StorePaymentGoalRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Six months of expenses"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Emergency fund"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-10-01"
+                },
+                "target_amount": {
+                    "type": "integer",
+                    "example": 30000000
+                },
+                "target_date": {
+                    "type": "string",
+                    "example": "2027-09-30"
+                }
+            }
+        },
+        "dto.StorePaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_to": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "has_items": {
+                    "type": "boolean"
+                },
+                "is_draft": {
+                    "type": "boolean"
+                },
+                "is_scheduled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "payment_account_to_id": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "groceries",
+                        "weekly"
+                    ]
+                },
+                "type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.StorePaymentTagRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "groceries"
+                }
+            }
+        },
+        "dto.StoreRecurringPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "day_of_month": {
+                    "type": "integer"
+                },
+                "end_date": {
                     "type": "string"
                 },
-                "city": {
+                "frequency": {
                     "type": "string"
                 },
-                "country": {
+                "interval": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AttachMultipleItemsItem"
+                    }
+                },
+                "name": {
                     "type": "string"
                 },
-                "description": {
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "payment_account_to_id": {
+                    "type": "integer"
+                },
+                "start_date": {
                     "type": "string"
                 },
-                "event": {
+                "type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.StoreTokenRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "abilities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "payments:read"
+                    ]
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2027-01-31"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "reporting"
+                }
+            }
+        },
+        "dto.StoreTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "$ref": "#/definitions/dto.TokenResponse"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StoreUptimeMonitorLogRequest": {
+            "type": "object",
+            "required": [
+                "checked_at",
+                "response_time_ms",
+                "status_code",
+                "uptime_monitor_id"
+            ],
+            "properties": {
+                "checked_at": {
                     "type": "string"
                 },
-                "geolocation": {
+                "error_message": {
+                    "type": "string"
+                },
+                "is_healthy": {
+                    "type": "boolean"
+                },
+                "response_time_ms": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "uptime_monitor_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.StoreUptimeMonitorRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "interval",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StoreUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "role": {
+                    "type": "string",
+                    "example": "member"
+                }
+            }
+        },
+        "dto.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "abilities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TwoFactorChallengeRequest": {
+            "type": "object",
+            "required": [
+                "challenge_token"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "recovery_code": {
+                    "type": "string",
+                    "example": "a1b2c-3d4e5"
+                }
+            }
+        },
+        "dto.TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "dto.TwoFactorRecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.TwoFactorSetupResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "type": "string"
+                },
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateBudgetRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 2000000
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                },
+                "rollover": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.UpdatePaymentAccountRequest": {
+            "type": "object",
+            "properties": {
+                "credit_limit": {
+                    "type": "integer",
+                    "example": 10000000
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "deposit": {
+                    "type": "integer"
+                },
+                "due_day": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "logo": {
+                    "type": "string",
+                    "example": "images/payment_account/bca.png"
+                },
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "statement_day": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "bank",
+                        "e_wallet",
+                        "credit_card",
+                        "loan"
+                    ],
+                    "example": "bank"
+                }
+            }
+        },
+        "dto.UpdatePaymentCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#4CAF50"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.UpdatePaymentGoalRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Six months of expenses"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Emergency fund"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-10-01"
+                },
+                "target_amount": {
+                    "type": "integer",
+                    "example": 30000000
+                },
+                "target_date": {
+                    "type": "string",
+                    "example": "2027-09-30"
+                }
+            }
+        },
+        "dto.UpdatePaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_to": {
                     "type": "integer"
                 },
-                "ip_address": {
-                    "type": "string"
+                "category_id": {
+                    "type": "integer"
                 },
-                "log_name": {
+                "date": {
                     "type": "string"
                 },
-                "postal": {
+                "name": {
                     "type": "string"
                 },
-                "prev_properties": {
-                    "type": "string"
+                "payment_account_id": {
+                    "type": "integer"
                 },
-                "properties": {
-                    "type": "string"
+                "payment_account_to_id": {
+                    "type": "integer"
                 },
-                "referer": {
-                    "type": "string"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "groceries",
+                        "weekly"
+                    ]
                 },
-                "region": {
+                "type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.UpdateProfileRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "subject_id": {
-                    "type": "integer"
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "id-ID",
+                        "en-US",
+                        "en-GB"
+                    ],
+                    "example": "en-US"
                 },
-                "subject_type": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "minLength": 3
                 },
                 "timezone": {
-                    "type": "string"
-                },
-                "user_agent": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Asia/Jakarta"
                 }
             }
         },
-        "controllers.AttachmentResponse": {
+        "dto.UpdateRecurringPaymentRequest": {
             "type": "object",
             "properties": {
-                "extension": {
-                    "type": "string"
+                "amount": {
+                    "type": "integer"
                 },
-                "filename": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "filepath": {
+                "end_date": {
                     "type": "string"
                 },
-                "formatted_size": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AttachMultipleItemsItem"
+                    }
+                },
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "payment_account_id": {
                     "type": "integer"
                 },
-                "original": {
-                    "$ref": "#/definitions/controllers.OriginalAttachment"
+                "payment_account_to_id": {
+                    "type": "integer"
                 },
-                "url": {
-                    "type": "string"
+                "type_id": {
+                    "type": "integer"
                 }
             }
         },
-        "controllers.FileSwagger": {
+        "dto.UpdateUptimeMonitorRequest": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string"
                 },
-                "download_url": {
-                    "type": "string"
+                "interval": {
+                    "type": "integer"
                 },
-                "file_alias": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "file_name": {
+                "name": {
                     "type": "string"
                 },
-                "file_size": {
+                "url": {
                     "type": "string"
-                },
-                "id": {
-                    "type": "integer"
                 }
             }
         },
-        "controllers.FormattedAccountSwagger": {
+        "dto.UpdateUserRequest": {
             "type": "object",
             "properties": {
-                "deposit": {
+                "email": {
                     "type": "string"
                 },
-                "logo": {
+                "name": {
+                    "type": "string"
+                },
+                "password": {
                     "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "member"
                 }
             }
         },
-        "controllers.FormattedGoalSwagger": {
+        "dto.ValidateTokenResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "string"
-                },
-                "progress": {
-                    "type": "string"
-                },
-                "start_date": {
-                    "type": "string"
-                },
-                "target_amount": {
-                    "type": "string"
-                },
-                "target_date": {
-                    "type": "string"
+                "user": {
+                    "$ref": "#/definitions/dto.ValidateTokenUserResponse"
                 }
             }
         },
-        "controllers.ItemNotAttachedSwagger": {
+        "dto.ValidateTokenUserResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "integer"
-                },
                 "code": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "formatted_amount": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
-                },
-                "type": {
-                    "type": "string"
-                },
-                "type_id": {
-                    "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "controllers.OriginalAttachment": {
+        "dto.VerifyEmailRequest": {
             "type": "object",
+            "required": [
+                "expires",
+                "hash",
+                "id",
+                "signature"
+            ],
             "properties": {
-                "formatted_size": {
+                "expires": {
                     "type": "string"
                 },
-                "url": {
+                "hash": {
                     "type": "string"
-                }
-            }
-        },
-        "controllers.OverviewResponse": {
-            "type": "object",
-            "properties": {
-                "completed": {
-                    "type": "integer"
                 },
-                "success_rate": {
+                "id": {
                     "type": "string"
                 },
-                "total_goals": {
-                    "type": "integer"
+                "signature": {
+                    "type": "string"
                 }
             }
         },
-        "controllers.PaymentAccountSwagger": {
+        "models.AccountInfo": {
             "type": "object",
             "properties": {
-                "deposit": {
-                    "type": "integer"
-                },
-                "formatted": {
-                    "$ref": "#/definitions/controllers.FormattedAccountSwagger"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "logo": {
-                    "type": "string"
-                },
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "controllers.PaymentGoalSwagger": {
+        "models.Budget": {
             "type": "object",
             "properties": {
                 "amount": {
                     "type": "integer"
                 },
-                "code": {
-                    "type": "string"
+                "category": {
+                    "$ref": "#/definitions/models.PaymentCategory"
+                },
+                "category_id": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "currency": {
                     "type": "string"
                 },
-                "formatted": {
-                    "$ref": "#/definitions/controllers.FormattedGoalSwagger"
+                "formatted_amount": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
@@ -2501,117 +9575,183 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "progress_percent": {
+                "payment_account": {
+                    "$ref": "#/definitions/models.PaymentAccount"
+                },
+                "payment_account_id": {
                     "type": "integer"
                 },
-                "start_date": {
+                "rollover": {
+                    "type": "boolean"
+                },
+                "scope": {
                     "type": "string"
                 },
-                "status": {
+                "updated_at": {
                     "type": "string"
                 },
-                "status_id": {
+                "user_id": {
                     "type": "integer"
-                },
-                "target_amount": {
+                }
+            }
+        },
+        "models.CreditSummary": {
+            "type": "object",
+            "properties": {
+                "available": {
                     "type": "integer"
                 },
-                "target_date": {
+                "formatted_outstanding": {
                     "type": "string"
                 },
-                "updated_at": {
+                "next_due_at": {
                     "type": "string"
                 },
-                "user_id": {
+                "next_statement_at": {
+                    "type": "string"
+                },
+                "outstanding": {
                     "type": "integer"
                 }
             }
         },
-        "controllers.PaymentItemAttachedSwagger": {
+        "models.ExchangeRate": {
             "type": "object",
             "properties": {
-                "code": {
+                "base_currency": {
                     "type": "string"
                 },
-                "formatted_price": {
+                "created_at": {
                     "type": "string"
                 },
-                "formatted_total": {
+                "date": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "quote_currency": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "integer"
+                "rate": {
+                    "type": "number"
                 },
-                "quantity": {
-                    "type": "integer"
+                "source": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Formatted": {
+            "type": "object",
+            "properties": {
+                "deposit": {
+                    "type": "string"
                 },
-                "type": {
+                "logo": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FormattedGoal": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "string"
                 },
-                "type_id": {
-                    "type": "integer"
+                "progress": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "target_date": {
                     "type": "string"
                 }
             }
         },
-        "controllers.PaymentItemSummarySwagger": {
+        "models.LoginAttempt": {
             "type": "object",
             "properties": {
-                "formatted_amount": {
+                "created_at": {
                     "type": "string"
                 },
-                "payment_code": {
+                "email": {
                     "type": "string"
                 },
-                "payment_id": {
+                "id": {
                     "type": "integer"
                 },
-                "total_amount": {
-                    "type": "integer"
+                "ip_address": {
+                    "type": "string"
                 },
-                "total_items": {
-                    "type": "integer"
+                "reason": {
+                    "type": "string"
                 },
-                "total_qty": {
+                "successful": {
+                    "type": "boolean"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "controllers.PaymentSwagger": {
+        "models.Payment": {
             "type": "object",
             "properties": {
                 "account": {
-                    "$ref": "#/definitions/controllers.AccountInfoSwagger"
+                    "$ref": "#/definitions/models.AccountInfo"
                 },
                 "account_to": {
-                    "$ref": "#/definitions/controllers.AccountInfoSwagger"
+                    "$ref": "#/definitions/models.AccountInfo"
                 },
                 "amount": {
                     "type": "integer"
                 },
+                "amount_to": {
+                    "type": "integer"
+                },
                 "attachments_count": {
                     "type": "integer"
                 },
+                "category": {
+                    "$ref": "#/definitions/models.PaymentCategory"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "cleared_at": {
+                    "type": "string"
+                },
                 "code": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
                 "date": {
                     "type": "string"
                 },
+                "exchange_rate": {
+                    "type": "number"
+                },
                 "formatted_amount": {
                     "type": "string"
                 },
+                "formatted_amount_to": {
+                    "type": "string"
+                },
                 "formatted_date": {
                     "type": "string"
                 },
@@ -2642,373 +9782,241 @@ const docTemplate = `{
                 "payment_account_to_id": {
                     "type": "integer"
                 },
-                "type": {
-                    "type": "string"
-                },
-                "type_id": {
-                    "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "controllers.PaymentTypeSwagger": {
-            "type": "object",
-            "properties": {
-                "id": {
+                "publish_attempts": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "controllers.SummaryPercents": {
-            "type": "object",
-            "properties": {
-                "expenses": {
-                    "type": "number"
-                },
-                "income": {
-                    "type": "number"
-                },
-                "transfer": {
-                    "type": "number"
-                },
-                "withdrawal": {
-                    "type": "number"
-                }
-            }
-        },
-        "controllers.SummaryPeriod": {
-            "type": "object",
-            "properties": {
-                "end_date": {
+                "publish_error": {
                     "type": "string"
                 },
-                "start_date": {
+                "publish_failed_at": {
                     "type": "string"
-                }
-            }
-        },
-        "controllers.SummaryResponse": {
-            "type": "object",
-            "properties": {
-                "expenses": {
-                    "type": "integer"
-                },
-                "income": {
-                    "type": "integer"
                 },
-                "initial_balance": {
+                "reconciliation_id": {
                     "type": "integer"
                 },
-                "percents": {
-                    "$ref": "#/definitions/controllers.SummaryPercents"
-                },
-                "period": {
-                    "$ref": "#/definitions/controllers.SummaryPeriod"
-                },
-                "scheduled_expense": {
-                    "type": "integer"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentTag"
+                    }
                 },
-                "total_after_scheduled": {
-                    "type": "integer"
+                "type": {
+                    "type": "string"
                 },
-                "total_balance": {
+                "type_id": {
                     "type": "integer"
                 },
-                "transfer": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 },
-                "withdrawal": {
-                    "type": "integer"
-                }
-            }
-        },
-        "controllers.UpdateNotificationSettingsRequest": {
-            "type": "object",
-            "properties": {
-                "has_allow_notification": {
+                "user_id": {
                     "type": "integer"
-                },
-                "notification_token": {
-                    "type": "string"
                 }
             }
         },
-        "controllers.UptimeMonitorLogSwagger": {
+        "models.PaymentAccount": {
             "type": "object",
             "properties": {
-                "checked_at": {
+                "archived_at": {
                     "type": "string"
                 },
-                "error_message": {
-                    "type": "string"
+                "credit": {
+                    "$ref": "#/definitions/models.CreditSummary"
                 },
-                "id": {
+                "credit_limit": {
                     "type": "integer"
                 },
-                "is_healthy": {
-                    "type": "boolean"
-                },
-                "response_time_ms": {
-                    "type": "integer"
+                "currency": {
+                    "type": "string"
                 },
-                "status_code": {
+                "deposit": {
                     "type": "integer"
                 },
-                "uptime_monitor_id": {
+                "due_day": {
                     "type": "integer"
-                }
-            }
-        },
-        "controllers.UptimeMonitorSwagger": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
                 },
-                "healthy_checks": {
-                    "type": "integer"
+                "formatted": {
+                    "$ref": "#/definitions/models.Formatted"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "interval": {
-                    "type": "integer"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "last_checked_at": {
-                    "type": "string"
-                },
-                "last_healthy_at": {
-                    "type": "string"
-                },
-                "last_unhealthy_at": {
+                "logo": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "next_check_at": {
-                    "type": "string"
-                },
-                "total_checks": {
-                    "type": "integer"
-                },
-                "unhealthy_checks": {
+                "statement_day": {
                     "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
-                },
-                "url": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "controllers.UserSwagger": {
+        "models.PaymentCategory": {
             "type": "object",
             "properties": {
-                "avatar_url": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentCategory"
+                    }
                 },
-                "deleted_at": {
+                "color": {
                     "type": "string"
                 },
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "has_allow_notification": {
-                    "type": "boolean"
-                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.AttachMultipleItemsItem": {
-            "type": "object",
-            "properties": {
-                "amount": {
+                "parent_id": {
                     "type": "integer"
                 },
-                "item_id": {
-                    "type": "integer"
+                "path": {
+                    "type": "string"
                 },
-                "name": {
+                "updated_at": {
                     "type": "string"
                 },
-                "qty": {
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.AttachMultipleItemsRequest": {
+        "models.PaymentGoal": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.AttachMultipleItemsItem"
-                    }
-                },
-                "totalAmount": {
+                "amount": {
                     "type": "integer"
-                }
-            }
-        },
-        "dto.ChangePasswordRequest": {
-            "type": "object",
-            "required": [
-                "current_password",
-                "new_password",
-                "new_password_confirmation"
-            ],
-            "properties": {
-                "current_password": {
-                    "type": "string",
-                    "minLength": 6
                 },
-                "new_password": {
-                    "type": "string",
-                    "minLength": 6
+                "code": {
+                    "type": "string"
                 },
-                "new_password_confirmation": {
-                    "type": "string",
-                    "minLength": 6
-                }
-            }
-        },
-        "dto.GenerateReportRequest": {
-            "type": "object",
-            "properties": {
-                "end_date": {
+                "created_at": {
                     "type": "string"
                 },
-                "periode": {
+                "description": {
                     "type": "string"
                 },
-                "report_type": {
+                "formatted": {
+                    "$ref": "#/definitions/models.FormattedGoal"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
+                "progress_percent": {
+                    "type": "integer"
+                },
                 "start_date": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.LoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
+                },
+                "status": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
-                }
-            }
-        },
-        "dto.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
+                "status_id": {
+                    "type": "integer"
+                },
+                "target_amount": {
+                    "type": "integer"
+                },
+                "target_date": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.StoreActivityLogRequest": {
+        "models.PaymentGoalContribution": {
             "type": "object",
             "properties": {
-                "batch_uuid": {
-                    "type": "string"
-                },
-                "causer_id": {
+                "amount": {
                     "type": "integer"
                 },
-                "causer_type": {
+                "created_at": {
                     "type": "string"
                 },
-                "city": {
+                "formatted_amount": {
                     "type": "string"
                 },
-                "country": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "description": {
-                    "type": "string"
+                "payment": {
+                    "$ref": "#/definitions/models.Payment"
                 },
-                "event": {
-                    "type": "string"
+                "payment_goal_id": {
+                    "type": "integer"
                 },
-                "geolocation": {
+                "payment_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "ip_address": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PaymentImportMapping": {
+            "type": "object",
+            "properties": {
+                "amount_column": {
                     "type": "string"
                 },
-                "log_name": {
+                "created_at": {
                     "type": "string"
                 },
-                "postal": {
+                "credit_column": {
                     "type": "string"
                 },
-                "prev_properties": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "date_column": {
+                    "type": "string"
                 },
-                "properties": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "date_format": {
+                    "type": "string"
                 },
-                "referer": {
+                "debit_column": {
                     "type": "string"
                 },
-                "region": {
+                "delimiter": {
                     "type": "string"
                 },
-                "subject_id": {
+                "has_header": {
+                    "type": "boolean"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "subject_type": {
+                "name_column": {
                     "type": "string"
                 },
-                "timezone": {
-                    "type": "string"
+                "payment_account_id": {
+                    "type": "integer"
                 },
-                "user_agent": {
+                "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.StorePaymentRequest": {
+        "models.PaymentImportRow": {
             "type": "object",
             "properties": {
                 "amount": {
@@ -3017,139 +10025,175 @@ const docTemplate = `{
                 "date": {
                     "type": "string"
                 },
-                "has_items": {
-                    "type": "boolean"
+                "duplicate_of_id": {
+                    "type": "integer"
                 },
-                "is_draft": {
-                    "type": "boolean"
+                "index": {
+                    "type": "integer"
                 },
-                "is_scheduled": {
+                "is_duplicate": {
                     "type": "boolean"
                 },
                 "name": {
                     "type": "string"
                 },
-                "payment_account_id": {
+                "payment_id": {
                     "type": "integer"
                 },
-                "payment_account_to_id": {
-                    "type": "integer"
+                "reference": {
+                    "type": "string"
                 },
                 "type_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.StoreUptimeMonitorLogRequest": {
+        "models.PaymentReconciliation": {
             "type": "object",
-            "required": [
-                "checked_at",
-                "response_time_ms",
-                "status_code",
-                "uptime_monitor_id"
-            ],
             "properties": {
-                "checked_at": {
+                "adjustment_payment_id": {
+                    "type": "integer"
+                },
+                "book_balance": {
+                    "type": "integer"
+                },
+                "cleared_count": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "error_message": {
+                "difference": {
+                    "type": "integer"
+                },
+                "flagged_at": {
                     "type": "string"
                 },
-                "is_healthy": {
-                    "type": "boolean"
+                "id": {
+                    "type": "integer"
                 },
-                "response_time_ms": {
+                "payment_account_id": {
                     "type": "integer"
                 },
-                "status_code": {
+                "statement_balance": {
                     "type": "integer"
                 },
-                "uptime_monitor_id": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.StoreUptimeMonitorRequest": {
+        "models.PaymentTag": {
             "type": "object",
-            "required": [
-                "code",
-                "interval",
-                "name",
-                "url"
-            ],
             "properties": {
-                "code": {
+                "created_at": {
                     "type": "string"
                 },
-                "interval": {
+                "id": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
                 },
-                "url": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.UpdateProfileRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "name"
-            ],
-            "properties": {
-                "email": {
+                "payments_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "minLength": 3
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.UpdateUptimeMonitorRequest": {
+        "models.RecurringPaymentOccurrence": {
             "type": "object",
             "properties": {
-                "code": {
+                "action": {
                     "type": "string"
                 },
-                "interval": {
+                "amount": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "url": {
+                "payment_account_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "recurring_payment_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "dto.ValidateTokenResponse": {
-            "type": "object",
-            "properties": {
-                "user": {
-                    "$ref": "#/definitions/dto.ValidateTokenUserResponse"
-                }
-            }
-        },
-        "dto.ValidateTokenUserResponse": {
+        "models.User": {
             "type": "object",
             "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
                 "code": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "deactivated_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified_at": {
+                    "type": "string"
+                },
+                "has_allow_notification": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "locale": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "two_factor_confirmed_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
@@ -57,7 +57,8 @@ func ConnectDatabase() {
 	}
 
 	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: gormLogger,
+		Logger:                                   gormLogger,
+		DisableForeignKeyConstraintWhenMigrating: true,
 	})
 
 	if err != nil {
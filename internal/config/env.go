@@ -1,19 +1,30 @@
 package config
 
 import (
+	"encoding/base64"
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 var (
+	AppName string
 	AppURL  string
 	AppPort string
 	AppIP   string
 	CdnUrl  string
 	MainUrl string
+	AppKey  []byte
+
+	SchedulerEnabled bool
+
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	TokenCacheTTL   time.Duration
 
 	MailHost        string
 	MailPort        int
@@ -30,16 +41,28 @@ func LoadEnv() {
 		log.Println("Warning: .env file not found, using system environment variables")
 	}
 
+	AppName = os.Getenv("APP_NAME")
 	AppURL = os.Getenv("APP_URL")
 	AppPort = os.Getenv("APP_PORT")
 	AppIP = os.Getenv("APP_IP")
 	CdnUrl = os.Getenv("CDN_URL")
 	MainUrl = os.Getenv("MAIN_URL")
+	AppKey = appKey(os.Getenv("APP_KEY"))
+
+	if AppName == "" {
+		AppName = "Golang API"
+	}
 
 	if AppPort == "" {
 		AppPort = "8080"
 	}
 
+	SchedulerEnabled = os.Getenv("SCHEDULER_ENABLED") != "false"
+
+	AccessTokenTTL = time.Duration(envInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute
+	RefreshTokenTTL = time.Duration(envInt("REFRESH_TOKEN_TTL_DAYS", 30)) * 24 * time.Hour
+	TokenCacheTTL = time.Duration(envInt("TOKEN_CACHE_TTL_SECONDS", 60)) * time.Second
+
 	MailHost = os.Getenv("MAIL_HOST")
 	MailPort, _ = strconv.Atoi(os.Getenv("MAIL_PORT"))
 	MailUsername = os.Getenv("MAIL_USERNAME")
@@ -48,3 +71,29 @@ func LoadEnv() {
 	MailFromAddress = os.Getenv("MAIL_FROM_ADDRESS")
 	MailFromName = os.Getenv("MAIL_FROM_NAME")
 }
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 1 {
+		return fallback
+	}
+	return value
+}
+
+// appKey decodes a Laravel style "base64:" APP_KEY, other values are used as they are. Signed links, two-factor
+// challenges and encrypted secrets all depend on it, so the application does not start without one.
+func appKey(value string) []byte {
+	if encoded, ok := strings.CutPrefix(value, "base64:"); ok {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			log.Fatal("APP_KEY is not valid base64: ", err)
+		}
+		value = string(key)
+	}
+
+	if strings.TrimSpace(value) == "" {
+		log.Fatal("APP_KEY is not set, generate one with: echo \"base64:$(openssl rand -base64 32)\"")
+	}
+
+	return []byte(value)
+}
@@ -45,7 +45,7 @@ func NewAuthController(db *gorm.DB) *AuthController {
 
 // Login godoc
 // @Summary Authenticate a user
-// @Description Login with email and password to receive a personal access token
+// @Description Login with email and password to receive a personal access token. Users with two-factor authentication receive a challenge token instead, to be completed at /auth/two-factor/challenge. Pass abilities to issue a token limited to them, e.g. ["payments:read"] for a read-only integration; by default the token has every ability
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -53,14 +53,212 @@ func NewAuthController(db *gorm.DB) *AuthController {
 // @Success 200 {object} utils.Response{data=dto.LoginResponse}
 // @Failure 401 {object} utils.UnauthorizedResponse
 // @Failure 422 {object} utils.ValidationErrorResponse
+// @Failure 429 {object} utils.SimpleErrorResponse
 // @Router /auth/login [post]
 func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 	return ctrl.AuthMainService.Login(c)
 }
 
+// Register godoc
+// @Summary Register a user
+// @Description Create an account and send a signed email verification link. Until the email address is verified, login only grants the profile abilities
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param register body dto.RegisterRequest true "Registration"
+// @Success 201 {object} utils.Response{data=models.User}
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/register [post]
+func (ctrl *AuthController) Register(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.Register(c)
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Verify the email address with the id, hash, expires and signature query parameters of the emailed link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param verify body dto.VerifyEmailRequest true "Verification link parameters"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/verify-email [post]
+func (ctrl *AuthController) VerifyEmail(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.VerifyEmail(c)
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Send a new email verification link to the authenticated user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /auth/verify-email/resend [post]
+func (ctrl *AuthController) ResendVerification(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.ResendVerification(c)
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset link
+// @Description Email a single use password reset link. The response does not reveal whether the email address is registered, and each address gets at most one email per minute
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param forgot-password body dto.ForgotPasswordRequest true "Email address"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/forgot-password [post]
+func (ctrl *AuthController) ForgotPassword(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.ForgotPassword(c)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token from the reset link. The token can be used once and every access token of the user is revoked
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param reset-password body dto.ResetPasswordRequest true "Reset password"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/reset-password [post]
+func (ctrl *AuthController) ResetPassword(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.ResetPassword(c)
+}
+
+// TwoFactorChallenge godoc
+// @Summary Complete a two-factor login
+// @Description Exchange the challenge token returned by login and a TOTP code or a recovery code for an access token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param challenge body dto.TwoFactorChallengeRequest true "Two-factor challenge"
+// @Success 200 {object} utils.Response{data=dto.LoginResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/two-factor/challenge [post]
+func (ctrl *AuthController) TwoFactorChallenge(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.TwoFactorChallenge(c)
+}
+
+// EnableTwoFactor godoc
+// @Summary Set up two-factor authentication
+// @Description Generate a TOTP secret, its otpauth URI and recovery codes. Two-factor authentication is enforced once a first code is confirmed
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=dto.TwoFactorSetupResponse}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /auth/two-factor [post]
+func (ctrl *AuthController) EnableTwoFactor(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.EnableTwoFactor(c)
+}
+
+// ConfirmTwoFactor godoc
+// @Summary Confirm two-factor authentication
+// @Description Confirm the setup with a first code from the authenticator app
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param confirm body dto.TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/two-factor/confirm [post]
+func (ctrl *AuthController) ConfirmTwoFactor(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.ConfirmTwoFactor(c)
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication, the current password is required
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param disable body dto.DisableTwoFactorRequest true "Current password"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/two-factor [delete]
+func (ctrl *AuthController) DisableTwoFactor(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.DisableTwoFactor(c)
+}
+
+// TwoFactorRecoveryCodes godoc
+// @Summary Get two-factor recovery codes
+// @Description Get the unused two-factor recovery codes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=dto.TwoFactorRecoveryCodesResponse}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /auth/two-factor/recovery-codes [get]
+func (ctrl *AuthController) TwoFactorRecoveryCodes(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.TwoFactorRecoveryCodes(c)
+}
+
+// RegenerateTwoFactorRecoveryCodes godoc
+// @Summary Regenerate two-factor recovery codes
+// @Description Replace every two-factor recovery code with a new set
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=dto.TwoFactorRecoveryCodesResponse}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /auth/two-factor/recovery-codes [post]
+func (ctrl *AuthController) RegenerateTwoFactorRecoveryCodes(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.RegenerateTwoFactorRecoveryCodes(c)
+}
+
+// LoginHistory godoc
+// @Summary Get login history
+// @Description Get a paginated list of the login attempts of the authenticated user, newest first
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(15)
+// @Success 200 {object} utils.PaginatedResponse{data=[]models.LoginAttempt}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /auth/login-history [get]
+func (ctrl *AuthController) LoginHistory(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.LoginHistory(c)
+}
+
+// Refresh godoc
+// @Summary Refresh an access token
+// @Description Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing a rotated refresh token revokes every token of that login session
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} utils.Response{data=dto.LoginResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/refresh [post]
+func (ctrl *AuthController) Refresh(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.Refresh(c)
+}
+
 // Logout godoc
 // @Summary Logout user
-// @Description Logout user and revoke current access token
+// @Description Logout user and revoke current access token together with its refresh token
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -70,7 +268,7 @@ func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 // @Router /auth/logout [post]
 func (ctrl *AuthController) Logout(c *fiber.Ctx) error {
 	token := c.Locals("token").(models.PersonalAccessToken)
-	ctrl.TokenRepo.Delete(&token)
+	ctrl.AuthService.RevokeTokens(token)
 
 	return utils.SimpleSuccessResponse(c, "Logout successful. Current access token has been revoked.")
 }
@@ -142,10 +340,13 @@ func (ctrl *AuthController) ChangePassword(c *fiber.Ctx) error {
 		})
 	}
 
-	newToken, err := ctrl.AuthService.ChangePassword(
+	token := c.Locals("token").(models.PersonalAccessToken)
+
+	session, err := ctrl.AuthService.ChangePassword(
 		user,
 		data["current_password"].(string),
 		data["new_password"].(string),
+		token.AbilityList(),
 	)
 
 	if err != nil {
@@ -155,14 +356,12 @@ func (ctrl *AuthController) ChangePassword(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update password")
 	}
 
-	return utils.SuccessResponse(c, "Password changed successfully", dto.LoginResponse{
-		Token: newToken,
-	})
+	return utils.SuccessResponse(c, "Password changed successfully", session)
 }
 
 // UpdateProfile godoc
 // @Summary Update user profile
-// @Description Update user profile with name and email
+// @Description Update user profile with name and email, and the locale and timezone responses are formatted with. An empty locale or timezone clears the preference. A new email address has to be verified again, until then the tokens of the user only keep the unverified abilities.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -194,10 +393,32 @@ func (ctrl *AuthController) UpdateProfile(c *fiber.Ctx) error {
 		return utils.ValidationError(c, errs)
 	}
 
-	err = ctrl.AuthService.UpdateProfile(
+	validationErrs := make(map[string][]string)
+
+	if req.Locale != nil && *req.Locale != "" {
+		if locale, ok := utils.FindLocale(*req.Locale); ok {
+			req.Locale = &locale.Tag
+		} else {
+			validationErrs["locale"] = []string{"The locale field must be one of " + utils.LocaleCodes()}
+		}
+	}
+
+	if req.Timezone != nil && *req.Timezone != "" {
+		if _, err := utils.LoadTimezone(*req.Timezone); err != nil {
+			validationErrs["timezone"] = []string{"The timezone field must be a valid IANA timezone, e.g. Asia/Jakarta"}
+		}
+	}
+
+	if len(validationErrs) > 0 {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	emailChanged, err := ctrl.AuthService.UpdateProfile(
 		user,
 		req.Name,
 		req.Email,
+		req.Locale,
+		req.Timezone,
 	)
 
 	if err != nil {
@@ -207,5 +428,74 @@ func (ctrl *AuthController) UpdateProfile(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update profile")
 	}
 
+	if emailChanged {
+		user, err = ctrl.UserRepo.FindByID(userId)
+		if err == nil {
+			ctrl.AuthMainService.SendVerification(*user)
+		}
+
+		return utils.SimpleSuccessResponse(c, "Profile updated successfully, please verify your new email address and log in again to get full access")
+	}
+
 	return utils.SimpleSuccessResponse(c, "Profile updated successfully")
 }
+
+// Tokens godoc
+// @Summary List access tokens
+// @Description List the access tokens of the authenticated user, flagging the token that made the request
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.TokenResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /auth/tokens [get]
+func (ctrl *AuthController) Tokens(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.Tokens(c)
+}
+
+// StoreToken godoc
+// @Summary Create an API token
+// @Description Create a named API token with the chosen abilities and an optional expiry date. The abilities cannot exceed the abilities of the current token, and the plain token is only returned once
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param token body dto.StoreTokenRequest true "Token"
+// @Success 200 {object} utils.Response{data=dto.StoreTokenResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /auth/tokens [post]
+func (ctrl *AuthController) StoreToken(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.StoreToken(c)
+}
+
+// DestroyToken godoc
+// @Summary Revoke an access token
+// @Description Revoke one access token of the authenticated user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Token ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /auth/tokens/{id} [delete]
+func (ctrl *AuthController) DestroyToken(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.DestroyToken(c)
+}
+
+// DestroyOtherTokens godoc
+// @Summary Revoke other access tokens
+// @Description Revoke every access token of the authenticated user except the current one
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /auth/tokens [delete]
+func (ctrl *AuthController) DestroyOtherTokens(c *fiber.Ctx) error {
+	return ctrl.AuthMainService.DestroyOtherTokens(c)
+}
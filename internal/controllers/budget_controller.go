@@ -0,0 +1,320 @@
+/*
+ * Project Name: controllers
+ * File: budget_controller.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package controllers
+
+import (
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/internal/service/payment_service"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type BudgetController struct {
+	repo           *repositories.BudgetRepository
+	category       *repositories.PaymentCategoryRepository
+	paymentAccount *repositories.PaymentAccountRepository
+	budgetService  payment_service.BudgetService
+	db             *gorm.DB
+}
+
+func NewBudgetController(db *gorm.DB) *BudgetController {
+	return &BudgetController{
+		repo:           repositories.NewBudgetRepository(db),
+		category:       repositories.NewPaymentCategoryRepository(db),
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		budgetService:  payment_service.NewBudgetService(db),
+		db:             db,
+	}
+}
+
+// Index godoc
+// @Summary List budgets
+// @Description Get the monthly budgets of the authenticated user
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]models.Budget}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /budgets [get]
+// @Security BearerAuth
+func (ctrl *BudgetController) Index(c *fiber.Ctx) error {
+	budgets, err := ctrl.repo.FindAllByUser(c.Locals("user_id").(uint))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve budgets")
+	}
+
+	return utils.SuccessResponse(c, "Budgets retrieved successfully", budgets)
+}
+
+// Status godoc
+// @Summary Get budget status
+// @Description Get the spent and remaining amount of every budget in a month, the current month in the user's timezone by default. Only booked expenses count, converted into the budget currency
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param month query string false "Month (YYYY-MM)"
+// @Success 200 {object} utils.Response{data=[]dto.BudgetStatusResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /budgets/status [get]
+// @Security BearerAuth
+func (ctrl *BudgetController) Status(c *fiber.Ctx) error {
+	return ctrl.budgetService.Status(c)
+}
+
+// Show godoc
+// @Summary Get budget details
+// @Description Get a budget of the authenticated user
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param id path int true "Budget ID"
+// @Success 200 {object} utils.Response{data=models.Budget}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /budgets/{id} [get]
+// @Security BearerAuth
+func (ctrl *BudgetController) Show(c *fiber.Ctx) error {
+	budget, err := ctrl.find(c)
+	if err != nil || !policies.Budget.View(c.Locals("user_id").(uint), budget) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Budget not found")
+	}
+
+	return utils.SuccessResponse(c, "Budget retrieved successfully", budget)
+}
+
+// Store godoc
+// @Summary Create a budget
+// @Description Create a monthly budget for a category, including its subcategories, or for a payment account. Account budgets use the currency of the account, category budgets IDR unless a currency is given
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param request body dto.StoreBudgetRequest true "Budget data"
+// @Success 201 {object} utils.Response{data=models.Budget}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /budgets [post]
+// @Security BearerAuth
+func (ctrl *BudgetController) Store(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	var request dto.StoreBudgetRequest
+
+	rules := govalidator.MapData{
+		"name":               []string{"required", "max:100"},
+		"scope":              []string{"required", "in:" + models.BudgetScopeCategory + "," + models.BudgetScopeAccount},
+		"category_id":        []string{"numeric"},
+		"payment_account_id": []string{"numeric"},
+		"amount":             []string{"required", "numeric"},
+		"currency":           []string{"in:" + utils.CurrencyCodes()},
+		"rollover":           []string{"bool"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	budget := models.Budget{
+		UserID:   userID,
+		Name:     strings.TrimSpace(request.Name),
+		Scope:    request.Scope,
+		Amount:   request.Amount,
+		Currency: utils.DefaultCurrency,
+		Rollover: request.Rollover,
+	}
+
+	if request.Currency != nil && *request.Currency != "" {
+		budget.Currency = *request.Currency
+	}
+
+	validationErrs := ctrl.validateAmount(budget.Amount)
+
+	switch budget.Scope {
+	case models.BudgetScopeCategory:
+		budget.CategoryID = request.CategoryID
+		if request.CategoryID == nil || !policies.PaymentCategory.UseByID(ctrl.category, userID, request.CategoryID) {
+			validationErrs["category_id"] = []string{"The selected category is invalid"}
+		}
+	case models.BudgetScopeAccount:
+		budget.PaymentAccountID = request.PaymentAccountID
+		if request.PaymentAccountID == nil || !policies.PaymentAccount.UseByID(ctrl.paymentAccount, userID, request.PaymentAccountID) {
+			validationErrs["payment_account_id"] = []string{"The selected payment account is invalid"}
+		} else if paymentAccount, err := ctrl.paymentAccount.SelectByID(nil, *request.PaymentAccountID, []string{"id", "currency"}); err == nil && paymentAccount.Currency != "" {
+			budget.Currency = paymentAccount.Currency
+		}
+	}
+
+	if len(validationErrs) == 0 && ctrl.repo.Exists(userID, budget.Scope, budget.CategoryID, budget.PaymentAccountID) {
+		validationErrs["scope"] = []string{"A budget for this " + budget.Scope + " already exists"}
+	}
+
+	if len(validationErrs) > 0 {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	if err := ctrl.repo.Create(&budget); err != nil {
+		log.Println("Failed to create budget: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to create budget")
+	}
+
+	created, err := ctrl.repo.FindByID(budget.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Budget not found")
+	}
+
+	return utils.CreatedResponse(c, "Budget created successfully", created)
+}
+
+// Update godoc
+// @Summary Update a budget
+// @Description Rename a budget or change its amount, currency or rollover. The category or account of a budget cannot change, the currency only for category budgets
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param id path int true "Budget ID"
+// @Param request body dto.UpdateBudgetRequest true "Budget data"
+// @Success 200 {object} utils.Response{data=models.Budget}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /budgets/{id} [put]
+// @Security BearerAuth
+func (ctrl *BudgetController) Update(c *fiber.Ctx) error {
+	budget, err := ctrl.find(c)
+	if err != nil || !policies.Budget.Update(c.Locals("user_id").(uint), budget) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Budget not found")
+	}
+
+	var request dto.UpdateBudgetRequest
+
+	rules := govalidator.MapData{
+		"name":     []string{"max:100"},
+		"amount":   []string{"numeric"},
+		"currency": []string{"in:" + utils.CurrencyCodes()},
+		"rollover": []string{"bool"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	fields := make(map[string]interface{})
+	validationErrs := make(map[string][]string)
+
+	if request.Name != nil {
+		if name := strings.TrimSpace(*request.Name); name == "" {
+			validationErrs["name"] = []string{"The name field is required"}
+		} else {
+			fields["name"] = name
+		}
+	}
+
+	if request.Amount != nil {
+		for field, messages := range ctrl.validateAmount(*request.Amount) {
+			validationErrs[field] = messages
+		}
+		fields["amount"] = *request.Amount
+	}
+
+	if request.Currency != nil && *request.Currency != "" && *request.Currency != budget.Currency {
+		if budget.Scope == models.BudgetScopeAccount {
+			validationErrs["currency"] = []string{"An account budget uses the currency of its account"}
+		}
+		fields["currency"] = *request.Currency
+	}
+
+	if request.Rollover != nil {
+		fields["rollover"] = *request.Rollover
+	}
+
+	if len(validationErrs) > 0 {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	if len(fields) > 0 {
+		if err := ctrl.repo.UpdateFields(budget.ID, fields); err != nil {
+			log.Println("Failed to update budget: ", err)
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to update budget")
+		}
+	}
+
+	updated, err := ctrl.repo.FindByID(budget.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Budget not found")
+	}
+
+	return utils.SuccessResponse(c, "Budget updated successfully", updated)
+}
+
+// Destroy godoc
+// @Summary Delete a budget
+// @Description Delete a budget of the authenticated user
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param id path int true "Budget ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /budgets/{id} [delete]
+// @Security BearerAuth
+func (ctrl *BudgetController) Destroy(c *fiber.Ctx) error {
+	budget, err := ctrl.find(c)
+	if err != nil || !policies.Budget.Delete(c.Locals("user_id").(uint), budget) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Budget not found")
+	}
+
+	err = ctrl.db.Transaction(func(tx *gorm.DB) error {
+		return ctrl.repo.Delete(tx, budget.ID)
+	})
+
+	if err != nil {
+		log.Println("Failed to delete budget: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to delete budget")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Budget deleted successfully")
+}
+
+func (ctrl *BudgetController) validateAmount(amount int64) map[string][]string {
+	validationErrs := make(map[string][]string)
+	if amount < 1 {
+		validationErrs["amount"] = []string{"The amount must be greater than 0"}
+	}
+	return validationErrs
+}
+
+func (ctrl *BudgetController) find(c *fiber.Ctx) (*models.Budget, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl.repo.FindByID(uint(id))
+}
@@ -0,0 +1,304 @@
+/*
+ * Project Name: controllers
+ * File: exchange_rate_controller.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+const exchangeRateMaxFileSize = 1024 * 1024
+
+type ExchangeRateController struct {
+	repo *repositories.ExchangeRateRepository
+}
+
+func NewExchangeRateController(db *gorm.DB) *ExchangeRateController {
+	return &ExchangeRateController{
+		repo: repositories.NewExchangeRateRepository(db),
+	}
+}
+
+// Index godoc
+// @Summary List exchange rates
+// @Description Get a paginated list of exchange rates, newest first
+// @Tags exchange_rates
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Param base query string false "Base currency code"
+// @Param quote query string false "Quote currency code"
+// @Success 200 {object} utils.PaginatedResponse{data=[]models.ExchangeRate}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /exchange-rates [get]
+// @Security BearerAuth
+func (ctrl *ExchangeRateController) Index(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	perPage, _ := strconv.Atoi(c.Query("per_page", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+
+	if perPage < 1 {
+		perPage = 10
+	} else if perPage > 100 {
+		perPage = 100
+	}
+
+	filter := repositories.ExchangeRateFilter{
+		BaseCurrency:  strings.ToUpper(c.Query("base")),
+		QuoteCurrency: strings.ToUpper(c.Query("quote")),
+	}
+
+	total, err := ctrl.repo.Count(filter)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to count exchange rates")
+	}
+
+	rates, err := ctrl.repo.FindAllPaginated(page, perPage, filter)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to retrieve exchange rates")
+	}
+
+	return utils.PaginatedSuccessResponse(c, "Exchange rates retrieved successfully", rates, page, perPage, total, len(rates))
+}
+
+// Store godoc
+// @Summary Store an exchange rate
+// @Description Store the price of one unit of the base currency in the quote currency on a day, replacing the rate the pair already has on that day
+// @Tags exchange_rates
+// @Accept json
+// @Produce json
+// @Param request body dto.StoreExchangeRateRequest true "Exchange rate"
+// @Success 201 {object} utils.Response{data=models.ExchangeRate}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /exchange-rates [post]
+// @Security BearerAuth
+func (ctrl *ExchangeRateController) Store(c *fiber.Ctx) error {
+	var request dto.StoreExchangeRateRequest
+
+	rules := govalidator.MapData{
+		"base_currency":  []string{"required", "in:" + utils.CurrencyCodes()},
+		"quote_currency": []string{"required", "in:" + utils.CurrencyCodes()},
+		"rate":           []string{"required", "float"},
+		"date":           []string{"date:yyyy-mm-dd"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if request.Date == "" {
+		request.Date = time.Now().Format("2006-01-02")
+	}
+
+	rate, err := exchangeRate(request.BaseCurrency, request.QuoteCurrency, strconv.FormatFloat(request.Rate, 'f', -1, 64), request.Date)
+	if err != nil {
+		return utils.ValidationError(c, map[string][]string{"rate": {err.Error()}})
+	}
+	rate.Source = models.ExchangeRateSourceManual
+
+	if err := ctrl.repo.Upsert(nil, *rate); err != nil {
+		log.Println("Failed to store exchange rate: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to store exchange rate")
+	}
+
+	stored, err := ctrl.repo.LatestOn(rate.BaseCurrency, rate.QuoteCurrency, rate.Date)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to store exchange rate")
+	}
+
+	return utils.CreatedResponse(c, "Exchange rate stored successfully", stored)
+}
+
+// Import godoc
+// @Summary Import exchange rates
+// @Description Import exchange rates from a CSV file with the columns base_currency, quote_currency, rate and date (yyyy-mm-dd), the header row is optional. Rates a pair already has on the same day are replaced. Nothing is imported when a row is invalid
+// @Tags exchange_rates
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file, up to 1 MB"
+// @Success 200 {object} utils.Response{data=dto.ImportExchangeRatesResponse}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /exchange-rates/import [post]
+// @Security BearerAuth
+func (ctrl *ExchangeRateController) Import(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return utils.ValidationError(c, map[string][]string{"file": {"The file field is required"}})
+	}
+
+	if fileHeader.Size > exchangeRateMaxFileSize {
+		return utils.ValidationError(c, map[string][]string{"file": {"The file may not be greater than 1 MB"}})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to read the uploaded file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to read the uploaded file")
+	}
+
+	rates, rowErrs := parseExchangeRates(data)
+	if len(rowErrs) > 0 {
+		return utils.ValidationError(c, map[string][]string{"file": rowErrs})
+	}
+
+	if err := ctrl.repo.Upsert(nil, rates...); err != nil {
+		log.Println("Failed to import exchange rates: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to import exchange rates")
+	}
+
+	return utils.SuccessResponse(c, "Exchange rates imported successfully", dto.ImportExchangeRatesResponse{
+		Imported: len(rates),
+	})
+}
+
+// Destroy godoc
+// @Summary Delete an exchange rate
+// @Description Delete an exchange rate. Payments already converted with it keep their amounts
+// @Tags exchange_rates
+// @Accept json
+// @Produce json
+// @Param id path int true "Exchange rate ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /exchange-rates/{id} [delete]
+// @Security BearerAuth
+func (ctrl *ExchangeRateController) Destroy(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Exchange rate not found")
+	}
+
+	rate, err := ctrl.repo.FindByID(uint(id))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Exchange rate not found")
+	}
+
+	if err := ctrl.repo.Delete(rate); err != nil {
+		log.Println("Failed to delete exchange rate: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to delete exchange rate")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Exchange rate deleted successfully")
+}
+
+// parseExchangeRates reads base_currency, quote_currency, rate, date rows. A first row whose rate is not a
+// number is taken as the header.
+func parseExchangeRates(data []byte) ([]models.ExchangeRate, []string) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, []string{"The CSV file could not be read: " + err.Error()}
+	}
+
+	if len(records) > 0 && len(records[0]) > 2 {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(records[0][2]), 64); err != nil {
+			records = records[1:]
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, []string{"The CSV file is empty"}
+	}
+
+	var rates []models.ExchangeRate
+	var errs []string
+
+	for i, record := range records {
+		if len(record) < 4 {
+			errs = append(errs, fmt.Sprintf("Row %d: expected base_currency, quote_currency, rate and date", i+1))
+			continue
+		}
+
+		rate, err := exchangeRate(record[0], record[1], record[2], record[3])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Row %d: %s", i+1, err.Error()))
+			continue
+		}
+
+		rate.Source = models.ExchangeRateSourceImport
+		rates = append(rates, *rate)
+	}
+
+	return rates, errs
+}
+
+func exchangeRate(base, quote, value, date string) (*models.ExchangeRate, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+
+	if _, ok := utils.Currencies[base]; !ok {
+		return nil, fmt.Errorf("the currency %q is not supported", base)
+	}
+
+	if _, ok := utils.Currencies[quote]; !ok {
+		return nil, fmt.Errorf("the currency %q is not supported", quote)
+	}
+
+	if base == quote {
+		return nil, fmt.Errorf("the base and quote currency must be different")
+	}
+
+	rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || rate <= 0 {
+		return nil, fmt.Errorf("the rate must be a number greater than 0")
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(date), time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("the date must be in the yyyy-mm-dd format")
+	}
+
+	return &models.ExchangeRate{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          rate,
+		Date:          day,
+	}, nil
+}
@@ -1,18 +1,22 @@
 package controllers
 
 import (
+	"golang-api/internal/policies"
 	"golang-api/internal/repositories"
 	"golang-api/pkg/utils"
+	"os"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type FileDownloadController struct {
-	repo *repositories.FileDownloadRepository
+	repo     *repositories.FileDownloadRepository
+	fileRepo *repositories.FileRepository
 }
 
-func NewFileDownloadController(repo *repositories.FileDownloadRepository) *FileDownloadController {
-	return &FileDownloadController{repo: repo}
+func NewFileDownloadController(repo *repositories.FileDownloadRepository, fileRepo *repositories.FileRepository) *FileDownloadController {
+	return &FileDownloadController{repo: repo, fileRepo: fileRepo}
 }
 
 type FileResponse struct {
@@ -57,3 +61,32 @@ func (ctrl *FileDownloadController) GetFiles(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, "Files retrieved successfully", files)
 }
+
+// Download godoc
+// @Summary Download a generated file
+// @Description Download a generated file, such as a payment report, of the authenticated user. Files are only available until their scheduled deletion time
+// @Tags files
+// @Produce octet-stream
+// @Param code path string true "File code"
+// @Success 200 {file} file
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /files/{code}/download [get]
+// @Security BearerAuth
+func (ctrl *FileDownloadController) Download(c *fiber.Ctx) error {
+	file, err := ctrl.fileRepo.FindByCode(c.Params("code"))
+	if err != nil || !policies.File.Download(c.Locals("user_id").(uint), file) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "File not found")
+	}
+
+	expired := file.ScheduledDeletionTime != nil && !file.ScheduledDeletionTime.After(time.Now())
+	if expired || (file.HasBeenDeleted != nil && *file.HasBeenDeleted) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "File not found")
+	}
+
+	if _, err := os.Stat(file.FilePath); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "File not found")
+	}
+
+	return c.Download(file.FilePath, file.FileName)
+}
@@ -13,29 +13,51 @@
 package controllers
 
 import (
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
 	"golang-api/internal/repositories"
+	"golang-api/internal/service/payment_service"
 	"golang-api/pkg/utils"
+	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
 )
 
 type PaymentAccountController struct {
-	repo *repositories.PaymentAccountRepository
+	repo           *repositories.PaymentAccountRepository
+	balanceHistory payment_service.BalanceHistoryService
+	reconcile      payment_service.ReconcileService
+	creditPayment  payment_service.CreditPaymentService
+	budget         *repositories.BudgetRepository
+	db             *gorm.DB
 }
 
-func NewPaymentAccountController(repo *repositories.PaymentAccountRepository) *PaymentAccountController {
-	return &PaymentAccountController{repo: repo}
+func NewPaymentAccountController(db *gorm.DB) *PaymentAccountController {
+	return &PaymentAccountController{
+		repo:           repositories.NewPaymentAccountRepository(db),
+		balanceHistory: payment_service.NewBalanceHistoryService(db),
+		reconcile:      payment_service.NewReconcileService(db),
+		creditPayment:  payment_service.NewCreditPaymentService(db),
+		budget:         repositories.NewBudgetRepository(db),
+		db:             db,
+	}
 }
 
 // Index godoc
 // @Summary List payment accounts
-// @Description Get a paginated list of payment accounts
+// @Description Get a paginated list of payment accounts. Archived accounts are left out unless include_archived is set
 // @Tags payment_accounts
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(10)
+// @Param include_archived query bool false "Include archived accounts" default(false)
 // @Success 200 {object} utils.PaginatedResponse{data=[]PaymentAccountSwagger}
 // @Failure 401 {object} utils.UnauthorizedResponse
 // @Failure 400 {object} utils.SimpleErrorResponse
@@ -46,6 +68,7 @@ func (ctrl *PaymentAccountController) Index(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uint)
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	perPage, _ := strconv.Atoi(c.Query("per_page", "10"))
+	includeArchived := c.QueryBool("include_archived", false)
 
 	if page < 1 {
 		page = 1
@@ -57,13 +80,13 @@ func (ctrl *PaymentAccountController) Index(c *fiber.Ctx) error {
 		perPage = 100
 	}
 
-	total, err := ctrl.repo.Count(userID)
+	total, err := ctrl.repo.Count(userID, includeArchived)
 
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to count payment accounts")
 	}
 
-	paymentAccounts, err := ctrl.repo.FindAllPaginated(userID, page, perPage)
+	paymentAccounts, err := ctrl.repo.FindAllPaginated(userID, page, perPage, includeArchived)
 
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to retrieve payment accounts")
@@ -71,3 +94,514 @@ func (ctrl *PaymentAccountController) Index(c *fiber.Ctx) error {
 
 	return utils.PaginatedSuccessResponse(c, "Payment accounts retrieved successfully", paymentAccounts, page, perPage, total, len(paymentAccounts))
 }
+
+// Show godoc
+// @Summary Get payment account details
+// @Description Get a payment account of the authenticated user
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Success 200 {object} utils.Response{data=PaymentAccountSwagger}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-accounts/{id} [get]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Show(c *fiber.Ctx) error {
+	paymentAccount, err := ctrl.find(c)
+	if err != nil || !policies.PaymentAccount.View(c.Locals("user_id").(uint), paymentAccount) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	return utils.SuccessResponse(c, "Payment account retrieved successfully", paymentAccount)
+}
+
+// Store godoc
+// @Summary Create a payment account
+// @Description Create a payment account, the deposit is its opening balance. Credit card and loan accounts may open with a negative balance, their debt. Amounts are in minor units of the account currency, IDR by default
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param request body dto.StorePaymentAccountRequest true "Payment account data"
+// @Success 201 {object} utils.Response{data=PaymentAccountSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts [post]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Store(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	var request dto.StorePaymentAccountRequest
+
+	rules := govalidator.MapData{
+		"name":          []string{"required", "max:255"},
+		"type":          []string{"in:" + strings.Join(models.PaymentAccountTypes, ",")},
+		"currency":      []string{"in:" + utils.CurrencyCodes()},
+		"logo":          []string{"max:255"},
+		"deposit":       []string{"numeric"},
+		"credit_limit":  []string{"numeric"},
+		"statement_day": []string{"numeric_between:1,31"},
+		"due_day":       []string{"numeric_between:1,31"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if request.Type == "" {
+		request.Type = models.PaymentAccountTypeBank
+	}
+
+	if request.Currency == "" {
+		request.Currency = utils.DefaultCurrency
+	}
+
+	paymentAccount := &models.PaymentAccount{
+		UserID:       userID,
+		Name:         strings.TrimSpace(request.Name),
+		Type:         request.Type,
+		Currency:     request.Currency,
+		Logo:         strings.TrimSpace(request.Logo),
+		Deposit:      request.Deposit,
+		CreditLimit:  request.CreditLimit,
+		StatementDay: request.StatementDay,
+		DueDay:       request.DueDay,
+	}
+
+	if errs := validateAccountType(paymentAccount); errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	paymentAccount, err := ctrl.repo.Create(nil, userID, userName, paymentAccount)
+	if err != nil {
+		log.Println("Failed to create payment account: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to create payment account")
+	}
+
+	paymentAccount, _ = ctrl.repo.FindByID(paymentAccount.ID)
+
+	return utils.CreatedResponse(c, "Payment account created successfully", paymentAccount)
+}
+
+// Update godoc
+// @Summary Update a payment account
+// @Description Update the name, type, currency, logo, balance or credit details of a payment account. Balance changes are recorded in the activity log, the currency can only change while no payments are booked on the account
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param request body dto.UpdatePaymentAccountRequest true "Payment account data"
+// @Success 200 {object} utils.Response{data=PaymentAccountSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts/{id} [put]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Update(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	paymentAccount, err := ctrl.find(c)
+	if err != nil || !policies.PaymentAccount.Update(userID, paymentAccount) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	var request dto.UpdatePaymentAccountRequest
+
+	rules := govalidator.MapData{
+		"name":          []string{"max:255"},
+		"type":          []string{"in:" + strings.Join(models.PaymentAccountTypes, ",")},
+		"currency":      []string{"in:" + utils.CurrencyCodes()},
+		"logo":          []string{"max:255"},
+		"deposit":       []string{"numeric"},
+		"credit_limit":  []string{"numeric"},
+		"statement_day": []string{"numeric_between:1,31"},
+		"due_day":       []string{"numeric_between:1,31"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if request.Name != nil && strings.TrimSpace(*request.Name) == "" {
+		return utils.ValidationError(c, map[string][]string{
+			"name": {"The name field may not be empty"},
+		})
+	}
+
+	merged := *paymentAccount
+
+	if request.Deposit != nil {
+		merged.Deposit = *request.Deposit
+	}
+
+	if request.Type != nil {
+		merged.Type = *request.Type
+	}
+	if request.CreditLimit != nil {
+		merged.CreditLimit = request.CreditLimit
+	}
+	if request.StatementDay != nil {
+		merged.StatementDay = request.StatementDay
+	}
+	if request.DueDay != nil {
+		merged.DueDay = request.DueDay
+	}
+
+	// Credit details of an account that stops being a credit type are dropped instead of rejected.
+	if request.Type != nil && !merged.IsCredit() {
+		if request.CreditLimit == nil {
+			merged.CreditLimit = nil
+		}
+		if request.StatementDay == nil {
+			merged.StatementDay = nil
+		}
+		if request.DueDay == nil {
+			merged.DueDay = nil
+		}
+	}
+
+	if errs := validateAccountType(&merged); errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if request.Currency != nil && *request.Currency != paymentAccount.Currency {
+		referenced, err := ctrl.repo.IsReferenced(paymentAccount.ID)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to check payment account usage")
+		}
+
+		if referenced {
+			return utils.ValidationError(c, map[string][]string{
+				"currency": {"The currency cannot be changed once payments are booked on the account"},
+			})
+		}
+
+		merged.Currency = *request.Currency
+	}
+
+	fields := map[string]interface{}{
+		"type":          merged.Type,
+		"currency":      merged.Currency,
+		"credit_limit":  merged.CreditLimit,
+		"statement_day": merged.StatementDay,
+		"due_day":       merged.DueDay,
+	}
+
+	if request.Name != nil {
+		fields["name"] = strings.TrimSpace(*request.Name)
+	}
+	if request.Logo != nil {
+		fields["logo"] = strings.TrimSpace(*request.Logo)
+	}
+
+	err = ctrl.db.Transaction(func(tx *gorm.DB) error {
+		// Only an explicit deposit touches the balance, read under lock so payments booked meanwhile are not lost.
+		if request.Deposit != nil {
+			current, err := ctrl.repo.SelectForUpdate(tx, paymentAccount.ID, []string{"id", "user_id", "name", "deposit"})
+			if err != nil {
+				return err
+			}
+
+			if _, err := ctrl.repo.Update(tx, userID, userName, &models.PaymentAccount{ID: paymentAccount.ID, Deposit: *request.Deposit}, current); err != nil {
+				return err
+			}
+		}
+
+		return ctrl.repo.UpdateFields(tx, paymentAccount.ID, fields)
+	})
+
+	if err != nil {
+		log.Println("Failed to update payment account: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to update payment account")
+	}
+
+	paymentAccount, _ = ctrl.repo.FindByID(paymentAccount.ID)
+
+	return utils.SuccessResponse(c, "Payment account updated successfully", paymentAccount)
+}
+
+// Archive godoc
+// @Summary Archive a payment account
+// @Description Hide a payment account from the account list. Its payments and balance are kept
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Success 200 {object} utils.Response{data=PaymentAccountSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-accounts/{id}/archive [post]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Archive(c *fiber.Ctx) error {
+	return ctrl.setArchived(c, true)
+}
+
+// Unarchive godoc
+// @Summary Unarchive a payment account
+// @Description Show an archived payment account in the account list again
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Success 200 {object} utils.Response{data=PaymentAccountSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-accounts/{id}/unarchive [post]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Unarchive(c *fiber.Ctx) error {
+	return ctrl.setArchived(c, false)
+}
+
+// Destroy godoc
+// @Summary Delete a payment account
+// @Description Delete a payment account. Accounts that payments or recurring payments still book on cannot be deleted, archive them instead
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 409 {object} utils.SimpleErrorResponse
+// @Router /payment-accounts/{id} [delete]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Destroy(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	paymentAccount, err := ctrl.find(c)
+	if err != nil || !policies.PaymentAccount.Delete(userID, paymentAccount) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	referenced, err := ctrl.repo.IsReferenced(paymentAccount.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to check payment account usage")
+	}
+
+	if referenced {
+		return utils.ErrorResponse(c, fiber.StatusConflict, "The payment account still has payments, archive it instead")
+	}
+
+	err = ctrl.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("payment_account_id = ?", paymentAccount.ID).Delete(&models.PaymentImportMapping{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("payment_account_id = ?", paymentAccount.ID).Delete(&models.PaymentAccountBalance{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("payment_account_id = ?", paymentAccount.ID).Delete(&models.PaymentReconciliation{}).Error; err != nil {
+			return err
+		}
+
+		if err := ctrl.budget.DeleteByAccount(tx, paymentAccount.ID); err != nil {
+			return err
+		}
+
+		return ctrl.repo.Delete(tx, userID, userName, paymentAccount)
+	})
+
+	if err != nil {
+		log.Println("Failed to delete payment account: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to delete payment account")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Payment account deleted successfully")
+}
+
+// BalanceHistory godoc
+// @Summary Get payment account balance history
+// @Description Get the closing balance of a payment account for every day, week or month between from and to
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param from query string false "Start date (yyyy-mm-dd), defaults to 30 days ago"
+// @Param to query string false "End date (yyyy-mm-dd), defaults to today"
+// @Param interval query string false "Interval" Enums(day, week, month) default(day)
+// @Success 200 {object} utils.Response{data=dto.BalanceHistoryResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts/{id}/balance-history [get]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) BalanceHistory(c *fiber.Ctx) error {
+	return ctrl.balanceHistory.History(c)
+}
+
+// BalanceAt godoc
+// @Summary Get payment account balance on a date
+// @Description Get the balance of a payment account at the end of the given day
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param date query string false "Date (yyyy-mm-dd), defaults to today"
+// @Success 200 {object} utils.Response{data=dto.BalanceAtResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts/{id}/balance [get]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) BalanceAt(c *fiber.Ctx) error {
+	return ctrl.balanceHistory.BalanceAt(c)
+}
+
+// ReconcilePreview godoc
+// @Summary Preview a payment account reconciliation
+// @Description Get the balance of a payment account, its last reconciliation and the payments not cleared yet. With statement_balance the difference to the book balance is included
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param statement_balance query int false "Balance on the actual statement"
+// @Success 200 {object} utils.Response{data=dto.ReconciliationPreviewResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts/{id}/reconcile [get]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) ReconcilePreview(c *fiber.Ctx) error {
+	return ctrl.reconcile.Preview(c)
+}
+
+// Reconcile godoc
+// @Summary Reconcile a payment account
+// @Description Record the balance of the actual statement and mark the selected payments as cleared. With create_adjustment an income or expense payment is booked for the difference and cleared as well. Later changes to cleared payments flag the reconciliation
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param request body dto.ReconcileRequest true "Reconciliation"
+// @Success 200 {object} utils.Response{data=dto.ReconcileResponse}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts/{id}/reconcile [post]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Reconcile(c *fiber.Ctx) error {
+	return ctrl.reconcile.Reconcile(c)
+}
+
+// Reconciliations godoc
+// @Summary List payment account reconciliations
+// @Description Get a paginated list of the reconciliations of a payment account, newest first
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]models.PaymentReconciliation}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-accounts/{id}/reconciliations [get]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Reconciliations(c *fiber.Ctx) error {
+	return ctrl.reconcile.History(c)
+}
+
+// Pay godoc
+// @Summary Pay a credit card or loan account
+// @Description Transfer money from a funding account into a credit card or loan account, lowering its debt. The amount defaults to the whole outstanding balance and may not exceed it
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Credit card or loan account ID"
+// @Param request body dto.PayCreditRequest true "Funding account, amount and date"
+// @Success 201 {object} utils.Response{data=PaymentSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts/{id}/pay [post]
+// @Security BearerAuth
+func (ctrl *PaymentAccountController) Pay(c *fiber.Ctx) error {
+	return ctrl.creditPayment.Pay(c)
+}
+
+// validateAccountType checks the balance and credit details of an account against its type.
+func validateAccountType(paymentAccount *models.PaymentAccount) map[string][]string {
+	errs := map[string][]string{}
+
+	if paymentAccount.IsCredit() {
+		if paymentAccount.CreditLimit != nil && *paymentAccount.CreditLimit < 0 {
+			errs["credit_limit"] = []string{"The credit limit must be at least 0"}
+		} else if paymentAccount.CreditLimit != nil && paymentAccount.Deposit < -*paymentAccount.CreditLimit {
+			errs["deposit"] = []string{"The outstanding balance may not exceed the credit limit"}
+		}
+
+		if paymentAccount.DueDay != nil && paymentAccount.StatementDay == nil {
+			errs["statement_day"] = []string{"The statement day field is required when due day is present"}
+		}
+	} else {
+		if paymentAccount.Deposit < 0 {
+			errs["deposit"] = []string{"The deposit may only be negative for credit card and loan accounts"}
+		}
+
+		for field, value := range map[string]bool{
+			"credit_limit":  paymentAccount.CreditLimit != nil,
+			"statement_day": paymentAccount.StatementDay != nil,
+			"due_day":       paymentAccount.DueDay != nil,
+		} {
+			if value {
+				errs[field] = []string{"This field is only allowed for credit card and loan accounts"}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (ctrl *PaymentAccountController) setArchived(c *fiber.Ctx, archived bool) error {
+	paymentAccount, err := ctrl.find(c)
+	if err != nil || !policies.PaymentAccount.Update(c.Locals("user_id").(uint), paymentAccount) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	var archivedAt *time.Time
+	message := "Payment account unarchived successfully"
+
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+		message = "Payment account archived successfully"
+
+		if paymentAccount.IsArchived() {
+			archivedAt = paymentAccount.ArchivedAt
+		}
+	}
+
+	if err := ctrl.repo.UpdateFields(nil, paymentAccount.ID, map[string]interface{}{"archived_at": archivedAt}); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to update payment account")
+	}
+
+	paymentAccount.ArchivedAt = archivedAt
+
+	return utils.SuccessResponse(c, message, paymentAccount)
+}
+
+func (ctrl *PaymentAccountController) find(c *fiber.Ctx) (*models.PaymentAccount, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl.repo.FindByID(uint(id))
+}
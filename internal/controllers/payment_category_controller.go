@@ -0,0 +1,399 @@
+/*
+ * Project Name: controllers
+ * File: payment_category_controller.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package controllers
+
+import (
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+// suggestionWordLimit caps the words of a payment name matched against past payments.
+const suggestionWordLimit = 5
+
+type PaymentCategoryController struct {
+	repo   *repositories.PaymentCategoryRepository
+	budget *repositories.BudgetRepository
+	db     *gorm.DB
+}
+
+func NewPaymentCategoryController(db *gorm.DB) *PaymentCategoryController {
+	return &PaymentCategoryController{
+		repo:   repositories.NewPaymentCategoryRepository(db),
+		budget: repositories.NewBudgetRepository(db),
+		db:     db,
+	}
+}
+
+// Index godoc
+// @Summary List payment categories
+// @Description Get the payment categories of the authenticated user as a tree, or as a flat list with flat=true
+// @Tags payment_categories
+// @Accept json
+// @Produce json
+// @Param flat query bool false "Return a flat list instead of a tree" default(false)
+// @Success 200 {object} utils.Response{data=[]models.PaymentCategory}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /payment-categories [get]
+// @Security BearerAuth
+func (ctrl *PaymentCategoryController) Index(c *fiber.Ctx) error {
+	categories, err := ctrl.repo.FindAllByUser(c.Locals("user_id").(uint))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve payment categories")
+	}
+
+	if c.QueryBool("flat", false) {
+		paths := models.CategoryPaths(categories)
+		for i := range categories {
+			categories[i].Path = paths[categories[i].ID]
+		}
+
+		return utils.SuccessResponse(c, "Payment categories retrieved successfully", categories)
+	}
+
+	return utils.SuccessResponse(c, "Payment categories retrieved successfully", models.CategoryTree(categories))
+}
+
+// Store godoc
+// @Summary Create a payment category
+// @Description Create a payment category, nested under parent_id when given. Categories can be nested up to three levels deep
+// @Tags payment_categories
+// @Accept json
+// @Produce json
+// @Param request body dto.StorePaymentCategoryRequest true "Payment category data"
+// @Success 201 {object} utils.Response{data=models.PaymentCategory}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-categories [post]
+// @Security BearerAuth
+func (ctrl *PaymentCategoryController) Store(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	var request dto.StorePaymentCategoryRequest
+
+	rules := govalidator.MapData{
+		"name":      []string{"required", "max:100"},
+		"parent_id": []string{"numeric"},
+		"color":     []string{"regex:^#[0-9a-fA-F]{6}$"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	category := models.PaymentCategory{
+		UserID:   userID,
+		ParentID: request.ParentID,
+		Name:     strings.TrimSpace(request.Name),
+		Color:    request.Color,
+	}
+
+	if validationErrs := ctrl.validate(userID, &category); validationErrs != nil {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	if err := ctrl.repo.Create(&category); err != nil {
+		log.Println("Failed to create payment category: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to create payment category")
+	}
+
+	return utils.CreatedResponse(c, "Payment category created successfully", ctrl.withPath(userID, &category))
+}
+
+// Update godoc
+// @Summary Update a payment category
+// @Description Rename, recolor or move a payment category. A parent_id of 0 moves it to the top level
+// @Tags payment_categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment category ID"
+// @Param request body dto.UpdatePaymentCategoryRequest true "Payment category data"
+// @Success 200 {object} utils.Response{data=models.PaymentCategory}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-categories/{id} [put]
+// @Security BearerAuth
+func (ctrl *PaymentCategoryController) Update(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	category, err := ctrl.find(c)
+	if err != nil || !policies.PaymentCategory.Update(userID, category) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment category not found")
+	}
+
+	var request dto.UpdatePaymentCategoryRequest
+
+	rules := govalidator.MapData{
+		"name":      []string{"max:100"},
+		"parent_id": []string{"numeric"},
+		"color":     []string{"regex:^#[0-9a-fA-F]{6}$"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if request.Name != nil {
+		category.Name = strings.TrimSpace(*request.Name)
+	}
+
+	if request.ParentID != nil {
+		category.ParentID = request.ParentID
+		if *request.ParentID == 0 {
+			category.ParentID = nil
+		}
+	}
+
+	if request.Color != nil {
+		category.Color = request.Color
+		if *request.Color == "" {
+			category.Color = nil
+		}
+	}
+
+	if validationErrs := ctrl.validate(userID, category); validationErrs != nil {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	err = ctrl.repo.UpdateFields(category.ID, map[string]interface{}{
+		"name":      category.Name,
+		"parent_id": category.ParentID,
+		"color":     category.Color,
+	})
+
+	if err != nil {
+		log.Println("Failed to update payment category: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to update payment category")
+	}
+
+	updated, err := ctrl.repo.FindByID(category.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment category not found")
+	}
+
+	return utils.SuccessResponse(c, "Payment category updated successfully", ctrl.withPath(userID, updated))
+}
+
+// Destroy godoc
+// @Summary Delete a payment category
+// @Description Delete a payment category without subcategories, its payments become uncategorized and its budgets are deleted
+// @Tags payment_categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment category ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-categories/{id} [delete]
+// @Security BearerAuth
+func (ctrl *PaymentCategoryController) Destroy(c *fiber.Ctx) error {
+	category, err := ctrl.find(c)
+	if err != nil || !policies.PaymentCategory.Delete(c.Locals("user_id").(uint), category) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment category not found")
+	}
+
+	if ctrl.repo.HasChildren(category.ID) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "This payment category has subcategories, delete or move them first")
+	}
+
+	err = ctrl.db.Transaction(func(tx *gorm.DB) error {
+		if err := ctrl.budget.DeleteByCategory(tx, category.ID); err != nil {
+			return err
+		}
+
+		return ctrl.repo.Delete(tx, category.ID)
+	})
+
+	if err != nil {
+		log.Println("Failed to delete payment category: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to delete payment category")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Payment category deleted successfully")
+}
+
+// Suggest godoc
+// @Summary Suggest a payment category
+// @Description Suggest categories for a payment name from the categories of past payments with a similar name, best match first
+// @Tags payment_categories
+// @Accept json
+// @Produce json
+// @Param name query string true "Payment name"
+// @Success 200 {object} utils.Response{data=[]dto.CategorySuggestionResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /payment-categories/suggest [get]
+// @Security BearerAuth
+func (ctrl *PaymentCategoryController) Suggest(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	name := strings.TrimSpace(c.Query("name"))
+
+	if name == "" {
+		return utils.ValidationError(c, map[string][]string{
+			"name": {"The name field is required"},
+		})
+	}
+
+	suggestions, err := ctrl.repo.Suggestions(userID, name, suggestionWords(name), 5)
+	if err != nil {
+		log.Println("Failed to suggest payment categories: ", err)
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to suggest payment categories")
+	}
+
+	categories, err := ctrl.repo.FindAllByUser(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to suggest payment categories")
+	}
+	paths := models.CategoryPaths(categories)
+
+	var total int64
+	for _, suggestion := range suggestions {
+		total += suggestion.Count
+	}
+
+	response := make([]dto.CategorySuggestionResponse, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		response = append(response, dto.CategorySuggestionResponse{
+			CategoryID: suggestion.CategoryID,
+			Name:       suggestion.Name,
+			Path:       paths[suggestion.CategoryID],
+			Score:      suggestion.Count,
+			Confidence: math.Round(float64(suggestion.Count)/float64(total)*100) / 100,
+		})
+	}
+
+	message := "No category suggestion found"
+	if len(response) > 0 {
+		message = "Payment category suggestions retrieved successfully"
+	}
+
+	return utils.SuccessResponse(c, message, response)
+}
+
+// validate checks the parent and the name of a new or changed category. The parent must be a category of the user
+// that is not the category itself or nested below it, and the hierarchy may not grow deeper than the maximum depth.
+func (ctrl *PaymentCategoryController) validate(userID uint, category *models.PaymentCategory) map[string][]string {
+	validationErrs := make(map[string][]string)
+
+	if category.Name == "" {
+		validationErrs["name"] = []string{"The name field is required"}
+	} else if ctrl.repo.ExistsByName(userID, category.ParentID, category.Name, category.ID) {
+		validationErrs["name"] = []string{"A category with this name already exists at this level"}
+	}
+
+	if category.ParentID != nil {
+		categories, err := ctrl.repo.FindAllByUser(userID)
+		if err != nil {
+			validationErrs["parent_id"] = []string{"The selected parent category is invalid"}
+			return validationErrs
+		}
+
+		parentID := *category.ParentID
+		found := false
+		for _, existing := range categories {
+			if existing.ID == parentID {
+				found = true
+				break
+			}
+		}
+
+		height := 1
+		if category.ID != 0 {
+			height = models.CategoryHeight(categories, category.ID)
+		}
+
+		if !found {
+			validationErrs["parent_id"] = []string{"The selected parent category is invalid"}
+		} else if category.ID != 0 && containsID(models.CategoryDescendants(categories, category.ID), parentID) {
+			validationErrs["parent_id"] = []string{"A category cannot be moved under itself or one of its subcategories"}
+		} else if models.CategoryDepth(categories, parentID)+height > models.PaymentCategoryMaxDepth {
+			validationErrs["parent_id"] = []string{"Categories can be nested at most " + strconv.Itoa(models.PaymentCategoryMaxDepth) + " levels deep"}
+		}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
+
+func (ctrl *PaymentCategoryController) withPath(userID uint, category *models.PaymentCategory) *models.PaymentCategory {
+	if categories, err := ctrl.repo.FindAllByUser(userID); err == nil {
+		category.Path = models.CategoryPaths(categories)[category.ID]
+	}
+	return category
+}
+
+func (ctrl *PaymentCategoryController) find(c *fiber.Ctx) (*models.PaymentCategory, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl.repo.FindByID(uint(id))
+}
+
+// suggestionWords returns the distinct lowercase words of a payment name worth matching, skipping numbers and
+// words shorter than three letters.
+func suggestionWords(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0, suggestionWordLimit)
+	seen := make(map[string]bool)
+
+	for _, field := range fields {
+		if len([]rune(field)) < 3 || seen[field] || strings.IndexFunc(field, unicode.IsLetter) < 0 {
+			continue
+		}
+
+		seen[field] = true
+		words = append(words, field)
+
+		if len(words) == suggestionWordLimit {
+			break
+		}
+	}
+
+	return words
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
@@ -13,9 +13,11 @@
 package controllers
 
 import (
+	"fmt"
 	"golang-api/internal/config"
 	"golang-api/internal/dto"
 	"golang-api/internal/models"
+	"golang-api/internal/policies"
 	"golang-api/internal/repositories"
 	"golang-api/internal/service/payment_service"
 	"golang-api/pkg/utils"
@@ -39,6 +41,8 @@ type PaymentController struct {
 	paymentAccount  *repositories.PaymentAccountRepository
 	paymentItemRepo *repositories.PaymentItemRepository
 	itemRepo        *repositories.ItemRepository
+	exchangeRate    *repositories.ExchangeRateRepository
+	category        *repositories.PaymentCategoryRepository
 	paymentService  payment_service.MainService
 	db              *gorm.DB
 }
@@ -49,6 +53,7 @@ func NewPaymentController(db *gorm.DB) *PaymentController {
 	paymentAccount := repositories.NewPaymentAccountRepository(db)
 	paymentItemRepo := repositories.NewPaymentItemRepository(db)
 	itemRepo := repositories.NewItemRepository(db)
+	exchangeRate := repositories.NewExchangeRateRepository(db)
 	paymentService := payment_service.NewMainService(db)
 
 	return &PaymentController{
@@ -57,22 +62,38 @@ func NewPaymentController(db *gorm.DB) *PaymentController {
 		paymentAccount:  paymentAccount,
 		paymentItemRepo: paymentItemRepo,
 		itemRepo:        itemRepo,
+		exchangeRate:    exchangeRate,
+		category:        repositories.NewPaymentCategoryRepository(db),
 		paymentService:  paymentService,
 		db:              db,
 	}
 }
 
 type SummaryResponse struct {
-	TotalBalance        int64           `json:"total_balance"`
-	ScheduledExpense    int64           `json:"scheduled_expense"`
-	TotalAfterScheduled int64           `json:"total_after_scheduled"`
-	InitialBalance      int64           `json:"initial_balance"`
-	Income              int64           `json:"income"`
-	Expenses            int64           `json:"expenses"`
-	Withdrawal          int64           `json:"withdrawal"`
-	Transfer            int64           `json:"transfer"`
-	Percents            SummaryPercents `json:"percents"`
-	Period              SummaryPeriod   `json:"period"`
+	Currency            string             `json:"currency" example:"IDR"`
+	ExchangeRates       map[string]float64 `json:"exchange_rates"`
+	TotalBalance        int64              `json:"total_balance"`
+	ScheduledExpense    int64              `json:"scheduled_expense"`
+	TotalAfterScheduled int64              `json:"total_after_scheduled"`
+	InitialBalance      int64              `json:"initial_balance"`
+	Income              int64              `json:"income"`
+	Expenses            int64              `json:"expenses"`
+	Withdrawal          int64              `json:"withdrawal"`
+	Transfer            int64              `json:"transfer"`
+	Percents            SummaryPercents    `json:"percents"`
+	Categories          []SummaryCategory  `json:"categories"`
+	Period              SummaryPeriod      `json:"period"`
+}
+
+// SummaryCategory holds the income and expenses of a category in the summary period. The totals of a category
+// include its subcategories, the payments without a category are grouped under a category without an ID.
+type SummaryCategory struct {
+	ID       *uint             `json:"id"`
+	Name     string            `json:"name" example:"Groceries"`
+	Path     string            `json:"path" example:"Food > Groceries"`
+	Income   int64             `json:"income"`
+	Expenses int64             `json:"expenses"`
+	Children []SummaryCategory `json:"children,omitempty"`
 }
 
 type SummaryPercents struct {
@@ -115,6 +136,9 @@ type OriginalAttachment struct {
 // @Param date_from query string false "Start date (YYYY-MM-DD)"
 // @Param date_to query string false "End date (YYYY-MM-DD)"
 // @Param search query string false "Search query"
+// @Param category_id query int false "Category ID, also matches its subcategories"
+// @Param tags query string false "Comma-separated tag names, matches payments with any of the tags"
+// @Param publish_failed query bool false "Only scheduled payments the scheduler failed to publish"
 // @Success 200 {object} utils.PaginatedResponse{data=[]PaymentSwagger}
 // @Failure 400 {object} utils.Response
 // @Router /payments [get]
@@ -125,6 +149,7 @@ func (ctrl *PaymentController) Index(c *fiber.Ctx) error {
 	perPage, _ := strconv.Atoi(c.Query("per_page", "10"))
 	typeID, _ := strconv.Atoi(c.Query("type", "0"))
 	accountID, _ := strconv.Atoi(c.Query("account_id", "0"))
+	categoryID, _ := strconv.Atoi(c.Query("category_id", "0"))
 
 	if page < 1 {
 		page = 1
@@ -135,12 +160,16 @@ func (ctrl *PaymentController) Index(c *fiber.Ctx) error {
 	}
 
 	filter := repositories.PaymentFilter{
-		DateFrom:  c.Query("date_from"),
-		DateTo:    c.Query("date_to"),
-		Type:      typeID,
-		AccountID: accountID,
-		Search:    c.Query("search"),
-		UserID:    userId,
+		DateFrom:    c.Query("date_from"),
+		DateTo:      c.Query("date_to"),
+		Type:        typeID,
+		AccountID:   accountID,
+		Search:      c.Query("search"),
+		UserID:      userId,
+		CategoryIDs: ctrl.category.FilterIDs(userId, categoryID),
+		Tags:        models.NormalizeTagNames(strings.Split(c.Query("tags"), ",")),
+
+		PublishFailed: c.QueryBool("publish_failed"),
 	}
 
 	total, err := ctrl.repo.Count(filter)
@@ -171,13 +200,14 @@ func (ctrl *PaymentController) Index(c *fiber.Ctx) error {
 // @Router /payments/{id} [get]
 // @Security BearerAuth
 func (ctrl *PaymentController) Show(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
 	paymentID, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
 	}
 
 	payment, err := ctrl.repo.FindByID(paymentID)
-	if err != nil {
+	if err != nil || !policies.Payment.View(userId, payment) {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
 	}
 
@@ -186,12 +216,13 @@ func (ctrl *PaymentController) Show(c *fiber.Ctx) error {
 
 // Summary godoc
 // @Summary Get payment summary
-// @Description Get summary of payments within a date range
+// @Description Get summary of payments within a date range. Amounts of other currencies are converted into the chosen currency, payments at the latest exchange rate on the end date and balances at the latest rate
 // @Tags payments
 // @Accept json
 // @Produce json
 // @Param startDate query string false "Start date (YYYY-MM-DD)"
 // @Param endDate query string false "End date (YYYY-MM-DD)"
+// @Param currency query string false "Currency to report in" default(IDR)
 // @Success 200 {object} utils.Response{data=SummaryResponse}
 // @Failure 422 {object} utils.ValidationErrorResponse
 // @Router /payments/summary [get]
@@ -202,6 +233,7 @@ func (ctrl *PaymentController) Summary(c *fiber.Ctx) error {
 	rules := govalidator.MapData{
 		"startDate": []string{"date"},
 		"endDate":   []string{"date"},
+		"currency":  []string{"in:" + utils.CurrencyCodes()},
 	}
 
 	errs := utils.ValidateJSON(c, &data, rules)
@@ -225,10 +257,22 @@ func (ctrl *PaymentController) Summary(c *fiber.Ctx) error {
 		endDate = time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, now.Location()).Format("2006-01-02")
 	}
 
+	currency, _ := data["currency"].(string)
+	if currency == "" {
+		currency = strings.ToUpper(c.Query("currency", utils.DefaultCurrency))
+	}
+
+	if _, ok := utils.Currencies[currency]; !ok {
+		return utils.ValidationError(c, map[string][]string{
+			"currency": {"The selected currency is invalid"},
+		})
+	}
+
 	userID := c.Locals("user_id").(uint)
 	db := config.GetDB()
 
-	var totals struct {
+	var totalsByCurrency []struct {
+		Currency         string
 		TotalIncome      int64
 		TotalExpense     int64
 		TotalWithdrawal  int64
@@ -240,19 +284,86 @@ func (ctrl *PaymentController) Summary(c *fiber.Ctx) error {
 		Where("user_id = ?", userID).
 		Where("date BETWEEN ? AND ?", startDate, endDate).
 		Select(`
+			currency,
 			SUM(CASE WHEN type_id = ? THEN amount ELSE 0 END) as total_income,
 			SUM(CASE WHEN type_id = ? THEN amount ELSE 0 END) as total_expense,
 			SUM(CASE WHEN type_id = ? THEN amount ELSE 0 END) as total_withdrawal,
 			SUM(CASE WHEN type_id = ? THEN amount ELSE 0 END) as total_transfer,
 			SUM(CASE WHEN type_id = ? AND is_scheduled = 1 THEN amount ELSE 0 END) as scheduled_expense
 		`, models.PaymentTypeIncome, models.PaymentTypeExpense, models.PaymentTypeWithdrawal, models.PaymentTypeTransfer, models.PaymentTypeExpense).
-		Scan(&totals)
+		Group("currency").
+		Scan(&totalsByCurrency)
+
+	var balancesByCurrency []struct {
+		Currency string
+		Balance  int64
+	}
 
-	var totalBalance int64
 	db.Model(&models.PaymentAccount{}).
 		Where("user_id = ?", userID).
-		Select("COALESCE(SUM(deposit), 0)").
-		Scan(&totalBalance)
+		Select("currency, COALESCE(SUM(deposit), 0) as balance").
+		Group("currency").
+		Scan(&balancesByCurrency)
+
+	rateDate, _ := time.ParseInLocation("2006-01-02", endDate, time.Local)
+	if rateDate.IsZero() || rateDate.After(time.Now()) {
+		rateDate = time.Now()
+	}
+
+	rates := map[string]float64{}
+	convert := func(amount int64, from string, date time.Time) (int64, error) {
+		if from == "" {
+			from = utils.DefaultCurrency
+		}
+
+		if from == currency || amount == 0 {
+			return amount, nil
+		}
+
+		rate, err := ctrl.exchangeRate.Rate(from, currency, date)
+		if err != nil {
+			return 0, fmt.Errorf("No exchange rate from %s to %s on or before %s", from, currency, date.Format("2006-01-02"))
+		}
+
+		rates[from] = rate
+		return utils.ConvertMoney(amount, from, currency, rate), nil
+	}
+
+	var totals struct {
+		TotalIncome      int64
+		TotalExpense     int64
+		TotalWithdrawal  int64
+		TotalTransfer    int64
+		ScheduledExpense int64
+	}
+
+	for _, row := range totalsByCurrency {
+		for _, total := range []struct {
+			amount int64
+			into   *int64
+		}{
+			{row.TotalIncome, &totals.TotalIncome},
+			{row.TotalExpense, &totals.TotalExpense},
+			{row.TotalWithdrawal, &totals.TotalWithdrawal},
+			{row.TotalTransfer, &totals.TotalTransfer},
+			{row.ScheduledExpense, &totals.ScheduledExpense},
+		} {
+			converted, err := convert(total.amount, row.Currency, rateDate)
+			if err != nil {
+				return utils.ValidationError(c, map[string][]string{"currency": {err.Error()}})
+			}
+			*total.into += converted
+		}
+	}
+
+	var totalBalance int64
+	for _, row := range balancesByCurrency {
+		converted, err := convert(row.Balance, row.Currency, time.Now())
+		if err != nil {
+			return utils.ValidationError(c, map[string][]string{"currency": {err.Error()}})
+		}
+		totalBalance += converted
+	}
 
 	initialBalance := totals.TotalIncome + totals.TotalExpense
 
@@ -267,7 +378,16 @@ func (ctrl *PaymentController) Summary(c *fiber.Ctx) error {
 
 	totalAfterScheduled := totalBalance - totals.ScheduledExpense
 
+	categories, err := ctrl.summaryCategories(userID, startDate, endDate, func(amount int64, from string) (int64, error) {
+		return convert(amount, from, rateDate)
+	})
+	if err != nil {
+		return utils.ValidationError(c, map[string][]string{"currency": {err.Error()}})
+	}
+
 	response := SummaryResponse{
+		Currency:            currency,
+		ExchangeRates:       rates,
 		TotalBalance:        totalBalance,
 		ScheduledExpense:    totals.ScheduledExpense,
 		TotalAfterScheduled: totalAfterScheduled,
@@ -282,6 +402,7 @@ func (ctrl *PaymentController) Summary(c *fiber.Ctx) error {
 			Withdrawal: percentWithdrawal,
 			Transfer:   percentTransfer,
 		},
+		Categories: categories,
 		Period: SummaryPeriod{
 			StartDate: startDate,
 			EndDate:   endDate,
@@ -291,6 +412,106 @@ func (ctrl *PaymentController) Summary(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, "Summary retrieved successfully", response)
 }
 
+// summaryCategories breaks the income and expenses of the period down by category, converted with the same
+// rates as the totals. Each category carries the totals of its subcategories.
+func (ctrl *PaymentController) summaryCategories(userID uint, startDate, endDate string, convert func(amount int64, from string) (int64, error)) ([]SummaryCategory, error) {
+	var rows []struct {
+		CategoryID *uint
+		Currency   string
+		Income     int64
+		Expenses   int64
+	}
+
+	err := ctrl.db.Model(&models.Payment{}).
+		Where("user_id = ?", userID).
+		Where("date BETWEEN ? AND ?", startDate, endDate).
+		Where("type_id IN ?", []uint{models.PaymentTypeIncome, models.PaymentTypeExpense}).
+		Select(`
+			category_id,
+			currency,
+			SUM(CASE WHEN type_id = ? THEN amount ELSE 0 END) as income,
+			SUM(CASE WHEN type_id = ? THEN amount ELSE 0 END) as expenses
+		`, models.PaymentTypeIncome, models.PaymentTypeExpense).
+		Group("category_id, currency").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := ctrl.category.FindAllByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := make(map[uint]*uint, len(all))
+	for _, category := range all {
+		parents[category.ID] = category.ParentID
+	}
+
+	income := make(map[uint]int64)
+	expenses := make(map[uint]int64)
+	uncategorized := SummaryCategory{Name: "Uncategorized", Path: "Uncategorized"}
+
+	for _, row := range rows {
+		rowIncome, err := convert(row.Income, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		rowExpenses, err := convert(row.Expenses, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if row.CategoryID == nil {
+			uncategorized.Income += rowIncome
+			uncategorized.Expenses += rowExpenses
+			continue
+		}
+
+		if _, ok := parents[*row.CategoryID]; !ok {
+			uncategorized.Income += rowIncome
+			uncategorized.Expenses += rowExpenses
+			continue
+		}
+
+		for id := row.CategoryID; id != nil; id = parents[*id] {
+			income[*id] += rowIncome
+			expenses[*id] += rowExpenses
+		}
+	}
+
+	var build func(categories []models.PaymentCategory) []SummaryCategory
+	build = func(categories []models.PaymentCategory) []SummaryCategory {
+		result := make([]SummaryCategory, 0, len(categories))
+
+		for _, category := range categories {
+			if income[category.ID] == 0 && expenses[category.ID] == 0 {
+				continue
+			}
+
+			id := category.ID
+			result = append(result, SummaryCategory{
+				ID:       &id,
+				Name:     category.Name,
+				Path:     category.Path,
+				Income:   income[category.ID],
+				Expenses: expenses[category.ID],
+				Children: build(category.Children),
+			})
+		}
+
+		return result
+	}
+
+	categories := build(models.CategoryTree(all))
+	if uncategorized.Income != 0 || uncategorized.Expenses != 0 {
+		categories = append(categories, uncategorized)
+	}
+
+	return categories, nil
+}
+
 // GetAttachments godoc
 // @Summary Get payment attachments
 // @Description Get a list of attachments for a specific payment
@@ -303,13 +524,14 @@ func (ctrl *PaymentController) Summary(c *fiber.Ctx) error {
 // @Router /payments/{id}/attachments [get]
 // @Security BearerAuth
 func (ctrl *PaymentController) GetAttachments(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
 	}
 
 	payment, err := ctrl.repo.FindByID(id)
-	if err != nil {
+	if err != nil || !policies.Payment.View(userId, payment) {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
 	}
 
@@ -372,13 +594,66 @@ func (ctrl *PaymentController) Store(c *fiber.Ctx) error {
 	return ctrl.paymentService.Store(c)
 }
 
+// Update godoc
+// @Summary Update payment
+// @Description Update a payment and re-apply its effect on the payment account balances
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment ID"
+// @Param body body dto.UpdatePaymentRequest true "Payment data"
+// @Success 200 {object} utils.Response{data=PaymentSwagger}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payments/{id} [put]
+// @Security BearerAuth
+func (ctrl *PaymentController) Update(c *fiber.Ctx) error {
+	return ctrl.paymentService.Update(c)
+}
+
+// Destroy godoc
+// @Summary Delete payment
+// @Description Delete a payment and reverse its effect on the payment account balances
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payments/{id} [delete]
+// @Security BearerAuth
+func (ctrl *PaymentController) Destroy(c *fiber.Ctx) error {
+	return ctrl.paymentService.Destroy(c)
+}
+
+// Publish godoc
+// @Summary Publish payment
+// @Description Turn a draft or scheduled payment into a real transaction and update the account balances
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment ID"
+// @Success 200 {object} utils.Response{data=PaymentSwagger}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payments/{id}/publish [post]
+// @Security BearerAuth
+func (ctrl *PaymentController) Publish(c *fiber.Ctx) error {
+	return ctrl.paymentService.Publish(c)
+}
+
 // @Summary Generate payment report
-// @Description Generate a payment report (daily, monthly, or date range)
+// @Description Generate a payment report (daily, monthly, or date range) as PDF and CSV files. The returned UID lists the files through /files/d/{uid}.
 // @Tags payments
 // @Accept json
 // @Produce json
 // @Param body body dto.GenerateReportRequest true "Report parameters"
-// @Success 200 {object} utils.SimpleResponse
+// @Success 200 {object} utils.Response{data=dto.GenerateReportResponse}
 // @Failure 400 {object} utils.SimpleErrorResponse
 // @Failure 401 {object} utils.UnauthorizedResponse
 // @Failure 422 {object} utils.ValidationErrorResponse
@@ -389,6 +664,29 @@ func (ctrl *PaymentController) GenerateReport(c *fiber.Ctx) error {
 	return ctrl.paymentService.GenerateReport(c)
 }
 
+// Export godoc
+// @Summary Export payments
+// @Description Download the filtered payments as a CSV or XLSX file
+// @Tags payments
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format" Enums(csv, xlsx) default(csv)
+// @Param type query int false "Type ID (1: Expense, 2: Income, 3: Transfer, 4: Withdrawal)"
+// @Param account_id query int false "Account ID"
+// @Param date_from query string false "Start date (YYYY-MM-DD)"
+// @Param date_to query string false "End date (YYYY-MM-DD)"
+// @Param search query string false "Search query"
+// @Param category_id query int false "Category ID, also matches its subcategories"
+// @Param tags query string false "Comma-separated tag names, matches payments with any of the tags"
+// @Success 200 {file} file
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payments/export [get]
+// @Security BearerAuth
+func (ctrl *PaymentController) Export(c *fiber.Ctx) error {
+	return ctrl.paymentService.Export(c)
+}
+
 // GetItemsSummary godoc
 // @Summary Get payment items summary
 // @Description Get summary of items attached to a specific payment
@@ -403,13 +701,14 @@ func (ctrl *PaymentController) GenerateReport(c *fiber.Ctx) error {
 // @Router /payments/{id}/items/summary [get]
 // @Security BearerAuth
 func (ctrl *PaymentController) GetItemsSummary(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
 	paymentID, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
 	}
 
 	payment, err := ctrl.repo.FindByID(paymentID)
-	if err != nil {
+	if err != nil || !policies.PaymentItem.View(userId, payment) {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
 	}
 
@@ -428,12 +727,11 @@ func (ctrl *PaymentController) GetItemsSummary(c *fiber.Ctx) error {
 	}
 
 	response := dto.PaymentItemSummaryResponse{
-		PaymentID:       summary.PaymentID,
-		PaymentCode:     payment.Code,
-		TotalItems:      summary.TotalItems,
-		TotalQty:        summary.TotalQty,
-		TotalAmount:     summary.TotalAmount,
-		FormattedAmount: utils.FormatRupiah(summary.TotalAmount),
+		PaymentID:   summary.PaymentID,
+		PaymentCode: payment.Code,
+		TotalItems:  summary.TotalItems,
+		TotalQty:    summary.TotalQty,
+		TotalAmount: summary.TotalAmount,
 	}
 
 	return utils.SuccessResponse(c, "Payment items summary retrieved successfully", response)
@@ -455,13 +753,14 @@ func (ctrl *PaymentController) GetItemsSummary(c *fiber.Ctx) error {
 // @Router /payments/{id}/items/attached [get]
 // @Security BearerAuth
 func (ctrl *PaymentController) GetItemsAttached(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
 	paymentID, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
 	}
 
-	_, err = ctrl.repo.FindByID(paymentID)
-	if err != nil {
+	payment, err := ctrl.repo.FindByID(paymentID)
+	if err != nil || !policies.PaymentItem.View(userId, payment) {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
 	}
 
@@ -496,17 +795,15 @@ func (ctrl *PaymentController) GetItemsAttached(c *fiber.Ctx) error {
 				itemType = "Service"
 			}
 			response = append(response, dto.PaymentItemAttachedResponse{
-				ID:             pi.Item.ID,
-				Name:           pi.Item.Name,
-				TypeID:         pi.Item.TypeID,
-				Type:           itemType,
-				Code:           pi.ItemCode,
-				Price:          pi.Price,
-				Quantity:       pi.Quantity,
-				Total:          pi.Total,
-				FormattedPrice: utils.FormatRupiah(pi.Price),
-				FormattedTotal: utils.FormatRupiah(pi.Total),
-				UpdatedAt:      pi.UpdatedAt,
+				ID:        pi.Item.ID,
+				Name:      pi.Item.Name,
+				TypeID:    pi.Item.TypeID,
+				Type:      itemType,
+				Code:      pi.ItemCode,
+				Price:     pi.Price,
+				Quantity:  pi.Quantity,
+				Total:     pi.Total,
+				UpdatedAt: pi.UpdatedAt,
 			})
 		}
 	}
@@ -531,13 +828,14 @@ func (ctrl *PaymentController) GetItemsAttached(c *fiber.Ctx) error {
 // @Router /payments/{id}/items/not-attached [get]
 // @Security BearerAuth
 func (ctrl *PaymentController) GetItemsNotAttached(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
 	paymentID, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
 	}
 
-	_, err = ctrl.repo.FindByID(paymentID)
-	if err != nil {
+	payment, err := ctrl.repo.FindByID(paymentID)
+	if err != nil || !policies.PaymentItem.View(userId, payment) {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
 	}
 
@@ -574,15 +872,14 @@ func (ctrl *PaymentController) GetItemsNotAttached(c *fiber.Ctx) error {
 			itemType = item.ItemType.Name
 		}
 		response = append(response, dto.ItemNotAttachedResponse{
-			ID:              item.ID,
-			Name:            item.Name,
-			TypeID:          item.TypeID,
-			Type:            itemType,
-			Code:            item.Code,
-			Amount:          item.Amount,
-			FormattedAmount: utils.FormatRupiah(item.Amount),
-			CreatedAt:       item.CreatedAt,
-			UpdatedAt:       item.UpdatedAt,
+			ID:        item.ID,
+			Name:      item.Name,
+			TypeID:    item.TypeID,
+			Type:      itemType,
+			Code:      item.Code,
+			Amount:    item.Amount,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
 		})
 	}
 
@@ -1,19 +1,36 @@
 package controllers
 
 import (
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
 	"golang-api/internal/repositories"
+	"golang-api/internal/service/payment_service"
 	"golang-api/pkg/utils"
+	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
 )
 
 type PaymentGoalController struct {
-	repo *repositories.PaymentGoalRepository
+	repo             *repositories.PaymentGoalRepository
+	generate         *repositories.GenerateRepository
+	goalContribution payment_service.GoalContributionService
+	db               *gorm.DB
 }
 
-func NewPaymentGoalController(repo *repositories.PaymentGoalRepository) *PaymentGoalController {
-	return &PaymentGoalController{repo: repo}
+func NewPaymentGoalController(db *gorm.DB) *PaymentGoalController {
+	return &PaymentGoalController{
+		repo:             repositories.NewPaymentGoalRepository(db),
+		generate:         repositories.NewGenerateRepository(db),
+		goalContribution: payment_service.NewGoalContributionService(db),
+		db:               db,
+	}
 }
 
 // Index godoc
@@ -103,3 +120,276 @@ func (ctrl *PaymentGoalController) Overview(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, "Overview retrieved successfully", response)
 }
+
+// Store godoc
+// @Summary Create a payment goal
+// @Description Create a savings goal. The amount already saved is optional, progress and status follow from it and the target date
+// @Tags payment-goals
+// @Accept json
+// @Produce json
+// @Param request body dto.StorePaymentGoalRequest true "Payment goal data"
+// @Success 201 {object} utils.Response{data=PaymentGoalSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-goals [post]
+// @Security BearerAuth
+func (ctrl *PaymentGoalController) Store(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	var request dto.StorePaymentGoalRequest
+
+	rules := govalidator.MapData{
+		"name":          []string{"required", "max:255"},
+		"description":   []string{"max:1000"},
+		"amount":        []string{"numeric"},
+		"target_amount": []string{"required", "numeric"},
+		"start_date":    []string{"required", "date:yyyy-mm-dd"},
+		"target_date":   []string{"required", "date:yyyy-mm-dd"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	startDate, _ := time.Parse("2006-01-02", request.StartDate)
+	targetDate, _ := time.Parse("2006-01-02", request.TargetDate)
+
+	goal := &models.PaymentGoal{
+		UserID:       userID,
+		Code:         ctrl.generate.GetCode("payment_goal", true),
+		Name:         strings.TrimSpace(request.Name),
+		Description:  request.Description,
+		Amount:       request.Amount,
+		TargetAmount: request.TargetAmount,
+		StartDate:    models.DateOnly(startDate),
+		TargetDate:   models.DateOnly(targetDate),
+	}
+
+	if validationErrs := validatePaymentGoal(goal); validationErrs != nil {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	goal.Recalculate(time.Now())
+
+	err := ctrl.db.Transaction(func(tx *gorm.DB) error {
+		return ctrl.repo.Create(tx, userID, userName, goal)
+	})
+
+	if err != nil {
+		log.Println("Failed to create payment goal: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to create payment goal")
+	}
+
+	created, err := ctrl.repo.FindByID(int(goal.ID), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment goal not found")
+	}
+
+	return utils.CreatedResponse(c, "Payment goal created successfully", created)
+}
+
+// Update godoc
+// @Summary Update a payment goal
+// @Description Update the name, description, target or dates of a payment goal. The saved amount only changes through contributions
+// @Tags payment-goals
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment Goal ID"
+// @Param request body dto.UpdatePaymentGoalRequest true "Payment goal data"
+// @Success 200 {object} utils.Response{data=PaymentGoalSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-goals/{id} [put]
+// @Security BearerAuth
+func (ctrl *PaymentGoalController) Update(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	goal, err := ctrl.find(c)
+	if err != nil || !policies.PaymentGoal.Update(userID, goal) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment goal not found")
+	}
+
+	var request dto.UpdatePaymentGoalRequest
+
+	rules := govalidator.MapData{
+		"name":          []string{"max:255"},
+		"description":   []string{"max:1000"},
+		"target_amount": []string{"numeric"},
+		"start_date":    []string{"date:yyyy-mm-dd"},
+		"target_date":   []string{"date:yyyy-mm-dd"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	prev := *goal
+
+	if request.Name != nil {
+		goal.Name = strings.TrimSpace(*request.Name)
+	}
+
+	if request.Description != nil {
+		goal.Description = request.Description
+		if *request.Description == "" {
+			goal.Description = nil
+		}
+	}
+
+	if request.TargetAmount != nil {
+		goal.TargetAmount = *request.TargetAmount
+	}
+
+	if request.StartDate != nil {
+		startDate, _ := time.Parse("2006-01-02", *request.StartDate)
+		goal.StartDate = models.DateOnly(startDate)
+	}
+
+	if request.TargetDate != nil {
+		targetDate, _ := time.Parse("2006-01-02", *request.TargetDate)
+		goal.TargetDate = models.DateOnly(targetDate)
+	}
+
+	if validationErrs := validatePaymentGoal(goal); validationErrs != nil {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	goal.Recalculate(time.Now())
+
+	err = ctrl.db.Transaction(func(tx *gorm.DB) error {
+		return ctrl.repo.Update(tx, userID, userName, goal, &prev)
+	})
+
+	if err != nil {
+		log.Println("Failed to update payment goal: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to update payment goal")
+	}
+
+	updated, err := ctrl.repo.FindByID(int(goal.ID), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment goal not found")
+	}
+
+	return utils.SuccessResponse(c, "Payment goal updated successfully", updated)
+}
+
+// Destroy godoc
+// @Summary Delete a payment goal
+// @Description Delete a payment goal. The payments contributed to it are kept
+// @Tags payment-goals
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment Goal ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-goals/{id} [delete]
+// @Security BearerAuth
+func (ctrl *PaymentGoalController) Destroy(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	goal, err := ctrl.find(c)
+	if err != nil || !policies.PaymentGoal.Delete(userID, goal) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment goal not found")
+	}
+
+	err = ctrl.db.Transaction(func(tx *gorm.DB) error {
+		return ctrl.repo.Delete(tx, userID, userName, goal)
+	})
+
+	if err != nil {
+		log.Println("Failed to delete payment goal: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to delete payment goal")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Payment goal deleted successfully")
+}
+
+// Contributions godoc
+// @Summary List payment goal contributions
+// @Description Get the contributions to a payment goal with the payments that moved the money, newest first
+// @Tags payment-goals
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment Goal ID"
+// @Success 200 {object} utils.Response{data=[]models.PaymentGoalContribution}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-goals/{id}/contributions [get]
+// @Security BearerAuth
+func (ctrl *PaymentGoalController) Contributions(c *fiber.Ctx) error {
+	goal, err := ctrl.find(c)
+	if err != nil || !policies.PaymentGoal.View(c.Locals("user_id").(uint), goal) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment goal not found")
+	}
+
+	contributions, err := ctrl.repo.FindContributions(goal.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve contributions")
+	}
+
+	return utils.SuccessResponse(c, "Contributions retrieved successfully", contributions)
+}
+
+// Contribute godoc
+// @Summary Contribute to a payment goal
+// @Description Move money from a payment account toward a payment goal. The contribution is booked as an expense, or as a transfer when payment_account_to_id names the account the money is saved on, and completes the goal once its target is reached
+// @Tags payment-goals
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment Goal ID"
+// @Param request body dto.ContributePaymentGoalRequest true "Contribution data"
+// @Success 201 {object} utils.Response{data=dto.ContributePaymentGoalResponse}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-goals/{id}/contributions [post]
+// @Security BearerAuth
+func (ctrl *PaymentGoalController) Contribute(c *fiber.Ctx) error {
+	return ctrl.goalContribution.Contribute(c)
+}
+
+func (ctrl *PaymentGoalController) find(c *fiber.Ctx) (*models.PaymentGoal, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl.repo.FindByID(id, c.Locals("user_id").(uint))
+}
+
+func validatePaymentGoal(goal *models.PaymentGoal) map[string][]string {
+	validationErrs := make(map[string][]string)
+
+	if goal.Name == "" {
+		validationErrs["name"] = []string{"The name field is required"}
+	}
+
+	if goal.Amount < 0 {
+		validationErrs["amount"] = []string{"The amount may not be negative"}
+	}
+
+	if goal.TargetAmount < 1 {
+		validationErrs["target_amount"] = []string{"The target amount must be greater than 0"}
+	}
+
+	if time.Time(goal.TargetDate).Before(time.Time(goal.StartDate)) {
+		validationErrs["target_date"] = []string{"The target date must be on or after the start date"}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Project Name: controllers
+ * File: payment_import_controller.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package controllers
+
+import (
+	"golang-api/internal/service/payment_service"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type PaymentImportController struct {
+	importService payment_service.ImportService
+}
+
+func NewPaymentImportController(db *gorm.DB) *PaymentImportController {
+	return &PaymentImportController{
+		importService: payment_service.NewImportService(db),
+	}
+}
+
+// Import godoc
+// @Summary Import a bank statement
+// @Description Parse a CSV, OFX or QIF bank statement into a preview of payments with likely duplicates flagged. CSV files use the mapping sent with the upload or the saved mapping of the account.
+// @Tags payment_accounts
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param file formData file true "Bank statement file"
+// @Param format formData string false "File format, guessed from the extension when empty" Enums(csv, ofx, qif)
+// @Param date_format formData string false "Date format for CSV and QIF files, e.g. DD/MM/YYYY"
+// @Param delimiter formData string false "CSV delimiter" default(,)
+// @Param has_header formData bool false "Whether the CSV file has a header row" default(true)
+// @Param date_column formData string false "CSV date column (header name or zero based index)"
+// @Param name_column formData string false "CSV description column"
+// @Param amount_column formData string false "CSV signed amount column"
+// @Param debit_column formData string false "CSV debit column, used when there is no amount column"
+// @Param credit_column formData string false "CSV credit column, used when there is no amount column"
+// @Param save_mapping formData bool false "Save the CSV mapping for the next imports"
+// @Success 200 {object} utils.Response{data=dto.PaymentImportPreviewResponse}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts/{id}/import [post]
+// @Security BearerAuth
+func (ctrl *PaymentImportController) Import(c *fiber.Ctx) error {
+	return ctrl.importService.Preview(c)
+}
+
+// Confirm godoc
+// @Summary Confirm a bank statement import
+// @Description Book the selected rows of an import preview as payments in one transaction. Without rows, every row not flagged as a duplicate is booked.
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param importId path int true "Import ID"
+// @Param request body dto.ConfirmPaymentImportRequest false "Rows to import"
+// @Success 200 {object} utils.Response{data=dto.PaymentImportPreviewResponse}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-accounts/{id}/import/{importId}/confirm [post]
+// @Security BearerAuth
+func (ctrl *PaymentImportController) Confirm(c *fiber.Ctx) error {
+	return ctrl.importService.Confirm(c)
+}
+
+// ShowMapping godoc
+// @Summary Get the CSV import mapping
+// @Description Get the saved CSV column mapping of a payment account
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Success 200 {object} utils.Response{data=models.PaymentImportMapping}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-accounts/{id}/import-mapping [get]
+// @Security BearerAuth
+func (ctrl *PaymentImportController) ShowMapping(c *fiber.Ctx) error {
+	return ctrl.importService.ShowMapping(c)
+}
+
+// SaveMapping godoc
+// @Summary Save the CSV import mapping
+// @Description Save the CSV column mapping of a payment account
+// @Tags payment_accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment account ID"
+// @Param request body dto.PaymentImportMappingRequest true "Column mapping"
+// @Success 200 {object} utils.Response{data=models.PaymentImportMapping}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-accounts/{id}/import-mapping [put]
+// @Security BearerAuth
+func (ctrl *PaymentImportController) SaveMapping(c *fiber.Ctx) error {
+	return ctrl.importService.SaveMapping(c)
+}
@@ -0,0 +1,195 @@
+/*
+ * Project Name: controllers
+ * File: payment_tag_controller.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package controllers
+
+import (
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type PaymentTagController struct {
+	repo *repositories.PaymentTagRepository
+	db   *gorm.DB
+}
+
+func NewPaymentTagController(db *gorm.DB) *PaymentTagController {
+	return &PaymentTagController{
+		repo: repositories.NewPaymentTagRepository(db),
+		db:   db,
+	}
+}
+
+// Index godoc
+// @Summary List payment tags
+// @Description Get the payment tags of the authenticated user with the number of payments using each tag
+// @Tags payment_tags
+// @Accept json
+// @Produce json
+// @Param search query string false "Search by tag name"
+// @Success 200 {object} utils.Response{data=[]models.PaymentTag}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /payment-tags [get]
+// @Security BearerAuth
+func (ctrl *PaymentTagController) Index(c *fiber.Ctx) error {
+	tags, err := ctrl.repo.FindAllByUser(c.Locals("user_id").(uint), c.Query("search"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve payment tags")
+	}
+
+	return utils.SuccessResponse(c, "Payment tags retrieved successfully", tags)
+}
+
+// Store godoc
+// @Summary Create a payment tag
+// @Description Create a payment tag. Tag names are stored in lowercase and are unique per user
+// @Tags payment_tags
+// @Accept json
+// @Produce json
+// @Param request body dto.StorePaymentTagRequest true "Payment tag data"
+// @Success 201 {object} utils.Response{data=models.PaymentTag}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-tags [post]
+// @Security BearerAuth
+func (ctrl *PaymentTagController) Store(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	name, errs := ctrl.validate(c, userID, 0)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	tag := models.PaymentTag{UserID: userID, Name: name}
+
+	if err := ctrl.repo.Create(&tag); err != nil {
+		log.Println("Failed to create payment tag: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to create payment tag")
+	}
+
+	return utils.CreatedResponse(c, "Payment tag created successfully", tag)
+}
+
+// Update godoc
+// @Summary Rename a payment tag
+// @Description Rename a payment tag, the payments using it keep the tag
+// @Tags payment_tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment tag ID"
+// @Param request body dto.StorePaymentTagRequest true "Payment tag data"
+// @Success 200 {object} utils.Response{data=models.PaymentTag}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /payment-tags/{id} [put]
+// @Security BearerAuth
+func (ctrl *PaymentTagController) Update(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	tag, err := ctrl.find(c)
+	if err != nil || !policies.PaymentTag.Update(userID, tag) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment tag not found")
+	}
+
+	name, errs := ctrl.validate(c, userID, tag.ID)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if err := ctrl.repo.UpdateName(tag.ID, name); err != nil {
+		log.Println("Failed to update payment tag: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to update payment tag")
+	}
+
+	tag.Name = name
+
+	return utils.SuccessResponse(c, "Payment tag updated successfully", tag)
+}
+
+// Destroy godoc
+// @Summary Delete a payment tag
+// @Description Delete a payment tag and remove it from every payment using it
+// @Tags payment_tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment tag ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /payment-tags/{id} [delete]
+// @Security BearerAuth
+func (ctrl *PaymentTagController) Destroy(c *fiber.Ctx) error {
+	tag, err := ctrl.find(c)
+	if err != nil || !policies.PaymentTag.Delete(c.Locals("user_id").(uint), tag) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment tag not found")
+	}
+
+	err = ctrl.db.Transaction(func(tx *gorm.DB) error {
+		return ctrl.repo.Delete(tx, tag.ID)
+	})
+
+	if err != nil {
+		log.Println("Failed to delete payment tag: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to delete payment tag")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Payment tag deleted successfully")
+}
+
+// validate parses the tag name from the request and returns it normalized, rejecting names already used by
+// another tag of the user.
+func (ctrl *PaymentTagController) validate(c *fiber.Ctx, userID uint, exceptID uint) (string, map[string][]string) {
+	var request dto.StorePaymentTagRequest
+
+	rules := govalidator.MapData{
+		"name": []string{"required", "max:50"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return "", errs
+	}
+
+	names := models.NormalizeTagNames([]string{request.Name})
+	if len(names) == 0 {
+		return "", map[string][]string{"name": {"The name field is required"}}
+	}
+
+	if existing, err := ctrl.repo.FindByName(userID, names[0]); err == nil && existing.ID != exceptID {
+		return "", map[string][]string{"name": {"A tag with this name already exists"}}
+	}
+
+	return names[0], nil
+}
+
+func (ctrl *PaymentTagController) find(c *fiber.Ctx) (*models.PaymentTag, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl.repo.FindByID(uint(id))
+}
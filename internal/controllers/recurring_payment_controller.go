@@ -0,0 +1,208 @@
+/*
+ * Project Name: controllers
+ * File: recurring_payment_controller.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package controllers
+
+import (
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/internal/service/payment_service"
+	"golang-api/pkg/utils"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type RecurringPaymentController struct {
+	repo      *repositories.RecurringPaymentRepository
+	recurring payment_service.RecurringService
+}
+
+func NewRecurringPaymentController(db *gorm.DB) *RecurringPaymentController {
+	return &RecurringPaymentController{
+		repo:      repositories.NewRecurringPaymentRepository(db),
+		recurring: payment_service.NewRecurringService(db),
+	}
+}
+
+// Index godoc
+// @Summary List recurring payments
+// @Description Get a paginated list of recurring payment rules
+// @Tags recurring_payments
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]RecurringPaymentSwagger}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Router /recurring-payments [get]
+// @Security BearerAuth
+func (ctrl *RecurringPaymentController) Index(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	perPage, _ := strconv.Atoi(c.Query("per_page", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+
+	if perPage < 1 {
+		perPage = 10
+	} else if perPage > 100 {
+		perPage = 100
+	}
+
+	total, err := ctrl.repo.Count(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to count recurring payments")
+	}
+
+	recurringPayments, err := ctrl.repo.FindAllPaginated(userID, page, perPage)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve recurring payments")
+	}
+
+	return utils.PaginatedSuccessResponse(c, "Recurring payments retrieved successfully", recurringPayments, page, perPage, total, len(recurringPayments))
+}
+
+// Show godoc
+// @Summary Get recurring payment details
+// @Description Get a single recurring payment rule by ID
+// @Tags recurring_payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring payment ID"
+// @Success 200 {object} utils.Response{data=RecurringPaymentSwagger}
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /recurring-payments/{id} [get]
+// @Security BearerAuth
+func (ctrl *RecurringPaymentController) Show(c *fiber.Ctx) error {
+	recurringPayment, err := ctrl.find(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Recurring payment not found")
+	}
+
+	return utils.SuccessResponse(c, "Recurring payment retrieved successfully", recurringPayment)
+}
+
+// Store godoc
+// @Summary Create a recurring payment
+// @Description Create a recurring payment rule. Upcoming occurrences are generated as scheduled payments by the scheduler.
+// @Tags recurring_payments
+// @Accept json
+// @Produce json
+// @Param request body dto.StoreRecurringPaymentRequest true "Recurring payment data"
+// @Success 200 {object} utils.Response{data=RecurringPaymentSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /recurring-payments [post]
+// @Security BearerAuth
+func (ctrl *RecurringPaymentController) Store(c *fiber.Ctx) error {
+	return ctrl.recurring.Store(c)
+}
+
+// Update godoc
+// @Summary Update a recurring payment
+// @Description Update the template, end date, count or active state of a recurring payment rule. The schedule itself cannot be changed; create a new rule instead. Changing the type to income or expense clears the destination account. Payments that were already generated are not changed.
+// @Tags recurring_payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring payment ID"
+// @Param request body dto.UpdateRecurringPaymentRequest true "Recurring payment data"
+// @Success 200 {object} utils.Response{data=RecurringPaymentSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /recurring-payments/{id} [put]
+// @Security BearerAuth
+func (ctrl *RecurringPaymentController) Update(c *fiber.Ctx) error {
+	return ctrl.recurring.Update(c)
+}
+
+// Destroy godoc
+// @Summary Delete a recurring payment
+// @Description Delete a recurring payment rule together with the generated payments that are still scheduled. Booked payments are kept.
+// @Tags recurring_payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring payment ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /recurring-payments/{id} [delete]
+// @Security BearerAuth
+func (ctrl *RecurringPaymentController) Destroy(c *fiber.Ctx) error {
+	return ctrl.recurring.Destroy(c)
+}
+
+// Occurrences godoc
+// @Summary Preview upcoming occurrences
+// @Description List the upcoming occurrences of a recurring payment rule, with any skip or override applied
+// @Tags recurring_payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring payment ID"
+// @Param limit query int false "Number of occurrences" default(10)
+// @Success 200 {object} utils.Response{data=[]dto.RecurringPaymentOccurrenceResponse}
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /recurring-payments/{id}/occurrences [get]
+// @Security BearerAuth
+func (ctrl *RecurringPaymentController) Occurrences(c *fiber.Ctx) error {
+	return ctrl.recurring.Occurrences(c)
+}
+
+// StoreOccurrence godoc
+// @Summary Skip or override a single occurrence
+// @Description Skip a single upcoming occurrence, or override its name, amount or payment account
+// @Tags recurring_payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring payment ID"
+// @Param request body dto.RecurringPaymentOccurrenceRequest true "Occurrence exception"
+// @Success 200 {object} utils.Response{data=models.RecurringPaymentOccurrence}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Router /recurring-payments/{id}/occurrences [post]
+// @Security BearerAuth
+func (ctrl *RecurringPaymentController) StoreOccurrence(c *fiber.Ctx) error {
+	return ctrl.recurring.StoreOccurrence(c)
+}
+
+// DestroyOccurrence godoc
+// @Summary Remove an occurrence exception
+// @Description Remove a skip or override so the occurrence follows the rule again
+// @Tags recurring_payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Recurring payment ID"
+// @Param occurrenceId path int true "Occurrence exception ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /recurring-payments/{id}/occurrences/{occurrenceId} [delete]
+// @Security BearerAuth
+func (ctrl *RecurringPaymentController) DestroyOccurrence(c *fiber.Ctx) error {
+	return ctrl.recurring.DestroyOccurrence(c)
+}
+
+func (ctrl *RecurringPaymentController) find(c *fiber.Ctx) (*models.RecurringPayment, error) {
+	userID := c.Locals("user_id").(uint)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl.repo.FindByID(id, userID)
+}
@@ -1,6 +1,9 @@
 package controllers
 
-import "time"
+import (
+	"golang-api/internal/models"
+	"time"
+)
 
 type UserSwagger struct {
 	ID                   uint      `json:"id"`
@@ -9,6 +12,10 @@ type UserSwagger struct {
 	HasAllowNotification *bool     `json:"has_allow_notification"`
 	NotificationToken    *string   `json:"-"`
 	AvatarUrl            *string   `json:"avatar_url"`
+	Role                 string    `json:"role" example:"member"`
+	DeactivatedAt        *string   `json:"deactivated_at"`
+	Locale               *string   `json:"locale" example:"id-ID"`
+	Timezone             *string   `json:"timezone" example:"Asia/Jakarta"`
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
 	DeletedAt            *string   `json:"deleted_at,omitempty"`
@@ -20,27 +27,68 @@ type AccountInfoSwagger struct {
 }
 
 type PaymentSwagger struct {
-	ID                 uint                `json:"id"`
-	UserID             uint                `json:"user_id"`
-	Code               string              `json:"code"`
-	Name               string              `json:"name"`
-	Date               string              `json:"date"`
-	Amount             int64               `json:"amount"`
-	HasItems           bool                `json:"has_items"`
-	IsScheduled        bool                `json:"is_scheduled"`
-	IsDraft            bool                `json:"is_draft"`
-	TypeID             uint                `json:"type_id"`
-	PaymentAccountID   *uint               `json:"payment_account_id"`
-	PaymentAccountToID *uint               `json:"payment_account_to_id"`
-	UpdatedAt          time.Time           `json:"updated_at"`
-	Type               string              `json:"type"`
-	FormattedAmount    string              `json:"formatted_amount"`
-	FormattedDate      string              `json:"formatted_date"`
-	FormattedUpdatedAt string              `json:"formatted_updated_at"`
-	AttachmentsCount   int                 `json:"attachments_count"`
-	ItemsCount         int                 `json:"items_count"`
-	Account            *AccountInfoSwagger `json:"account"`
-	AccountTo          *AccountInfoSwagger `json:"account_to"`
+	ID                 uint                    `json:"id"`
+	UserID             uint                    `json:"user_id"`
+	Code               string                  `json:"code"`
+	Name               string                  `json:"name"`
+	Date               string                  `json:"date"`
+	Amount             int64                   `json:"amount"`
+	Currency           string                  `json:"currency" example:"IDR"`
+	AmountTo           *int64                  `json:"amount_to"`
+	ExchangeRate       *float64                `json:"exchange_rate"`
+	HasItems           bool                    `json:"has_items"`
+	IsScheduled        bool                    `json:"is_scheduled"`
+	IsDraft            bool                    `json:"is_draft"`
+	TypeID             uint                    `json:"type_id"`
+	PaymentAccountID   *uint                   `json:"payment_account_id"`
+	PaymentAccountToID *uint                   `json:"payment_account_to_id"`
+	CategoryID         *uint                   `json:"category_id"`
+	PublishAttempts    int                     `json:"publish_attempts"`
+	PublishFailedAt    *time.Time              `json:"publish_failed_at"`
+	PublishError       *string                 `json:"publish_error" example:"Insufficient balance for this payment account (e01)"`
+	UpdatedAt          time.Time               `json:"updated_at"`
+	Type               string                  `json:"type"`
+	FormattedAmount    string                  `json:"formatted_amount"`
+	FormattedAmountTo  *string                 `json:"formatted_amount_to"`
+	FormattedDate      string                  `json:"formatted_date"`
+	FormattedUpdatedAt string                  `json:"formatted_updated_at"`
+	AttachmentsCount   int                     `json:"attachments_count"`
+	ItemsCount         int                     `json:"items_count"`
+	Account            *AccountInfoSwagger     `json:"account"`
+	AccountTo          *AccountInfoSwagger     `json:"account_to"`
+	Category           *models.PaymentCategory `json:"category"`
+	Tags               []models.PaymentTag     `json:"tags"`
+}
+
+type RecurringPaymentItemSwagger struct {
+	ItemID *uint  `json:"item_id"`
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+	Qty    int    `json:"qty"`
+}
+
+type RecurringPaymentSwagger struct {
+	ID                 uint                          `json:"id"`
+	UserID             uint                          `json:"user_id"`
+	Name               string                        `json:"name"`
+	Amount             int64                         `json:"amount"`
+	Currency           string                        `json:"currency"`
+	TypeID             uint                          `json:"type_id"`
+	PaymentAccountID   uint                          `json:"payment_account_id"`
+	PaymentAccountToID *uint                         `json:"payment_account_to_id"`
+	Items              []RecurringPaymentItemSwagger `json:"items"`
+	Frequency          string                        `json:"frequency"`
+	Interval           int                           `json:"interval"`
+	DayOfMonth         *int                          `json:"day_of_month"`
+	StartDate          string                        `json:"start_date"`
+	EndDate            *string                       `json:"end_date"`
+	Count              *int                          `json:"count"`
+	GeneratedCount     int                           `json:"generated_count"`
+	NextDate           *string                       `json:"next_date"`
+	IsActive           bool                          `json:"is_active"`
+	CreatedAt          time.Time                     `json:"created_at"`
+	UpdatedAt          time.Time                     `json:"updated_at"`
+	FormattedAmount    string                        `json:"formatted_amount"`
 }
 
 type FileSwagger struct {
@@ -84,11 +132,18 @@ type FormattedAccountSwagger struct {
 }
 
 type PaymentAccountSwagger struct {
-	ID        uint                    `json:"id"`
-	Name      string                  `json:"name"`
-	Deposit   int64                   `json:"deposit"`
-	Logo      string                  `json:"logo"`
-	Formatted FormattedAccountSwagger `json:"formatted"`
+	ID           uint                    `json:"id"`
+	Name         string                  `json:"name"`
+	Type         string                  `json:"type" example:"bank"`
+	Currency     string                  `json:"currency" example:"IDR"`
+	Deposit      int64                   `json:"deposit"`
+	CreditLimit  *int64                  `json:"credit_limit"`
+	StatementDay *int                    `json:"statement_day"`
+	DueDay       *int                    `json:"due_day"`
+	Logo         string                  `json:"logo"`
+	ArchivedAt   *string                 `json:"archived_at"`
+	Credit       *models.CreditSummary   `json:"credit,omitempty"`
+	Formatted    FormattedAccountSwagger `json:"formatted"`
 }
 
 type ActivityLogSwagger struct {
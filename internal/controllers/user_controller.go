@@ -1,24 +1,42 @@
 package controllers
 
 import (
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
 	"golang-api/internal/repositories"
+	"golang-api/internal/service"
+	"golang-api/internal/tokencache"
 	"golang-api/pkg/utils"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
 )
 
 type UserController struct {
-	repo *repositories.UserRepository
+	repo             *repositories.UserRepository
+	lockoutRepo      *repositories.AccountLockoutRepository
+	tokenRepo        *repositories.PersonalAccessTokenRepository
+	refreshTokenRepo *repositories.RefreshTokenRepository
+	generateRepo     *repositories.GenerateRepository
 }
 
-func NewUserController(repo *repositories.UserRepository) *UserController {
-	return &UserController{repo: repo}
+func NewUserController(db *gorm.DB) *UserController {
+	return &UserController{
+		repo:             repositories.NewUserRepository(db),
+		lockoutRepo:      repositories.NewAccountLockoutRepository(db),
+		tokenRepo:        repositories.NewPersonalAccessTokenRepository(db),
+		refreshTokenRepo: repositories.NewRefreshTokenRepository(db),
+		generateRepo:     repositories.NewGenerateRepository(db),
+	}
 }
 
 // Index godoc
 // @Summary List users
-// @Description Get a paginated list of users
+// @Description Get a paginated list of users. Admins see every user, members only see themselves
 // @Tags users
 // @Accept json
 // @Produce json
@@ -42,6 +60,20 @@ func (ctrl *UserController) Index(c *fiber.Ctx) error {
 		perPage = 15
 	}
 
+	if !isAdmin(c) {
+		user, err := ctrl.repo.FindByID(c.Locals("user_id").(uint))
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
+		}
+
+		users := []models.User{}
+		if page == 1 {
+			users = append(users, *user)
+		}
+
+		return utils.PaginatedSuccessResponse(c, "Users retrieved successfully", users, page, perPage, 1, len(users))
+	}
+
 	total, err := ctrl.repo.Count()
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to count users")
@@ -57,7 +89,7 @@ func (ctrl *UserController) Index(c *fiber.Ctx) error {
 
 // Show godoc
 // @Summary Get user details
-// @Description Get detailed information about a specific user
+// @Description Get detailed information about a specific user. Members can only get themselves
 // @Tags users
 // @Accept json
 // @Produce json
@@ -75,6 +107,10 @@ func (ctrl *UserController) Show(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
 	}
 
+	if !isAdmin(c) && uint(id) != c.Locals("user_id").(uint) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
+	}
+
 	user, err := ctrl.repo.FindByID(uint(id))
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
@@ -106,3 +142,309 @@ func (ctrl *UserController) ShowMe(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, "User retrieved successfully", user)
 }
+
+// Store godoc
+// @Summary Create a user
+// @Description Create a user with a verified email address. Requires an admin account
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body dto.StoreUserRequest true "User data"
+// @Success 201 {object} utils.Response{data=UserSwagger}
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /users [post]
+// @Security BearerAuth
+func (ctrl *UserController) Store(c *fiber.Ctx) error {
+	var request dto.StoreUserRequest
+
+	rules := govalidator.MapData{
+		"name":     []string{"required", "min:3", "max:255"},
+		"email":    []string{"required", "email", "max:255"},
+		"password": []string{"required", "min:6"},
+		"role":     []string{"in:admin,member"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	request.Email = strings.ToLower(strings.TrimSpace(request.Email))
+
+	if ctrl.repo.EmailTaken(request.Email, 0) {
+		return utils.ValidationError(c, map[string][]string{
+			"email": {"The email has already been taken"},
+		})
+	}
+
+	if request.Role == "" {
+		request.Role = models.RoleMember
+	}
+
+	hashedPassword, err := service.HashPassword(request.Password)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create user")
+	}
+
+	now := time.Now()
+	user := models.User{
+		Code:            ctrl.generateRepo.GetCode("user", true),
+		Name:            request.Name,
+		Email:           request.Email,
+		Password:        hashedPassword,
+		Role:            request.Role,
+		EmailVerifiedAt: &now,
+	}
+
+	if err := ctrl.repo.Create(&user); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create user")
+	}
+
+	return utils.CreatedResponse(c, "User created successfully", user)
+}
+
+// Update godoc
+// @Summary Update a user
+// @Description Update the name, email, password or role of a user. Empty fields are left unchanged, a new password signs the user out everywhere. Requires an admin account
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param user body dto.UpdateUserRequest true "User data"
+// @Success 200 {object} utils.Response{data=UserSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.ValidationErrorResponse
+// @Failure 500 {object} utils.SimpleErrorResponse
+// @Router /users/{id} [put]
+// @Security BearerAuth
+func (ctrl *UserController) Update(c *fiber.Ctx) error {
+	user, fiberErr := ctrl.findUser(c)
+	if fiberErr != nil {
+		return utils.ErrorResponse(c, fiberErr.Code, fiberErr.Message)
+	}
+
+	var request dto.UpdateUserRequest
+
+	rules := govalidator.MapData{
+		"name":     []string{"min:3", "max:255"},
+		"email":    []string{"email", "max:255"},
+		"password": []string{"min:6"},
+		"role":     []string{"in:admin,member"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	fields := map[string]interface{}{}
+
+	if request.Name != "" {
+		fields["name"] = request.Name
+	}
+
+	if email := strings.ToLower(strings.TrimSpace(request.Email)); email != "" && email != user.Email {
+		if ctrl.repo.EmailTaken(email, user.ID) {
+			return utils.ValidationError(c, map[string][]string{
+				"email": {"The email has already been taken"},
+			})
+		}
+		fields["email"] = email
+	}
+
+	if request.Role != "" && request.Role != user.Role {
+		if user.ID == c.Locals("user_id").(uint) {
+			return utils.ValidationError(c, map[string][]string{
+				"role": {"You cannot change your own role"},
+			})
+		}
+		fields["role"] = request.Role
+	}
+
+	if request.Password != "" {
+		hashedPassword, err := service.HashPassword(request.Password)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update user")
+		}
+		fields["password"] = hashedPassword
+	}
+
+	if len(fields) > 0 {
+		if err := ctrl.repo.UpdateFields(user.ID, fields); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update user")
+		}
+	}
+
+	if request.Password != "" {
+		ctrl.revokeTokens(user.ID)
+	}
+
+	user, err := ctrl.repo.FindByID(user.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
+	}
+
+	return utils.SuccessResponse(c, "User updated successfully", user)
+}
+
+// Deactivate godoc
+// @Summary Deactivate a user
+// @Description Block a user from signing in and revoke every token of the user. Requires an admin account
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} utils.Response{data=UserSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.SimpleErrorResponse
+// @Router /users/{id}/deactivate [post]
+// @Security BearerAuth
+func (ctrl *UserController) Deactivate(c *fiber.Ctx) error {
+	user, fiberErr := ctrl.findUser(c)
+	if fiberErr != nil {
+		return utils.ErrorResponse(c, fiberErr.Code, fiberErr.Message)
+	}
+
+	if user.ID == c.Locals("user_id").(uint) {
+		return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "You cannot deactivate your own account")
+	}
+
+	if !user.IsActive() {
+		return utils.SuccessResponse(c, "User is already deactivated", user)
+	}
+
+	now := time.Now()
+	if err := ctrl.repo.UpdateFields(user.ID, map[string]interface{}{"deactivated_at": now}); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to deactivate user")
+	}
+
+	ctrl.revokeTokens(user.ID)
+	user.DeactivatedAt = &now
+
+	return utils.SuccessResponse(c, "User deactivated successfully", user)
+}
+
+// Activate godoc
+// @Summary Activate a user
+// @Description Allow a deactivated user to sign in again. Requires an admin account
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} utils.Response{data=UserSwagger}
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /users/{id}/activate [post]
+// @Security BearerAuth
+func (ctrl *UserController) Activate(c *fiber.Ctx) error {
+	user, fiberErr := ctrl.findUser(c)
+	if fiberErr != nil {
+		return utils.ErrorResponse(c, fiberErr.Code, fiberErr.Message)
+	}
+
+	if err := ctrl.repo.UpdateFields(user.ID, map[string]interface{}{"deactivated_at": nil}); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to activate user")
+	}
+
+	user.DeactivatedAt = nil
+
+	return utils.SuccessResponse(c, "User activated successfully", user)
+}
+
+// Destroy godoc
+// @Summary Delete a user
+// @Description Soft delete a user and revoke every token of the user. Requires an admin account
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Failure 422 {object} utils.SimpleErrorResponse
+// @Router /users/{id} [delete]
+// @Security BearerAuth
+func (ctrl *UserController) Destroy(c *fiber.Ctx) error {
+	user, fiberErr := ctrl.findUser(c)
+	if fiberErr != nil {
+		return utils.ErrorResponse(c, fiberErr.Code, fiberErr.Message)
+	}
+
+	if user.ID == c.Locals("user_id").(uint) {
+		return utils.ErrorResponse(c, fiber.StatusUnprocessableEntity, "You cannot delete your own account")
+	}
+
+	if err := ctrl.repo.Delete(user.ID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to delete user")
+	}
+
+	ctrl.revokeTokens(user.ID)
+
+	return utils.SimpleSuccessResponse(c, "User deleted successfully")
+}
+
+// Unlock godoc
+// @Summary Unlock a user account
+// @Description Clear the failed login attempts and the login lock of a user. Requires an admin account
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} utils.SimpleResponse
+// @Failure 400 {object} utils.SimpleErrorResponse
+// @Failure 401 {object} utils.UnauthorizedResponse
+// @Failure 403 {object} utils.SimpleErrorResponse
+// @Failure 404 {object} utils.SimpleErrorResponse
+// @Router /users/{id}/unlock [post]
+// @Security BearerAuth
+func (ctrl *UserController) Unlock(c *fiber.Ctx) error {
+	user, fiberErr := ctrl.findUser(c)
+	if fiberErr != nil {
+		return utils.ErrorResponse(c, fiberErr.Code, fiberErr.Message)
+	}
+
+	if err := ctrl.lockoutRepo.DeleteByEmail(strings.ToLower(user.Email)); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to unlock user")
+	}
+
+	return utils.SimpleSuccessResponse(c, "User unlocked successfully")
+}
+
+// findUser loads the user addressed by the id route parameter.
+func (ctrl *UserController) findUser(c *fiber.Ctx) (*models.User, *fiber.Error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	user, err := ctrl.repo.FindByID(uint(id))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "User not found")
+	}
+
+	return user, nil
+}
+
+func (ctrl *UserController) revokeTokens(userID uint) {
+	ctrl.tokenRepo.DeleteByUserID(nil, userID)
+	ctrl.refreshTokenRepo.RevokeByUserID(nil, userID)
+	tokencache.Tokens.ForgetUser(userID)
+}
+
+func isAdmin(c *fiber.Ctx) bool {
+	role, _ := c.Locals("user_role").(string)
+	return role == models.RoleAdmin
+}
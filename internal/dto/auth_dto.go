@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type ChangePasswordRequest struct {
 	CurrentPassword         string `json:"current_password" validate:"required,min=6"`
 	NewPassword             string `json:"new_password" validate:"required,min=6"`
@@ -17,15 +19,104 @@ type ValidateTokenResponse struct {
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token                 string    `json:"token"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Email     string   `json:"email" validate:"required,email"`
+	Password  string   `json:"password" validate:"required,min=6"`
+	TokenName string   `json:"token_name" example:"auth_token"`
+	Abilities []string `json:"abilities" example:"payments:read"`
 }
 
 type UpdateProfileRequest struct {
-	Name  string `json:"name" validate:"required,min=3"`
+	Name     string  `json:"name" validate:"required,min=3"`
+	Email    string  `json:"email" validate:"required,email"`
+	Locale   *string `json:"locale" example:"en-US" enums:"id-ID,en-US,en-GB"`
+	Timezone *string `json:"timezone" example:"Asia/Jakarta"`
+}
+
+type StoreTokenRequest struct {
+	Name      string   `json:"name" validate:"required,max=255" example:"reporting"`
+	Abilities []string `json:"abilities" example:"payments:read"`
+	ExpiresAt *string  `json:"expires_at" example:"2027-01-31"`
+}
+
+type TokenResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Abilities  []string   `json:"abilities"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	IsCurrent  bool       `json:"is_current"`
+}
+
+type StoreTokenResponse struct {
+	Token       string        `json:"token"`
+	AccessToken TokenResponse `json:"access_token"`
+}
+
+type RegisterRequest struct {
+	Name                 string `json:"name" validate:"required,min=3"`
+	Email                string `json:"email" validate:"required,email"`
+	Password             string `json:"password" validate:"required,min=6"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,min=6"`
+}
+
+// VerifyEmailRequest carries the query parameters of the signed verification link.
+type VerifyEmailRequest struct {
+	ID        string `json:"id" validate:"required"`
+	Hash      string `json:"hash" validate:"required"`
+	Expires   string `json:"expires" validate:"required"`
+	Signature string `json:"signature" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
+
+type ResetPasswordRequest struct {
+	Email                string `json:"email" validate:"required,email"`
+	Token                string `json:"token" validate:"required"`
+	Password             string `json:"password" validate:"required,min=6"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,min=6"`
+}
+
+// TwoFactorChallengeResponse is returned by login instead of a token when the user has two-factor authentication enabled.
+type TwoFactorChallengeResponse struct {
+	TwoFactor      bool      `json:"two_factor" example:"true"`
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" example:"123456"`
+	RecoveryCode   string `json:"recovery_code" example:"a1b2c-3d4e5"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret        string   `json:"secret"`
+	OtpauthURI    string   `json:"otpauth_uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
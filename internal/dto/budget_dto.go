@@ -0,0 +1,58 @@
+package dto
+
+import "golang-api/pkg/utils"
+
+type StoreBudgetRequest struct {
+	Name             string  `json:"name" example:"Groceries"`
+	Scope            string  `json:"scope" example:"category" enums:"category,account"`
+	CategoryID       *uint   `json:"category_id" example:"1"`
+	PaymentAccountID *uint   `json:"payment_account_id" example:"1"`
+	Amount           int64   `json:"amount" example:"2000000"`
+	Currency         *string `json:"currency" example:"IDR"`
+	Rollover         bool    `json:"rollover" example:"false"`
+}
+
+type UpdateBudgetRequest struct {
+	Name     *string `json:"name" example:"Groceries"`
+	Amount   *int64  `json:"amount" example:"2000000"`
+	Currency *string `json:"currency" example:"IDR"`
+	Rollover *bool   `json:"rollover" example:"true"`
+}
+
+const (
+	BudgetStatusOnTrack  = "on_track"
+	BudgetStatusWarning  = "warning"
+	BudgetStatusExceeded = "exceeded"
+)
+
+// BudgetStatusResponse is the spending against a budget in a month. Available is the amount plus the carryover
+// from the previous month for budgets with rollover, Remaining is what is left of it and goes negative on overspend.
+type BudgetStatusResponse struct {
+	BudgetID           uint    `json:"budget_id"`
+	Name               string  `json:"name" example:"Groceries"`
+	Scope              string  `json:"scope" example:"category"`
+	CategoryID         *uint   `json:"category_id"`
+	PaymentAccountID   *uint   `json:"payment_account_id"`
+	Currency           string  `json:"currency" example:"IDR"`
+	Period             string  `json:"period" example:"2026-10"`
+	StartDate          string  `json:"start_date" example:"2026-10-01"`
+	EndDate            string  `json:"end_date" example:"2026-10-31"`
+	Amount             int64   `json:"amount"`
+	Carryover          int64   `json:"carryover"`
+	Available          int64   `json:"available"`
+	Spent              int64   `json:"spent"`
+	Remaining          int64   `json:"remaining"`
+	Percent            float64 `json:"percent" example:"82.5"`
+	Status             string  `json:"status" example:"warning" enums:"on_track,warning,exceeded"`
+	FormattedAmount    string  `json:"formatted_amount"`
+	FormattedAvailable string  `json:"formatted_available"`
+	FormattedSpent     string  `json:"formatted_spent"`
+	FormattedRemaining string  `json:"formatted_remaining"`
+}
+
+func (r *BudgetStatusResponse) Localize(f *utils.Formatter) {
+	r.FormattedAmount = f.Money(r.Amount, r.Currency)
+	r.FormattedAvailable = f.Money(r.Available, r.Currency)
+	r.FormattedSpent = f.Money(r.Spent, r.Currency)
+	r.FormattedRemaining = f.Money(r.Remaining, r.Currency)
+}
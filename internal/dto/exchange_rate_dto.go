@@ -0,0 +1,12 @@
+package dto
+
+type StoreExchangeRateRequest struct {
+	BaseCurrency  string  `json:"base_currency" example:"USD"`
+	QuoteCurrency string  `json:"quote_currency" example:"IDR"`
+	Rate          float64 `json:"rate" example:"16250"`
+	Date          string  `json:"date" example:"2026-10-18"`
+}
+
+type ImportExchangeRatesResponse struct {
+	Imported int `json:"imported"`
+}
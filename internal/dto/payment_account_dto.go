@@ -1,5 +1,10 @@
 package dto
 
+import (
+	"golang-api/internal/models"
+	"golang-api/pkg/utils"
+)
+
 type PaymentAccountLogProperties struct {
 	ID         uint   `json:"id"`
 	UserID     uint   `json:"-"`
@@ -8,3 +13,88 @@ type PaymentAccountLogProperties struct {
 	Difference *int64 `json:"difference"`
 	Logo       string `json:"-"`
 }
+
+type StorePaymentAccountRequest struct {
+	Name         string `json:"name" validate:"required"`
+	Type         string `json:"type" example:"bank" enums:"cash,bank,e_wallet,credit_card,loan"`
+	Currency     string `json:"currency" example:"IDR"`
+	Logo         string `json:"logo" example:"images/payment_account/bca.png"`
+	Deposit      int64  `json:"deposit"`
+	CreditLimit  *int64 `json:"credit_limit" example:"10000000"`
+	StatementDay *int   `json:"statement_day" example:"25"`
+	DueDay       *int   `json:"due_day" example:"10"`
+}
+
+type UpdatePaymentAccountRequest struct {
+	Name         *string `json:"name"`
+	Type         *string `json:"type" example:"bank" enums:"cash,bank,e_wallet,credit_card,loan"`
+	Currency     *string `json:"currency" example:"IDR"`
+	Logo         *string `json:"logo" example:"images/payment_account/bca.png"`
+	Deposit      *int64  `json:"deposit"`
+	CreditLimit  *int64  `json:"credit_limit" example:"10000000"`
+	StatementDay *int    `json:"statement_day" example:"25"`
+	DueDay       *int    `json:"due_day" example:"10"`
+}
+
+type PayCreditRequest struct {
+	PaymentAccountID uint   `json:"payment_account_id"`
+	Amount           *int64 `json:"amount"`
+	Date             string `json:"date" example:"2026-10-18"`
+}
+
+type BalanceHistoryPoint struct {
+	Date             string `json:"date" example:"2026-03-01"`
+	Balance          int64  `json:"balance"`
+	Change           int64  `json:"change"`
+	FormattedBalance string `json:"formatted_balance"`
+}
+
+type BalanceHistoryResponse struct {
+	PaymentAccountID uint                  `json:"payment_account_id"`
+	Currency         string                `json:"currency" example:"IDR"`
+	From             string                `json:"from" example:"2026-03-01"`
+	To               string                `json:"to" example:"2026-03-31"`
+	Interval         string                `json:"interval" example:"day"`
+	OpeningBalance   int64                 `json:"opening_balance"`
+	ClosingBalance   int64                 `json:"closing_balance"`
+	Points           []BalanceHistoryPoint `json:"points"`
+}
+
+func (r *BalanceHistoryResponse) Localize(f *utils.Formatter) {
+	for i := range r.Points {
+		r.Points[i].FormattedBalance = f.Money(r.Points[i].Balance, r.Currency)
+	}
+}
+
+type BalanceAtResponse struct {
+	PaymentAccountID uint   `json:"payment_account_id"`
+	Currency         string `json:"currency" example:"IDR"`
+	Date             string `json:"date" example:"2026-03-01"`
+	Balance          int64  `json:"balance"`
+	FormattedBalance string `json:"formatted_balance"`
+}
+
+func (r *BalanceAtResponse) Localize(f *utils.Formatter) {
+	r.FormattedBalance = f.Money(r.Balance, r.Currency)
+}
+
+type ReconcileRequest struct {
+	StatementBalance  int64  `json:"statement_balance"`
+	ClearedPaymentIDs []uint `json:"cleared_payment_ids"`
+	CreateAdjustment  bool   `json:"create_adjustment"`
+	Date              string `json:"date" example:"2026-10-18"`
+}
+
+type ReconciliationPreviewResponse struct {
+	PaymentAccountID     uint                          `json:"payment_account_id"`
+	BookBalance          int64                         `json:"book_balance"`
+	StatementBalance     *int64                        `json:"statement_balance"`
+	Difference           *int64                        `json:"difference"`
+	LastReconciliation   *models.PaymentReconciliation `json:"last_reconciliation"`
+	UnreconciledPayments []models.Payment              `json:"unreconciled_payments"`
+}
+
+type ReconcileResponse struct {
+	Reconciliation    models.PaymentReconciliation `json:"reconciliation"`
+	AdjustmentPayment *models.Payment              `json:"adjustment_payment"`
+}
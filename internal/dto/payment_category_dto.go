@@ -0,0 +1,25 @@
+package dto
+
+type StorePaymentCategoryRequest struct {
+	Name     string  `json:"name" example:"Groceries"`
+	ParentID *uint   `json:"parent_id" example:"1"`
+	Color    *string `json:"color" example:"#4CAF50"`
+}
+
+type UpdatePaymentCategoryRequest struct {
+	Name     *string `json:"name" example:"Groceries"`
+	ParentID *uint   `json:"parent_id" example:"1"`
+	Color    *string `json:"color" example:"#4CAF50"`
+}
+
+type CategorySuggestionResponse struct {
+	CategoryID uint    `json:"category_id"`
+	Name       string  `json:"name" example:"Groceries"`
+	Path       string  `json:"path" example:"Food > Groceries"`
+	Score      int64   `json:"score"`
+	Confidence float64 `json:"confidence" example:"0.75"`
+}
+
+type StorePaymentTagRequest struct {
+	Name string `json:"name" example:"groceries"`
+}
@@ -2,19 +2,50 @@ package dto
 
 import (
 	"encoding/json"
+	"golang-api/pkg/utils"
 	"time"
 )
 
 type StorePaymentRequest struct {
-	Name               *string `json:"name"`
-	Amount             *int64  `json:"amount"`
-	TypeID             uint    `json:"type_id"`
-	Date               string  `json:"date"`
-	PaymentAccountID   uint    `json:"payment_account_id"`
-	PaymentAccountToID *uint   `json:"payment_account_to_id"`
-	HasItems           bool    `json:"has_items"`
-	IsDraft            bool    `json:"is_draft"`
-	IsScheduled        bool    `json:"is_scheduled"`
+	Name               *string  `json:"name"`
+	Amount             *int64   `json:"amount"`
+	AmountTo           *int64   `json:"amount_to"`
+	TypeID             uint     `json:"type_id"`
+	Date               string   `json:"date"`
+	PaymentAccountID   uint     `json:"payment_account_id"`
+	PaymentAccountToID *uint    `json:"payment_account_to_id"`
+	HasItems           bool     `json:"has_items"`
+	IsDraft            bool     `json:"is_draft"`
+	IsScheduled        bool     `json:"is_scheduled"`
+	CategoryID         *uint    `json:"category_id"`
+	Tags               []string `json:"tags" example:"groceries,weekly"`
+}
+
+type UpdatePaymentRequest struct {
+	Name               *string   `json:"name"`
+	Amount             *int64    `json:"amount"`
+	AmountTo           *int64    `json:"amount_to"`
+	TypeID             uint      `json:"type_id"`
+	Date               string    `json:"date"`
+	PaymentAccountID   uint      `json:"payment_account_id"`
+	PaymentAccountToID *uint     `json:"payment_account_to_id"`
+	CategoryID         *uint     `json:"category_id"`
+	Tags               *[]string `json:"tags" example:"groceries,weekly"`
+}
+
+type PaymentExportRow struct {
+	ID            uint      `json:"id"`
+	Code          string    `json:"code"`
+	Date          time.Time `json:"date"`
+	Name          *string   `json:"name"`
+	Amount        *int64    `json:"amount"`
+	Currency      string    `json:"currency"`
+	IsScheduled   bool      `json:"is_scheduled"`
+	IsDraft       bool      `json:"is_draft"`
+	TypeName      *string   `json:"type_name"`
+	AccountName   *string   `json:"account_name"`
+	AccountToName *string   `json:"account_to_name"`
+	ItemsCount    int       `json:"items_count"`
 }
 
 type PaymentLogProperties struct {
@@ -31,6 +62,7 @@ type PaymentLogProperties struct {
 	TypeID             uint            `json:"type_id"`
 	PaymentAccountID   uint            `json:"payment_account_id"`
 	PaymentAccountToID *uint           `json:"payment_account_to_id"`
+	CategoryID         *uint           `json:"category_id"`
 }
 
 type PaymentItemSummaryResponse struct {
@@ -42,6 +74,10 @@ type PaymentItemSummaryResponse struct {
 	FormattedAmount string `json:"formatted_amount"`
 }
 
+func (r *PaymentItemSummaryResponse) Localize(f *utils.Formatter) {
+	r.FormattedAmount = f.Money(r.TotalAmount, utils.DefaultCurrency)
+}
+
 type PaymentItemAttachedResponse struct {
 	ID             uint      `json:"id"`
 	Name           string    `json:"name"`
@@ -56,6 +92,11 @@ type PaymentItemAttachedResponse struct {
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+func (r *PaymentItemAttachedResponse) Localize(f *utils.Formatter) {
+	r.FormattedPrice = f.Money(r.Price, utils.DefaultCurrency)
+	r.FormattedTotal = f.Money(r.Total, utils.DefaultCurrency)
+}
+
 type ItemNotAttachedResponse struct {
 	ID              uint      `json:"id"`
 	Name            string    `json:"name"`
@@ -68,6 +109,10 @@ type ItemNotAttachedResponse struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+func (r *ItemNotAttachedResponse) Localize(f *utils.Formatter) {
+	r.FormattedAmount = f.Money(r.Amount, utils.DefaultCurrency)
+}
+
 type PaymentItemSummary struct {
 	PaymentID   uint  `gorm:"column:payment_id" json:"payment_id"`
 	TotalItems  int64 `gorm:"column:total_items" json:"total_items"`
@@ -82,6 +127,11 @@ type GenerateReportRequest struct {
 	Periode    string `json:"periode"`
 }
 
+type GenerateReportResponse struct {
+	UID       string    `json:"uid"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type AttachMultipleItemsItem struct {
 	Name   string `json:"name"`
 	Amount int64  `json:"amount"`
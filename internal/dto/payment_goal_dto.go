@@ -0,0 +1,45 @@
+package dto
+
+import "golang-api/internal/models"
+
+type PaymentGoalLogProperties struct {
+	ID              uint   `json:"id"`
+	Code            string `json:"code"`
+	Name            string `json:"name"`
+	StatusID        uint   `json:"status_id"`
+	Amount          int64  `json:"amount"`
+	TargetAmount    int64  `json:"target_amount"`
+	ProgressPercent int    `json:"progress_percent"`
+	StartDate       string `json:"start_date"`
+	TargetDate      string `json:"target_date"`
+}
+
+type StorePaymentGoalRequest struct {
+	Name         string  `json:"name" example:"Emergency fund"`
+	Description  *string `json:"description" example:"Six months of expenses"`
+	Amount       int64   `json:"amount" example:"0"`
+	TargetAmount int64   `json:"target_amount" example:"30000000"`
+	StartDate    string  `json:"start_date" example:"2026-10-01"`
+	TargetDate   string  `json:"target_date" example:"2027-09-30"`
+}
+
+type UpdatePaymentGoalRequest struct {
+	Name         *string `json:"name" example:"Emergency fund"`
+	Description  *string `json:"description" example:"Six months of expenses"`
+	TargetAmount *int64  `json:"target_amount" example:"30000000"`
+	StartDate    *string `json:"start_date" example:"2026-10-01"`
+	TargetDate   *string `json:"target_date" example:"2027-09-30"`
+}
+
+type ContributePaymentGoalRequest struct {
+	PaymentAccountID   uint   `json:"payment_account_id" example:"1"`
+	PaymentAccountToID *uint  `json:"payment_account_to_id" example:"2"`
+	Amount             int64  `json:"amount" example:"500000"`
+	Date               string `json:"date" example:"2026-10-18"`
+}
+
+type ContributePaymentGoalResponse struct {
+	Goal         *models.PaymentGoal             `json:"goal"`
+	Contribution *models.PaymentGoalContribution `json:"contribution"`
+	Payment      *models.Payment                 `json:"payment"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "golang-api/internal/models"
+
+type PaymentImportMappingRequest struct {
+	Delimiter    string  `json:"delimiter" form:"delimiter"`
+	HasHeader    *bool   `json:"has_header" form:"has_header"`
+	DateColumn   string  `json:"date_column" form:"date_column"`
+	DateFormat   string  `json:"date_format" form:"date_format"`
+	NameColumn   string  `json:"name_column" form:"name_column"`
+	AmountColumn *string `json:"amount_column" form:"amount_column"`
+	DebitColumn  *string `json:"debit_column" form:"debit_column"`
+	CreditColumn *string `json:"credit_column" form:"credit_column"`
+}
+
+type ConfirmPaymentImportRequest struct {
+	Rows []int `json:"rows"`
+}
+
+type PaymentImportPreviewResponse struct {
+	ID            uint                      `json:"id"`
+	Format        string                    `json:"format"`
+	FileName      string                    `json:"file_name"`
+	Status        string                    `json:"status"`
+	TotalRows     int                       `json:"total_rows"`
+	DuplicateRows int                       `json:"duplicate_rows"`
+	ImportedRows  int                       `json:"imported_rows"`
+	Rows          []models.PaymentImportRow `json:"rows"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "golang-api/pkg/utils"
+
+type StoreRecurringPaymentRequest struct {
+	Name               string                    `json:"name"`
+	Amount             int64                     `json:"amount"`
+	TypeID             uint                      `json:"type_id"`
+	PaymentAccountID   uint                      `json:"payment_account_id"`
+	PaymentAccountToID *uint                     `json:"payment_account_to_id"`
+	Items              []AttachMultipleItemsItem `json:"items"`
+	Frequency          string                    `json:"frequency"`
+	Interval           int                       `json:"interval"`
+	DayOfMonth         *int                      `json:"day_of_month"`
+	StartDate          string                    `json:"start_date"`
+	EndDate            *string                   `json:"end_date"`
+	Count              *int                      `json:"count"`
+	IsActive           *bool                     `json:"is_active"`
+}
+
+type UpdateRecurringPaymentRequest struct {
+	Name               *string                   `json:"name"`
+	Amount             *int64                    `json:"amount"`
+	TypeID             uint                      `json:"type_id"`
+	PaymentAccountID   uint                      `json:"payment_account_id"`
+	PaymentAccountToID *uint                     `json:"payment_account_to_id"`
+	Items              []AttachMultipleItemsItem `json:"items"`
+	EndDate            *string                   `json:"end_date"`
+	Count              *int                      `json:"count"`
+	IsActive           *bool                     `json:"is_active"`
+}
+
+type RecurringPaymentOccurrenceRequest struct {
+	Date             string  `json:"date"`
+	Action           string  `json:"action"`
+	Name             *string `json:"name"`
+	Amount           *int64  `json:"amount"`
+	PaymentAccountID *uint   `json:"payment_account_id"`
+}
+
+type RecurringPaymentOccurrenceResponse struct {
+	Date             string  `json:"date"`
+	Name             string  `json:"name"`
+	Amount           int64   `json:"amount"`
+	Currency         string  `json:"currency"`
+	FormattedAmount  string  `json:"formatted_amount"`
+	PaymentAccountID uint    `json:"payment_account_id"`
+	Action           *string `json:"action"`
+	ExceptionID      *uint   `json:"exception_id"`
+	PaymentID        *uint   `json:"payment_id"`
+}
+
+func (r *RecurringPaymentOccurrenceResponse) Localize(f *utils.Formatter) {
+	r.FormattedAmount = f.Money(r.Amount, r.Currency)
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"golang-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireAbility rejects requests whose access token lacks any of the given abilities.
+// It must run after Auth, which stores the token in the request locals.
+func RequireAbility(abilities ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := c.Locals("token").(models.PersonalAccessToken)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"message": "Unauthorized: No token provided",
+			})
+		}
+
+		for _, ability := range abilities {
+			if !token.Can(ability) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"success": false,
+					"message": "Forbidden: The access token does not have the " + ability + " ability",
+				})
+			}
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"golang-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireAdmin rejects requests unless the user has the admin role and the access token has the admin ability.
+// It must run after Auth, which stores the user role and the token in the request locals.
+func RequireAdmin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("user_role").(string)
+		if role != models.RoleAdmin {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"message": "Forbidden: This action requires an admin account",
+			})
+		}
+
+		return RequireAbility(models.AbilityAdmin)(c)
+	}
+}
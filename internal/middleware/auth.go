@@ -3,8 +3,8 @@ package middleware
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"golang-api/internal/models"
 	"golang-api/internal/repositories"
+	"golang-api/internal/tokencache"
 	"strconv"
 	"strings"
 	"time"
@@ -57,43 +57,61 @@ func Auth(db *gorm.DB) fiber.Handler {
 		hash := sha256.Sum256([]byte(plainTextToken))
 		hashedToken := hex.EncodeToString(hash[:])
 
-		result, err := PersonalAccessTokenRepo.FindByIDAndHashedTokenWithUser(tokenID, hashedToken)
-
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"message": "Unauthorized: Invalid token",
-			})
+		entry, ok := tokencache.Tokens.Get(tokenID, hashedToken)
+
+		if !ok {
+			result, err := PersonalAccessTokenRepo.FindByIDAndHashedTokenWithUser(tokenID, hashedToken)
+
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"message": "Unauthorized: Invalid token",
+				})
+			}
+
+			if result.UserDeactivatedAt != nil {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"success": false,
+					"message": "Forbidden: Your account has been deactivated",
+				})
+			}
+
+			entry = &tokencache.Entry{
+				Token:        result.PersonalAccessToken,
+				UserName:     result.UserName,
+				UserRole:     result.UserRole,
+				UserLocale:   result.UserLocale,
+				UserTimezone: result.UserTimezone,
+			}
+
+			tokencache.Tokens.Put(tokenID, hashedToken, *entry)
 		}
 
-		if result.ExpiresAt != nil && result.ExpiresAt.Before(time.Now()) {
+		token := entry.Token
+
+		if token.ExpiresAt != nil && token.ExpiresAt.Before(time.Now()) {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"success": false,
 				"message": "Unauthorized: Token expired",
 			})
 		}
 
-		token := &models.PersonalAccessToken{
-			ID:            result.ID,
-			TokenableType: result.TokenableType,
-			TokenableID:   result.TokenableID,
-			Name:          result.Name,
-			Token:         result.Token,
-			Abilities:     result.Abilities,
-			LastUsedAt:    result.LastUsedAt,
-			ExpiresAt:     result.ExpiresAt,
-			CreatedAt:     result.CreatedAt,
-			UpdatedAt:     result.UpdatedAt,
-		}
-
-		fields := map[string]interface{}{"last_used_at": time.Now()}
-		PersonalAccessTokenRepo.UpdateFields(token, fields)
+		tokencache.LastUsed.Touch(token.ID)
 
 		UserId := token.TokenableID
 
-		c.Locals("token", *token)
+		c.Locals("token", token)
 		c.Locals("user_id", UserId)
-		c.Locals("user_name", result.UserName)
+		c.Locals("user_name", entry.UserName)
+		c.Locals("user_role", entry.UserRole)
+
+		// The preferences of the user take over from the Accept-Language locale set by the Locale middleware.
+		if entry.UserLocale != nil {
+			c.Locals("locale", *entry.UserLocale)
+		}
+		if entry.UserTimezone != nil {
+			c.Locals("timezone", *entry.UserTimezone)
+		}
 
 		return c.Next()
 	}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"golang-api/internal/tokencache"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	benchTokenID    = 42
+	benchPlainToken = "benchmark-plain-text-token"
+)
+
+// newAuthBenchmark returns an app with one route behind Auth, a database mock that answers n token lookups and a
+// counter of the queries GORM sent to it.
+func newAuthBenchmark(b *testing.B, n int) (*fiber.App, sqlmock.Sqlmock, *int64) {
+	b.Helper()
+
+	conn, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var queries int64
+	count := func(*gorm.DB) { atomic.AddInt64(&queries, 1) }
+	db.Callback().Query().After("gorm:query").Register("bench:count_query", count)
+	db.Callback().Row().After("gorm:row").Register("bench:count_row", count)
+
+	hash := sha256.Sum256([]byte(benchPlainToken))
+	for i := 0; i < n; i++ {
+		mock.ExpectQuery("FROM `personal_access_tokens`").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tokenable_type", "tokenable_id", "name", "token", "abilities", "user_name", "user_role"}).
+				AddRow(benchTokenID, "App\\Models\\User", 1, "auth_token", hex.EncodeToString(hash[:]), `["*"]`, "Benchmark", "member"))
+	}
+
+	app := fiber.New()
+	app.Get("/", Auth(db), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	return app, mock, &queries
+}
+
+func newAuthRequest() *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(fiber.MethodGet)
+	ctx.Request.SetRequestURI("/")
+	ctx.Request.Header.Set(fiber.HeaderAuthorization, "Bearer 42|"+benchPlainToken)
+	return ctx
+}
+
+func serveAuth(b *testing.B, handler fasthttp.RequestHandler) {
+	ctx := newAuthRequest()
+	handler(ctx)
+	if ctx.Response.StatusCode() != http.StatusNoContent {
+		b.Fatalf("unexpected status %d", ctx.Response.StatusCode())
+	}
+}
+
+// BenchmarkAuthColdCache looks every token up in the database, as every request did before the cache.
+func BenchmarkAuthColdCache(b *testing.B) {
+	tokencache.Tokens.Forget(benchTokenID)
+	b.Cleanup(func() { tokencache.Tokens.Forget(benchTokenID) })
+
+	app, mock, queries := newAuthBenchmark(b, b.N)
+	handler := app.Handler()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		tokencache.Tokens.Forget(benchTokenID)
+		serveAuth(b, handler)
+	}
+
+	b.StopTimer()
+	b.ReportMetric(float64(*queries)/float64(b.N), "queries/op")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// BenchmarkAuthWarmCache only looks the token up on the first request, later requests are answered from the cache.
+func BenchmarkAuthWarmCache(b *testing.B) {
+	tokencache.Tokens.Forget(benchTokenID)
+	b.Cleanup(func() { tokencache.Tokens.Forget(benchTokenID) })
+
+	app, mock, queries := newAuthBenchmark(b, 1)
+	handler := app.Handler()
+
+	serveAuth(b, handler)
+	atomic.StoreInt64(queries, 0)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		serveAuth(b, handler)
+	}
+
+	b.StopTimer()
+	b.ReportMetric(float64(*queries)/float64(b.N), "queries/op")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		b.Fatal(err)
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"golang-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale picks the locale responses are formatted with from the Accept-Language header and reports it back in
+// Content-Language. Auth replaces the locale and timezone with the preferences of the user when they are set.
+func Locale() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tag := utils.NegotiateLocale(c.Get(fiber.HeaderAcceptLanguage))
+		if tag == "" {
+			tag = utils.DefaultLocale
+		}
+
+		c.Locals("locale", tag)
+		c.Locals("timezone", utils.DefaultTimezone)
+
+		err := c.Next()
+
+		c.Set(fiber.HeaderContentLanguage, utils.FormatterFromContext(c).Locale.Tag)
+		c.Vary(fiber.HeaderAcceptLanguage)
+
+		return err
+	}
+}
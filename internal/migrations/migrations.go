@@ -0,0 +1,89 @@
+/*
+ * Project Name: migrations
+ * File: migrations.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package migrations
+
+import (
+	"golang-api/internal/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// Run creates the tables owned by this service. Tables shared with the main app
+// (users, payments, payment_accounts, ...) are never auto-migrated from here,
+// only the columns this service owns are added to them, see addColumns.
+func Run(db *gorm.DB) {
+	hasRecurringCurrency := db.Migrator().HasColumn(&models.RecurringPayment{}, "Currency")
+
+	err := db.AutoMigrate(
+		&models.RecurringPayment{},
+		&models.RecurringPaymentOccurrence{},
+		&models.PaymentImport{},
+		&models.PaymentImportMapping{},
+		&models.RefreshToken{},
+		&models.LoginAttempt{},
+		&models.TwoFactorChallenge{},
+		&models.AccountLockout{},
+		&models.PaymentAccountBalance{},
+		&models.PaymentReconciliation{},
+		&models.ExchangeRate{},
+		&models.PaymentCategory{},
+		&models.PaymentTag{},
+		&models.PaymentPaymentTag{},
+		&models.Budget{},
+		&models.BudgetAlert{},
+		&models.PaymentGoalContribution{},
+	)
+
+	if err != nil {
+		log.Fatal("Failed to run database migrations:", err)
+	}
+
+	addColumns(db, &models.User{}, "Role", "DeactivatedAt", "Locale", "Timezone", "TwoFactorLastStep")
+	addColumns(db, &models.PaymentAccount{}, "ArchivedAt", "Type", "CreditLimit", "StatementDay", "DueDay", "Currency")
+	addColumns(db, &models.Payment{}, "ClearedAt", "ReconciliationID", "Currency", "AmountTo", "ExchangeRate", "CategoryID", "PublishAttempts", "PublishFailedAt", "PublishError")
+
+	if !hasRecurringCurrency {
+		syncRecurringCurrency(db)
+	}
+
+	log.Println("Database migrations completed successfully!")
+}
+
+// syncRecurringCurrency sets the currency of recurring payments created before they had one to the currency of
+// their payment account.
+func syncRecurringCurrency(db *gorm.DB) {
+	err := db.Exec(`UPDATE recurring_payments
+		JOIN payment_accounts ON payment_accounts.id = recurring_payments.payment_account_id
+		SET recurring_payments.currency = payment_accounts.currency
+		WHERE payment_accounts.currency IS NOT NULL AND payment_accounts.currency <> ''`).Error
+
+	if err != nil {
+		log.Fatal("Failed to set the currency of recurring payments:", err)
+	}
+}
+
+// addColumns adds the columns this service owns on a shared table, leaving the rest of the table alone.
+func addColumns(db *gorm.DB, model interface{}, fields ...string) {
+	migrator := db.Migrator()
+
+	for _, field := range fields {
+		if migrator.HasColumn(model, field) {
+			continue
+		}
+
+		if err := migrator.AddColumn(model, field); err != nil {
+			log.Fatal("Failed to add column "+field+":", err)
+		}
+	}
+}
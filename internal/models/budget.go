@@ -0,0 +1,74 @@
+/*
+ * Project Name: models
+ * File: budget.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"time"
+
+	"golang-api/pkg/utils"
+)
+
+const (
+	BudgetScopeCategory = "category"
+	BudgetScopeAccount  = "account"
+)
+
+// BudgetAlertThresholds are the percentages of a budget that trigger an overspend alert, lowest first.
+var BudgetAlertThresholds = []int{80, 100}
+
+// Budget caps the monthly expenses of a category, including its subcategories, or of a payment account. With
+// Rollover the amount left over from the previous month, or the overspend, is carried into the current one.
+type Budget struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"index" json:"user_id"`
+	Name             string    `gorm:"size:100;not null" json:"name"`
+	Scope            string    `gorm:"size:20;not null" json:"scope"`
+	CategoryID       *uint     `gorm:"index" json:"category_id"`
+	PaymentAccountID *uint     `gorm:"index" json:"payment_account_id"`
+	Amount           int64     `gorm:"not null" json:"amount"`
+	Currency         string    `gorm:"size:3;not null" json:"currency"`
+	Rollover         bool      `gorm:"not null;default:false" json:"rollover"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	Category        *PaymentCategory `gorm:"foreignKey:CategoryID" json:"category"`
+	PaymentAccount  *PaymentAccount  `gorm:"foreignKey:PaymentAccountID" json:"payment_account"`
+	FormattedAmount string           `gorm:"-" json:"formatted_amount"`
+}
+
+func (b *Budget) Localize(f *utils.Formatter) {
+	b.FormattedAmount = f.Money(b.Amount, b.Currency)
+}
+
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// IsBudgetScope reports whether the value is one of the known budget scopes.
+func IsBudgetScope(scope string) bool {
+	return scope == BudgetScopeCategory || scope == BudgetScopeAccount
+}
+
+// BudgetAlert records that the alert for a threshold of a budget was sent in a month, so it is sent only once.
+type BudgetAlert struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	BudgetID  uint      `gorm:"not null;uniqueIndex:idx_budget_alerts_budget_period_threshold,priority:1" json:"budget_id"`
+	Period    string    `gorm:"size:7;not null;uniqueIndex:idx_budget_alerts_budget_period_threshold,priority:2" json:"period"`
+	Threshold int       `gorm:"not null;uniqueIndex:idx_budget_alerts_budget_period_threshold,priority:3" json:"threshold"`
+	Spent     int64     `json:"spent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (BudgetAlert) TableName() string {
+	return "budget_alerts"
+}
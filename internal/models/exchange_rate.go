@@ -0,0 +1,37 @@
+/*
+ * Project Name: models
+ * File: exchange_rate.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import "time"
+
+const (
+	ExchangeRateSourceManual = "manual"
+	ExchangeRateSourceImport = "import"
+)
+
+// ExchangeRate is the price of one major unit of the base currency in the quote currency on a day, e.g.
+// USD to IDR at 16250. Conversions use the latest rate on or before the day of the converted amount.
+type ExchangeRate struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	BaseCurrency  string    `gorm:"size:3;not null;uniqueIndex:idx_exchange_rates_pair_date,priority:1" json:"base_currency"`
+	QuoteCurrency string    `gorm:"size:3;not null;uniqueIndex:idx_exchange_rates_pair_date,priority:2" json:"quote_currency"`
+	Date          time.Time `gorm:"type:date;not null;uniqueIndex:idx_exchange_rates_pair_date,priority:3" json:"date"`
+	Rate          float64   `gorm:"type:decimal(20,10);not null" json:"rate"`
+	Source        string    `gorm:"size:20;not null" json:"source"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
@@ -0,0 +1,59 @@
+/*
+ * Project Name: models
+ * File: login_attempt.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"time"
+)
+
+const (
+	LoginAttemptReasonInvalidCredentials = "invalid_credentials"
+	LoginAttemptReasonLocked             = "locked"
+	LoginAttemptReasonTwoFactorRequired  = "two_factor_required"
+	LoginAttemptReasonTwoFactorFailed    = "two_factor_failed"
+	LoginAttemptReasonDeactivated        = "deactivated"
+)
+
+// LoginAttempt records every password or two-factor login attempt. UserID is empty when the email is unknown.
+type LoginAttempt struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     *uint     `gorm:"index" json:"user_id"`
+	Email      string    `gorm:"size:255;index;not null" json:"email"`
+	IPAddress  *string   `gorm:"size:45" json:"ip_address"`
+	UserAgent  *string   `gorm:"type:text" json:"user_agent"`
+	Successful bool      `gorm:"not null;default:false" json:"successful"`
+	Reason     *string   `gorm:"size:50" json:"reason"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+// AccountLockout counts consecutive failed logins of an email address, whatever IP address they come from.
+type AccountLockout struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	Email          string     `gorm:"size:255;uniqueIndex;not null" json:"email"`
+	FailedAttempts int        `gorm:"not null;default:0" json:"failed_attempts"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (AccountLockout) TableName() string {
+	return "account_lockouts"
+}
+
+func (l AccountLockout) IsLocked() bool {
+	return l.LockedUntil != nil && l.LockedUntil.After(time.Now())
+}
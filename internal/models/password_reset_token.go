@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// PasswordResetToken is a row of the password_reset_tokens table shared with the main app, one per email address.
+type PasswordResetToken struct {
+	Email     string     `gorm:"primaryKey;size:255" json:"email"`
+	Token     string     `gorm:"size:255;not null" json:"-"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
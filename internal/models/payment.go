@@ -1,6 +1,7 @@
 package models
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"golang-api/pkg/utils"
@@ -17,6 +18,10 @@ func (d DateOnly) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("\"%s\"", t.Format("2006-01-02"))), nil
 }
 
+func (d DateOnly) Value() (driver.Value, error) {
+	return time.Time(d), nil
+}
+
 type Payment struct {
 	ID                 uint            `gorm:"primaryKey" json:"id"`
 	UserID             uint            `json:"user_id"`
@@ -24,6 +29,9 @@ type Payment struct {
 	Name               *string         `json:"name"`
 	Date               time.Time       `json:"date"`
 	Amount             *int64          `json:"amount"`
+	Currency           string          `gorm:"size:3;not null;default:IDR" json:"currency"`
+	AmountTo           *int64          `json:"amount_to"`
+	ExchangeRate       *float64        `gorm:"type:decimal(20,10)" json:"exchange_rate"`
 	HasItems           bool            `json:"has_items"`
 	IsScheduled        bool            `json:"is_scheduled"`
 	IsDraft            bool            `json:"is_draft"`
@@ -31,15 +39,24 @@ type Payment struct {
 	TypeID             uint            `json:"type_id"`
 	PaymentAccountID   uint            `json:"payment_account_id"`
 	PaymentAccountToID *uint           `json:"payment_account_to_id"`
+	CategoryID         *uint           `gorm:"index" json:"category_id"`
+	ClearedAt          *time.Time      `json:"cleared_at"`
+	ReconciliationID   *uint           `gorm:"index" json:"reconciliation_id"`
+	PublishAttempts    int             `gorm:"not null;default:0" json:"publish_attempts"`
+	PublishFailedAt    *time.Time      `json:"publish_failed_at"`
+	PublishError       *string         `gorm:"size:255" json:"publish_error"`
 	CreatedAt          time.Time       `json:"created_at"`
 	UpdatedAt          time.Time       `json:"updated_at"`
 
-	PaymentType      *PaymentType    `gorm:"foreignKey:TypeID" json:"-"`
-	PaymentAccount   *PaymentAccount `gorm:"foreignKey:PaymentAccountID" json:"-"`
-	PaymentAccountTo *PaymentAccount `gorm:"foreignKey:PaymentAccountToID" json:"-"`
+	PaymentType      *PaymentType     `gorm:"foreignKey:TypeID" json:"-"`
+	PaymentAccount   *PaymentAccount  `gorm:"foreignKey:PaymentAccountID" json:"-"`
+	PaymentAccountTo *PaymentAccount  `gorm:"foreignKey:PaymentAccountToID" json:"-"`
+	Category         *PaymentCategory `gorm:"foreignKey:CategoryID" json:"category"`
+	Tags             []PaymentTag     `gorm:"many2many:payment_payment_tag" json:"tags"`
 
 	Type               string       `gorm:"-" json:"type"`
 	FormattedAmount    string       `gorm:"-" json:"formatted_amount"`
+	FormattedAmountTo  *string      `gorm:"-" json:"formatted_amount_to"`
 	FormattedDate      string       `gorm:"-" json:"formatted_date"`
 	FormattedUpdatedAt string       `gorm:"-" json:"formatted_updated_at"`
 	AttachmentsCount   int          `gorm:"-" json:"attachments_count"`
@@ -48,6 +65,10 @@ type Payment struct {
 	AccountTo          *AccountInfo `gorm:"-" json:"account_to"`
 }
 
+// MaxPublishAttempts is how often the scheduler tries to publish a scheduled payment before it gives up, until
+// the payment is updated or published by hand.
+const MaxPublishAttempts = 10
+
 type AccountInfo struct {
 	ID   *uint   `json:"id"`
 	Name *string `json:"name"`
@@ -57,6 +78,39 @@ func (Payment) TableName() string {
 	return "payments"
 }
 
+// IsReconciled reports whether the payment was cleared by an account reconciliation.
+func (p *Payment) IsReconciled() bool {
+	return p.ReconciliationID != nil
+}
+
+// DestinationAmount returns the amount credited to the destination account of a transfer or withdrawal, which
+// differs from the amount for transfers between accounts of different currencies.
+func (p *Payment) DestinationAmount() int64 {
+	if p.AmountTo != nil {
+		return *p.AmountTo
+	}
+	if p.Amount != nil {
+		return *p.Amount
+	}
+	return 0
+}
+
+// NextPublishAttempt returns when the scheduler may retry publishing the payment, the delay doubles from 5 minutes
+// after every failed attempt up to a day. It is nil when publishing has not failed.
+func (p *Payment) NextPublishAttempt() *time.Time {
+	if p.PublishFailedAt == nil || p.PublishAttempts < 1 {
+		return nil
+	}
+
+	delay := 24 * time.Hour
+	if p.PublishAttempts < 10 {
+		delay = min(5*time.Minute<<(p.PublishAttempts-1), delay)
+	}
+
+	next := p.PublishFailedAt.Add(delay)
+	return &next
+}
+
 func (p *Payment) GetAttachmentsCount() int {
 	if len(p.Attachments) == 0 {
 		return 0
@@ -70,15 +124,6 @@ func (p *Payment) GetAttachmentsCount() int {
 }
 
 func (p *Payment) AfterFind(tx *gorm.DB) (err error) {
-	amount := int64(0)
-
-	if p.Amount != nil {
-		amount = *p.Amount
-	}
-
-	p.FormattedUpdatedAt = utils.FormatDateID(p.UpdatedAt, "Monday, 2 Jan 2006, 15.04 WIB")
-	p.FormattedAmount = utils.FormatRupiah(amount)
-	p.FormattedDate = utils.FormatDateID(time.Time(p.Date), "Monday, 2 Jan 2006")
 	p.AttachmentsCount = p.GetAttachmentsCount()
 
 	if p.PaymentType != nil {
@@ -97,5 +142,25 @@ func (p *Payment) AfterFind(tx *gorm.DB) (err error) {
 		p.AccountTo = &AccountInfo{}
 	}
 
+	if p.Tags == nil {
+		p.Tags = []PaymentTag{}
+	}
+
 	return
 }
+
+func (p *Payment) Localize(f *utils.Formatter) {
+	amount := int64(0)
+
+	if p.Amount != nil {
+		amount = *p.Amount
+	}
+
+	p.FormattedUpdatedAt = f.DateTime(p.UpdatedAt)
+	p.FormattedAmount = f.Money(amount, p.Currency)
+	p.FormattedDate = f.Date(p.Date)
+
+	if p.AmountTo != nil && p.PaymentAccountTo != nil {
+		p.FormattedAmountTo = utils.String(f.Money(*p.AmountTo, p.PaymentAccountTo.Currency))
+	}
+}
@@ -14,17 +14,120 @@ package models
 
 import (
 	"golang-api/pkg/utils"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+const (
+	PaymentAccountTypeCash       = "cash"
+	PaymentAccountTypeBank       = "bank"
+	PaymentAccountTypeEWallet    = "e_wallet"
+	PaymentAccountTypeCreditCard = "credit_card"
+	PaymentAccountTypeLoan       = "loan"
+)
+
+var PaymentAccountTypes = []string{
+	PaymentAccountTypeCash,
+	PaymentAccountTypeBank,
+	PaymentAccountTypeEWallet,
+	PaymentAccountTypeCreditCard,
+	PaymentAccountTypeLoan,
+}
+
+// PaymentAccount balances are positive for money held. Credit types may go negative, the negative balance being
+// the debt owed, down to the credit limit when one is set.
 type PaymentAccount struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	UserID    uint      `json:"-"`
-	Name      string    `json:"name"`
-	Deposit   int64     `json:"deposit"`
-	Logo      string    `json:"logo"`
-	Formatted Formatted `gorm:"-" json:"formatted"`
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	UserID       uint           `json:"-"`
+	Name         string         `json:"name"`
+	Type         string         `gorm:"size:20;not null;default:bank" json:"type"`
+	Currency     string         `gorm:"size:3;not null;default:IDR" json:"currency"`
+	Deposit      int64          `json:"deposit"`
+	CreditLimit  *int64         `json:"credit_limit"`
+	StatementDay *int           `json:"statement_day"`
+	DueDay       *int           `json:"due_day"`
+	Logo         string         `json:"logo"`
+	ArchivedAt   *time.Time     `json:"archived_at"`
+	Credit       *CreditSummary `gorm:"-" json:"credit,omitempty"`
+	Formatted    Formatted      `gorm:"-" json:"formatted"`
+}
+
+// CreditSummary describes the debt of a credit card or loan account.
+type CreditSummary struct {
+	Outstanding          int64   `json:"outstanding"`
+	Available            *int64  `json:"available"`
+	NextStatementAt      *string `json:"next_statement_at"`
+	NextDueAt            *string `json:"next_due_at"`
+	FormattedOutstanding string  `json:"formatted_outstanding"`
+}
+
+func (p PaymentAccount) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// IsCredit reports whether the account holds debt and may have a negative balance.
+func (p PaymentAccount) IsCredit() bool {
+	return p.Type == PaymentAccountTypeCreditCard || p.Type == PaymentAccountTypeLoan
+}
+
+// Outstanding returns the debt owed on a credit account.
+func (p PaymentAccount) Outstanding() int64 {
+	if !p.IsCredit() || p.Deposit >= 0 {
+		return 0
+	}
+	return -p.Deposit
+}
+
+// CanSpend reports whether the amount can be taken out of the account without going below zero, or below the
+// credit limit for credit accounts. Credit accounts without a limit can always be spent from.
+func (p PaymentAccount) CanSpend(amount int64) bool {
+	if !p.IsCredit() {
+		return amount <= p.Deposit
+	}
+
+	if p.CreditLimit == nil {
+		return true
+	}
+
+	return p.Deposit-amount >= -*p.CreditLimit
+}
+
+// NextStatement returns the next statement closing date on or after the given day and the due date following it.
+func (p PaymentAccount) NextStatement(from time.Time) (statementAt *time.Time, dueAt *time.Time) {
+	if p.StatementDay == nil {
+		return nil, nil
+	}
+
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+
+	closing := dayOfMonth(day.Year(), day.Month(), *p.StatementDay, day.Location())
+	if closing.Before(day) {
+		closing = dayOfMonth(day.Year(), day.Month()+1, *p.StatementDay, day.Location())
+	}
+	statementAt = &closing
+
+	if p.DueDay != nil {
+		due := dayOfMonth(closing.Year(), closing.Month(), *p.DueDay, closing.Location())
+		if !due.After(closing) {
+			due = dayOfMonth(closing.Year(), closing.Month()+1, *p.DueDay, closing.Location())
+		}
+		dueAt = &due
+	}
+
+	return statementAt, dueAt
+}
+
+// dayOfMonth returns the given day of the month, or the last day for months that are shorter.
+func dayOfMonth(year int, month time.Month, day int, loc *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	last := first.AddDate(0, 1, -1).Day()
+
+	if day > last {
+		day = last
+	}
+
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, loc)
 }
 
 func (PaymentAccount) TableName() string {
@@ -44,9 +147,44 @@ func (p *PaymentAccount) AfterFind(tx *gorm.DB) (err error) {
 	}
 
 	p.Formatted = Formatted{
-		Deposit: utils.FormatRupiah(p.Deposit),
-		Logo:    logo,
+		Logo: logo,
+	}
+
+	if p.IsCredit() {
+		p.Credit = p.creditSummary(time.Now())
 	}
 
 	return
 }
+
+func (p *PaymentAccount) Localize(f *utils.Formatter) {
+	p.Formatted.Deposit = f.Money(p.Deposit, p.Currency)
+
+	if p.Credit != nil {
+		p.Credit.FormattedOutstanding = f.Money(p.Credit.Outstanding, p.Currency)
+	}
+}
+
+func (p PaymentAccount) creditSummary(now time.Time) *CreditSummary {
+	summary := &CreditSummary{
+		Outstanding: p.Outstanding(),
+	}
+
+	if p.CreditLimit != nil {
+		available := *p.CreditLimit + p.Deposit
+		if available < 0 {
+			available = 0
+		}
+		summary.Available = &available
+	}
+
+	statementAt, dueAt := p.NextStatement(now)
+	if statementAt != nil {
+		summary.NextStatementAt = utils.String(statementAt.Format("2006-01-02"))
+	}
+	if dueAt != nil {
+		summary.NextDueAt = utils.String(dueAt.Format("2006-01-02"))
+	}
+
+	return summary
+}
@@ -0,0 +1,40 @@
+/*
+ * Project Name: models
+ * File: payment_account_balance.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import "time"
+
+const (
+	PaymentAccountBalanceSourceOpening  = "opening"
+	PaymentAccountBalanceSourcePayment  = "payment"
+	PaymentAccountBalanceSourceManual   = "manual"
+	PaymentAccountBalanceSourceBackfill = "backfill"
+)
+
+// PaymentAccountBalance is one entry of the balance ledger of a payment account: the change of the deposit
+// and the balance right after it, recorded at the moment the deposit changed.
+type PaymentAccountBalance struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"index" json:"user_id"`
+	PaymentAccountID uint      `gorm:"index:idx_payment_account_balances_account_recorded_at,priority:1" json:"payment_account_id"`
+	PaymentID        *uint     `gorm:"index" json:"payment_id"`
+	Source           string    `gorm:"size:20;not null" json:"source"`
+	Amount           int64     `json:"amount"`
+	Balance          int64     `json:"balance"`
+	RecordedAt       time.Time `gorm:"index:idx_payment_account_balances_account_recorded_at,priority:2" json:"recorded_at"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func (PaymentAccountBalance) TableName() string {
+	return "payment_account_balances"
+}
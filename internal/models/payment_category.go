@@ -0,0 +1,191 @@
+/*
+ * Project Name: models
+ * File: payment_category.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// PaymentCategoryMaxDepth limits how deep categories can be nested, e.g. Food > Groceries > Vegetables.
+const PaymentCategoryMaxDepth = 3
+
+// PaymentCategory is a user-defined category of payments, nested under ParentID to build a hierarchy such as
+// Food > Groceries.
+type PaymentCategory struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"index" json:"user_id"`
+	ParentID  *uint     `gorm:"index" json:"parent_id"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	Color     *string   `gorm:"size:7" json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Path     string            `gorm:"-" json:"path"`
+	Children []PaymentCategory `gorm:"-" json:"children,omitempty"`
+}
+
+func (PaymentCategory) TableName() string {
+	return "payment_categories"
+}
+
+// CategoryPaths returns the full path of every category, e.g. "Food > Groceries", keyed by category ID.
+// Categories whose parent is missing from the list start their path at themselves.
+func CategoryPaths(categories []PaymentCategory) map[uint]string {
+	byID := make(map[uint]PaymentCategory, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	paths := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		path := category.Name
+		current := category
+
+		for depth := 1; current.ParentID != nil && depth < PaymentCategoryMaxDepth*2; depth++ {
+			parent, ok := byID[*current.ParentID]
+			if !ok {
+				break
+			}
+			path = parent.Name + " > " + path
+			current = parent
+		}
+
+		paths[category.ID] = path
+	}
+
+	return paths
+}
+
+// CategoryTree nests the categories under their parents, sorted by name, and fills their paths.
+func CategoryTree(categories []PaymentCategory) []PaymentCategory {
+	paths := CategoryPaths(categories)
+	children := make(map[uint][]PaymentCategory)
+	byID := make(map[uint]bool, len(categories))
+
+	for _, category := range categories {
+		byID[category.ID] = true
+	}
+
+	var roots []PaymentCategory
+	for _, category := range categories {
+		category.Path = paths[category.ID]
+		if category.ParentID != nil && byID[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var attach func(nodes []PaymentCategory, depth int) []PaymentCategory
+	attach = func(nodes []PaymentCategory, depth int) []PaymentCategory {
+		sort.Slice(nodes, func(i, j int) bool {
+			return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+		})
+
+		for i := range nodes {
+			if depth < PaymentCategoryMaxDepth*2 {
+				nodes[i].Children = attach(children[nodes[i].ID], depth+1)
+			}
+		}
+
+		return nodes
+	}
+
+	if roots == nil {
+		return []PaymentCategory{}
+	}
+
+	return attach(roots, 1)
+}
+
+// CategoryDescendants returns the IDs of the category and every category nested below it.
+func CategoryDescendants(categories []PaymentCategory, id uint) []uint {
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	ids := []uint{id}
+	seen := map[uint]bool{id: true}
+
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+
+	return ids
+}
+
+// CategoryAncestors returns the ID of the category followed by the IDs of its parents up to the top level.
+func CategoryAncestors(categories []PaymentCategory, id uint) []uint {
+	parents := make(map[uint]*uint, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+
+	ids := []uint{id}
+	for parent := parents[id]; parent != nil && len(ids) <= PaymentCategoryMaxDepth*2; parent = parents[*parent] {
+		ids = append(ids, *parent)
+	}
+
+	return ids
+}
+
+// CategoryDepth returns the level of the category in its hierarchy, 1 for a top-level category.
+func CategoryDepth(categories []PaymentCategory, id uint) int {
+	parents := make(map[uint]*uint, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+
+	depth := 1
+	for parent := parents[id]; parent != nil && depth <= PaymentCategoryMaxDepth*2; parent = parents[*parent] {
+		depth++
+	}
+
+	return depth
+}
+
+// CategoryHeight returns the number of levels of the category and the categories nested below it, 1 for a
+// category without children.
+func CategoryHeight(categories []PaymentCategory, id uint) int {
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	var height func(id uint, depth int) int
+	height = func(id uint, depth int) int {
+		highest := 0
+		if depth <= PaymentCategoryMaxDepth*2 {
+			for _, child := range children[id] {
+				if h := height(child, depth+1); h > highest {
+					highest = h
+				}
+			}
+		}
+		return highest + 1
+	}
+
+	return height(id, 1)
+}
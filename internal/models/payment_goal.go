@@ -72,13 +72,43 @@ func (p *PaymentGoal) AfterFind(tx *gorm.DB) (err error) {
 		p.StatusName = p.Status.Name
 	}
 
+	return
+}
+
+func (p *PaymentGoal) Localize(f *utils.Formatter) {
 	p.Formatted = FormattedGoal{
-		Amount:       utils.FormatRupiah(p.Amount),
-		TargetAmount: utils.FormatRupiah(p.TargetAmount),
+		Amount:       f.Money(p.Amount, utils.DefaultCurrency),
+		TargetAmount: f.Money(p.TargetAmount, utils.DefaultCurrency),
 		Progress:     utils.FormatPercent(p.ProgressPercent),
-		StartDate:    utils.FormatDateID(time.Time(p.StartDate), "02/01/2006"),
-		TargetDate:   utils.FormatDateID(time.Time(p.TargetDate), "02/01/2006"),
+		StartDate:    f.ShortDate(time.Time(p.StartDate)),
+		TargetDate:   f.ShortDate(time.Time(p.TargetDate)),
 	}
+}
 
-	return
+func (p PaymentGoal) IsCompleted() bool {
+	return p.StatusID == PaymentGoalStatusCompleted
+}
+
+// Recalculate sets the progress from the saved amount and the status from the progress and the target date:
+// completed once the target is reached, overdue after the target date and ongoing otherwise.
+func (p *PaymentGoal) Recalculate(today time.Time) {
+	p.ProgressPercent = 0
+	if p.TargetAmount > 0 {
+		p.ProgressPercent = int(p.Amount * 100 / p.TargetAmount)
+	}
+
+	if p.ProgressPercent > 100 {
+		p.ProgressPercent = 100
+	} else if p.ProgressPercent < 0 {
+		p.ProgressPercent = 0
+	}
+
+	switch {
+	case p.TargetAmount > 0 && p.Amount >= p.TargetAmount:
+		p.StatusID = PaymentGoalStatusCompleted
+	case time.Time(p.TargetDate).Format("2006-01-02") < today.Format("2006-01-02"):
+		p.StatusID = PaymentGoalStatusOverdue
+	default:
+		p.StatusID = PaymentGoalStatusOngoing
+	}
 }
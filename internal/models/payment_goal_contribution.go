@@ -0,0 +1,41 @@
+/*
+ * Project Name: models
+ * File: payment_goal_contribution.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"golang-api/pkg/utils"
+	"time"
+)
+
+// PaymentGoalContribution links a payment to the goal it saved money for. Editing or deleting the payment
+// changes the saved amount of the goal with it.
+type PaymentGoalContribution struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	UserID        uint      `gorm:"index" json:"user_id"`
+	PaymentGoalID uint      `gorm:"index" json:"payment_goal_id"`
+	PaymentID     uint      `gorm:"uniqueIndex" json:"payment_id"`
+	Amount        int64     `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	Payment         *Payment `gorm:"foreignKey:PaymentID" json:"payment,omitempty"`
+	FormattedAmount string   `gorm:"-" json:"formatted_amount"`
+}
+
+func (p *PaymentGoalContribution) Localize(f *utils.Formatter) {
+	p.FormattedAmount = f.Money(p.Amount, utils.DefaultCurrency)
+}
+
+func (PaymentGoalContribution) TableName() string {
+	return "payment_goal_contributions"
+}
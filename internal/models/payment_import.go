@@ -0,0 +1,80 @@
+/*
+ * Project Name: models
+ * File: payment_import.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"time"
+)
+
+const (
+	PaymentImportFormatCSV = "csv"
+	PaymentImportFormatOFX = "ofx"
+	PaymentImportFormatQIF = "qif"
+)
+
+const (
+	PaymentImportStatusPending   = "pending"
+	PaymentImportStatusConfirmed = "confirmed"
+)
+
+// PaymentImportRow is a single parsed statement line, kept on the import until it is confirmed.
+type PaymentImportRow struct {
+	Index         int    `json:"index"`
+	Date          string `json:"date"`
+	Name          string `json:"name"`
+	Amount        int64  `json:"amount"`
+	TypeID        uint   `json:"type_id"`
+	Reference     string `json:"reference"`
+	IsDuplicate   bool   `json:"is_duplicate"`
+	DuplicateOfID *uint  `json:"duplicate_of_id"`
+	PaymentID     *uint  `json:"payment_id"`
+}
+
+type PaymentImport struct {
+	ID               uint               `gorm:"primaryKey" json:"id"`
+	UserID           uint               `gorm:"index" json:"user_id"`
+	PaymentAccountID uint               `gorm:"index" json:"payment_account_id"`
+	Format           string             `gorm:"size:10;not null" json:"format"`
+	FileName         string             `gorm:"size:255" json:"file_name"`
+	Status           string             `gorm:"size:20;not null;default:pending" json:"status"`
+	Rows             []PaymentImportRow `gorm:"type:json;serializer:json" json:"rows"`
+	ConfirmedAt      *time.Time         `json:"confirmed_at"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+func (PaymentImport) TableName() string {
+	return "payment_imports"
+}
+
+// PaymentImportMapping is the saved CSV column mapping of a payment account.
+// Columns are header names, or zero based column indexes when the file has no header row.
+type PaymentImportMapping struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"index" json:"user_id"`
+	PaymentAccountID uint      `gorm:"uniqueIndex" json:"payment_account_id"`
+	Delimiter        string    `gorm:"size:1;not null;default:," json:"delimiter"`
+	HasHeader        bool      `gorm:"not null;default:true" json:"has_header"`
+	DateColumn       string    `gorm:"size:100;not null" json:"date_column"`
+	DateFormat       string    `gorm:"size:20;not null" json:"date_format"`
+	NameColumn       string    `gorm:"size:100;not null" json:"name_column"`
+	AmountColumn     *string   `gorm:"size:100" json:"amount_column"`
+	DebitColumn      *string   `gorm:"size:100" json:"debit_column"`
+	CreditColumn     *string   `gorm:"size:100" json:"credit_column"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func (PaymentImportMapping) TableName() string {
+	return "payment_import_mappings"
+}
@@ -0,0 +1,35 @@
+/*
+ * Project Name: models
+ * File: payment_reconciliation.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import "time"
+
+// PaymentReconciliation records a check of a payment account against the balance on a bank statement.
+// FlaggedAt is set when a payment cleared by the reconciliation is edited or deleted afterwards.
+type PaymentReconciliation struct {
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	UserID              uint       `gorm:"index" json:"user_id"`
+	PaymentAccountID    uint       `gorm:"index" json:"payment_account_id"`
+	StatementBalance    int64      `json:"statement_balance"`
+	BookBalance         int64      `json:"book_balance"`
+	Difference          int64      `json:"difference"`
+	ClearedCount        int        `json:"cleared_count"`
+	AdjustmentPaymentID *uint      `json:"adjustment_payment_id"`
+	FlaggedAt           *time.Time `json:"flagged_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func (PaymentReconciliation) TableName() string {
+	return "payment_reconciliations"
+}
@@ -0,0 +1,59 @@
+/*
+ * Project Name: models
+ * File: payment_tag.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// PaymentTag is a free-form label of payments, unique by name for each user.
+type PaymentTag struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	UserID        uint      `gorm:"uniqueIndex:idx_payment_tags_user_name" json:"user_id"`
+	Name          string    `gorm:"size:50;not null;uniqueIndex:idx_payment_tags_user_name" json:"name"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	PaymentsCount int64     `gorm:"->;-:migration" json:"payments_count"`
+}
+
+func (PaymentTag) TableName() string {
+	return "payment_tags"
+}
+
+// PaymentPaymentTag is the pivot between payments and their tags.
+type PaymentPaymentTag struct {
+	PaymentID    uint `gorm:"primaryKey"`
+	PaymentTagID uint `gorm:"primaryKey;index"`
+}
+
+func (PaymentPaymentTag) TableName() string {
+	return "payment_payment_tag"
+}
+
+// NormalizeTagNames trims and lowercases tag names, dropping empty and duplicate ones.
+func NormalizeTagNames(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	return normalized
+}
@@ -1,9 +1,53 @@
 package models
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 )
 
+// Token abilities, a ":write" ability also grants the matching ":read" ability and "*" grants everything.
+const (
+	AbilityAll               = "*"
+	AbilityAdmin             = "admin"
+	AbilityProfileRead       = "profile:read"
+	AbilityProfileWrite      = "profile:write"
+	AbilityUsersRead         = "users:read"
+	AbilityPaymentsRead      = "payments:read"
+	AbilityPaymentsWrite     = "payments:write"
+	AbilityAccountsRead      = "accounts:read"
+	AbilityAccountsWrite     = "accounts:write"
+	AbilityGoalsRead         = "goals:read"
+	AbilityGoalsWrite        = "goals:write"
+	AbilityActivityLogsRead  = "activity-logs:read"
+	AbilityActivityLogsWrite = "activity-logs:write"
+	AbilityUptimeManage      = "uptime:manage"
+)
+
+// Abilities lists every ability a token can be issued with.
+var Abilities = []string{
+	AbilityAll,
+	AbilityAdmin,
+	AbilityProfileRead,
+	AbilityProfileWrite,
+	AbilityUsersRead,
+	AbilityPaymentsRead,
+	AbilityPaymentsWrite,
+	AbilityAccountsRead,
+	AbilityAccountsWrite,
+	AbilityGoalsRead,
+	AbilityGoalsWrite,
+	AbilityActivityLogsRead,
+	AbilityActivityLogsWrite,
+	AbilityUptimeManage,
+}
+
+// UnverifiedAbilities are the only abilities granted to users who have not verified their email address.
+var UnverifiedAbilities = []string{
+	AbilityProfileRead,
+	AbilityProfileWrite,
+}
+
 type PersonalAccessToken struct {
 	ID            uint       `gorm:"primaryKey" json:"id"`
 	TokenableType string     `json:"tokenable_type"`
@@ -20,3 +64,53 @@ type PersonalAccessToken struct {
 func (PersonalAccessToken) TableName() string {
 	return "personal_access_tokens"
 }
+
+// AbilityList decodes the JSON encoded abilities column, as written by Laravel Sanctum.
+func (t PersonalAccessToken) AbilityList() []string {
+	var abilities []string
+	if err := json.Unmarshal([]byte(t.Abilities), &abilities); err != nil {
+		return []string{}
+	}
+	return abilities
+}
+
+// Can reports whether the token was issued with the ability, directly or through "*" or the matching ":write" ability.
+func (t PersonalAccessToken) Can(ability string) bool {
+	return grants(t.AbilityList(), ability)
+}
+
+// UnverifiedAbilitiesOf narrows granted abilities down to the unverified abilities they include, for a user whose email
+// address is not verified.
+func UnverifiedAbilitiesOf(granted []string) []string {
+	abilities := []string{}
+	for _, ability := range UnverifiedAbilities {
+		if grants(granted, ability) {
+			abilities = append(abilities, ability)
+		}
+	}
+	return abilities
+}
+
+func grants(granted []string, ability string) bool {
+	for _, grant := range granted {
+		if grant == AbilityAll || grant == ability {
+			return true
+		}
+
+		if resource, ok := strings.CutSuffix(ability, ":read"); ok && grant == resource+":write" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAbility reports whether the ability is one a token can be issued with.
+func IsAbility(ability string) bool {
+	for _, known := range Abilities {
+		if known == ability {
+			return true
+		}
+	}
+	return false
+}
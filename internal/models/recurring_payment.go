@@ -0,0 +1,203 @@
+/*
+ * Project Name: models
+ * File: recurring_payment.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"golang-api/pkg/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RecurringFrequencyDaily   = "daily"
+	RecurringFrequencyWeekly  = "weekly"
+	RecurringFrequencyMonthly = "monthly"
+	RecurringFrequencyYearly  = "yearly"
+)
+
+const (
+	RecurringOccurrenceSkip     = "skip"
+	RecurringOccurrenceOverride = "override"
+)
+
+type RecurringPaymentItem struct {
+	ItemID *uint  `json:"item_id"`
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+	Qty    int    `json:"qty"`
+}
+
+type RecurringPayment struct {
+	ID                 uint                   `gorm:"primaryKey" json:"id"`
+	UserID             uint                   `gorm:"index" json:"user_id"`
+	Name               string                 `gorm:"size:255;not null" json:"name"`
+	Amount             int64                  `json:"amount"`
+	Currency           string                 `gorm:"size:3;not null;default:IDR" json:"currency"`
+	TypeID             uint                   `json:"type_id"`
+	PaymentAccountID   uint                   `json:"payment_account_id"`
+	PaymentAccountToID *uint                  `json:"payment_account_to_id"`
+	Items              []RecurringPaymentItem `gorm:"type:json;serializer:json" json:"items"`
+	Frequency          string                 `gorm:"size:20;not null" json:"frequency"`
+	Interval           int                    `gorm:"not null;default:1" json:"interval"`
+	DayOfMonth         *int                   `json:"day_of_month"`
+	StartDate          time.Time              `gorm:"type:date;not null" json:"start_date"`
+	EndDate            *time.Time             `gorm:"type:date" json:"end_date"`
+	Count              *int                   `json:"count"`
+	GeneratedCount     int                    `gorm:"not null;default:0" json:"generated_count"`
+	NextDate           *time.Time             `gorm:"type:date;index" json:"next_date"`
+	IsActive           bool                   `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt         `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
+
+	FormattedAmount string `gorm:"-" json:"formatted_amount"`
+}
+
+func (RecurringPayment) TableName() string {
+	return "recurring_payments"
+}
+
+func (r *RecurringPayment) Localize(f *utils.Formatter) {
+	r.FormattedAmount = f.Money(r.Amount, r.Currency)
+}
+
+// ItemsTotal returns the sum of the item totals, the amount of every payment of a rule with items.
+func (r *RecurringPayment) ItemsTotal() int64 {
+	total := int64(0)
+	for _, item := range r.Items {
+		total += item.Amount * int64(item.Qty)
+	}
+	return total
+}
+
+// OccurrenceAmount returns the amount of the payment generated for an occurrence. A rule with items always books
+// their total, an amount override only applies to rules without items.
+func (r *RecurringPayment) OccurrenceAmount(occurrence *RecurringPaymentOccurrence) int64 {
+	if len(r.Items) > 0 {
+		return r.ItemsTotal()
+	}
+	if occurrence != nil && occurrence.Amount != nil {
+		return *occurrence.Amount
+	}
+	return r.Amount
+}
+
+// OccurrenceAt returns the date of the n-th (zero based) occurrence of the rule.
+// Monthly and yearly rules are clamped to the last day of shorter months.
+func (r *RecurringPayment) OccurrenceAt(n int) time.Time {
+	start := time.Date(r.StartDate.Year(), r.StartDate.Month(), r.StartDate.Day(), 0, 0, 0, 0, time.Local)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	step := n * interval
+
+	switch r.Frequency {
+	case RecurringFrequencyDaily:
+		return start.AddDate(0, 0, step)
+	case RecurringFrequencyWeekly:
+		return start.AddDate(0, 0, step*7)
+	case RecurringFrequencyYearly:
+		return dateClamped(start.Year()+step, start.Month(), start.Day())
+	}
+
+	day := start.Day()
+	if r.DayOfMonth != nil {
+		day = *r.DayOfMonth
+	}
+
+	return dateClamped(start.Year(), start.Month()+time.Month(step), day)
+}
+
+// AlignStartDate moves the start date of a monthly rule with a fixed day of month
+// to its first matching day, so the first occurrence never falls before the start date.
+func (r *RecurringPayment) AlignStartDate() {
+	if r.Frequency != RecurringFrequencyMonthly || r.DayOfMonth == nil {
+		return
+	}
+
+	start := time.Date(r.StartDate.Year(), r.StartDate.Month(), r.StartDate.Day(), 0, 0, 0, 0, time.Local)
+	first := dateClamped(start.Year(), start.Month(), *r.DayOfMonth)
+
+	if first.Before(start) {
+		first = dateClamped(start.Year(), start.Month()+1, *r.DayOfMonth)
+	}
+
+	r.StartDate = first
+}
+
+// NextOccurrence returns the date of the next occurrence that has not been generated yet,
+// or nil when the rule has reached its end date or occurrence count.
+func (r *RecurringPayment) NextOccurrence() *time.Time {
+	if r.Count != nil && r.GeneratedCount >= *r.Count {
+		return nil
+	}
+
+	next := r.OccurrenceAt(r.GeneratedCount)
+
+	if r.EndDate != nil && next.After(*r.EndDate) {
+		return nil
+	}
+
+	return &next
+}
+
+// Upcoming returns up to limit occurrence dates that have not been generated yet.
+func (r *RecurringPayment) Upcoming(limit int) []time.Time {
+	dates := []time.Time{}
+
+	for n := r.GeneratedCount; len(dates) < limit; n++ {
+		if r.Count != nil && n >= *r.Count {
+			break
+		}
+
+		date := r.OccurrenceAt(n)
+		if r.EndDate != nil && date.After(*r.EndDate) {
+			break
+		}
+
+		dates = append(dates, date)
+	}
+
+	return dates
+}
+
+func dateClamped(year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+type RecurringPaymentOccurrence struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	RecurringPaymentID uint       `gorm:"uniqueIndex:idx_recurring_occurrence" json:"recurring_payment_id"`
+	Date               time.Time  `gorm:"type:date;uniqueIndex:idx_recurring_occurrence" json:"date"`
+	Action             *string    `gorm:"size:20" json:"action"`
+	Name               *string    `gorm:"size:255" json:"name"`
+	Amount             *int64     `json:"amount"`
+	PaymentAccountID   *uint      `json:"payment_account_id"`
+	PaymentID          *uint      `json:"payment_id"`
+	GeneratedAt        *time.Time `json:"generated_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+func (RecurringPaymentOccurrence) TableName() string {
+	return "recurring_payment_occurrences"
+}
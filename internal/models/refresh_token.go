@@ -0,0 +1,38 @@
+/*
+ * Project Name: models
+ * File: refresh_token.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"time"
+)
+
+// RefreshToken exchanges for a new access token once. Every refresh token issued from the same login
+// shares a Family, so presenting an already rotated token revokes the whole login session.
+type RefreshToken struct {
+	ID                    uint       `gorm:"primaryKey" json:"id"`
+	UserID                uint       `gorm:"index" json:"user_id"`
+	PersonalAccessTokenID uint       `gorm:"index" json:"personal_access_token_id"`
+	Family                string     `gorm:"size:36;index;not null" json:"family"`
+	Token                 string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	Name                  string     `gorm:"size:255" json:"name"`
+	Abilities             string     `gorm:"type:text" json:"abilities"`
+	ExpiresAt             time.Time  `json:"expires_at"`
+	RotatedAt             *time.Time `json:"rotated_at"`
+	RevokedAt             *time.Time `json:"revoked_at"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
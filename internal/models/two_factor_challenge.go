@@ -0,0 +1,30 @@
+/*
+ * Project Name: models
+ * File: two_factor_challenge.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+package models
+
+import (
+	"time"
+)
+
+// TwoFactorChallenge records a login challenge that has been completed, so its token cannot open a second session.
+// Rows are only needed until the challenge would have expired anyway.
+type TwoFactorChallenge struct {
+	ID        string    `gorm:"primaryKey;size:32" json:"id"`
+	UserID    uint      `gorm:"index" json:"user_id"`
+	ExpiresAt time.Time `gorm:"index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (TwoFactorChallenge) TableName() string {
+	return "two_factor_challenges"
+}
@@ -9,18 +9,32 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
 type User struct {
-	ID                   uint           `gorm:"primaryKey" json:"id"`
-	Code                 string         `gorm:"size:255" json:"code"`
-	Name                 string         `gorm:"size:255;not null" json:"name"`
-	Email                string         `gorm:"size:255;uniqueIndex;not null" json:"email"`
-	Password             string         `gorm:"size:255;not null" json:"-"`
-	HasAllowNotification *bool          `gorm:"default:false" json:"has_allow_notification"`
-	NotificationToken    *string        `gorm:"size:255" json:"-"`
-	AvatarUrl            *string        `gorm:"size:255" json:"-"`
-	CreatedAt            time.Time      `json:"created_at"`
-	UpdatedAt            time.Time      `json:"updated_at"`
-	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
+	ID                     uint           `gorm:"primaryKey" json:"id"`
+	Code                   string         `gorm:"size:255" json:"code"`
+	Name                   string         `gorm:"size:255;not null" json:"name"`
+	Email                  string         `gorm:"size:255;uniqueIndex;not null" json:"email"`
+	Password               string         `gorm:"size:255;not null" json:"-"`
+	EmailVerifiedAt        *time.Time     `json:"email_verified_at"`
+	TwoFactorSecret        *string        `gorm:"type:text" json:"-"`
+	TwoFactorRecoveryCodes *string        `gorm:"type:text" json:"-"`
+	TwoFactorConfirmedAt   *time.Time     `json:"two_factor_confirmed_at"`
+	TwoFactorLastStep      *int64         `json:"-"`
+	HasAllowNotification   *bool          `gorm:"default:false" json:"has_allow_notification"`
+	NotificationToken      *string        `gorm:"size:255" json:"-"`
+	AvatarUrl              *string        `gorm:"size:255" json:"-"`
+	Role                   string         `gorm:"size:20;not null;default:member" json:"role"`
+	DeactivatedAt          *time.Time     `json:"deactivated_at"`
+	Locale                 *string        `gorm:"size:10" json:"locale"`
+	Timezone               *string        `gorm:"size:64" json:"timezone"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
 
 	AvatarUrlFormatted *string `gorm:"-" json:"avatar_url"`
 }
@@ -41,6 +55,28 @@ func (u User) GetAvatarUrl() *string {
 	return &fullUrl
 }
 
+func (u User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+func (u User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+// IsRole reports whether the value is one of the known user roles.
+func IsRole(role string) bool {
+	return role == RoleAdmin || role == RoleMember
+}
+
+func (u User) IsVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// HasTwoFactor reports whether two-factor authentication is enabled and confirmed.
+func (u User) HasTwoFactor() bool {
+	return u.TwoFactorSecret != nil && u.TwoFactorConfirmedAt != nil
+}
+
 func (User) TableName() string {
 	return "users"
 }
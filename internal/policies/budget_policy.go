@@ -0,0 +1,19 @@
+package policies
+
+import "golang-api/internal/models"
+
+type BudgetPolicy struct{}
+
+var Budget BudgetPolicy
+
+func (BudgetPolicy) View(userID uint, budget *models.Budget) bool {
+	return budget != nil && owns(userID, budget.UserID)
+}
+
+func (BudgetPolicy) Update(userID uint, budget *models.Budget) bool {
+	return budget != nil && owns(userID, budget.UserID)
+}
+
+func (BudgetPolicy) Delete(userID uint, budget *models.Budget) bool {
+	return budget != nil && owns(userID, budget.UserID)
+}
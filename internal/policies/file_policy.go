@@ -0,0 +1,12 @@
+package policies
+
+import "golang-api/internal/models"
+
+type FilePolicy struct{}
+
+var File FilePolicy
+
+// Download reports whether the user may download the file, which is only allowed for the user it was generated for.
+func (FilePolicy) Download(userID uint, file *models.File) bool {
+	return file != nil && file.UserID != nil && owns(userID, *file.UserID)
+}
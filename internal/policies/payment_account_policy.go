@@ -0,0 +1,44 @@
+package policies
+
+import (
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+)
+
+type PaymentAccountPolicy struct{}
+
+var PaymentAccount PaymentAccountPolicy
+
+func (PaymentAccountPolicy) View(userID uint, paymentAccount *models.PaymentAccount) bool {
+	return paymentAccount != nil && owns(userID, paymentAccount.UserID)
+}
+
+func (PaymentAccountPolicy) Update(userID uint, paymentAccount *models.PaymentAccount) bool {
+	return paymentAccount != nil && owns(userID, paymentAccount.UserID)
+}
+
+func (PaymentAccountPolicy) Delete(userID uint, paymentAccount *models.PaymentAccount) bool {
+	return paymentAccount != nil && owns(userID, paymentAccount.UserID)
+}
+
+// Use reports whether payments of the user may be booked on the account.
+func (PaymentAccountPolicy) Use(userID uint, paymentAccount *models.PaymentAccount) bool {
+	return paymentAccount != nil && owns(userID, paymentAccount.UserID)
+}
+
+// UseByID loads each referenced account and reports whether the user may book payments on all of them.
+// Nil IDs, e.g. an absent payment_account_to_id, are skipped.
+func (p PaymentAccountPolicy) UseByID(repo *repositories.PaymentAccountRepository, userID uint, ids ...*uint) bool {
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+
+		paymentAccount, err := repo.SelectByID(nil, *id, []string{"id", "user_id"})
+		if err != nil || !p.Use(userID, paymentAccount) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,37 @@
+package policies
+
+import (
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+)
+
+type PaymentCategoryPolicy struct{}
+
+var PaymentCategory PaymentCategoryPolicy
+
+func (PaymentCategoryPolicy) View(userID uint, paymentCategory *models.PaymentCategory) bool {
+	return paymentCategory != nil && owns(userID, paymentCategory.UserID)
+}
+
+func (PaymentCategoryPolicy) Update(userID uint, paymentCategory *models.PaymentCategory) bool {
+	return paymentCategory != nil && owns(userID, paymentCategory.UserID)
+}
+
+func (PaymentCategoryPolicy) Delete(userID uint, paymentCategory *models.PaymentCategory) bool {
+	return paymentCategory != nil && owns(userID, paymentCategory.UserID)
+}
+
+// Use reports whether payments or child categories of the user may be filed under the category.
+func (PaymentCategoryPolicy) Use(userID uint, paymentCategory *models.PaymentCategory) bool {
+	return paymentCategory != nil && owns(userID, paymentCategory.UserID)
+}
+
+// UseByID loads the referenced category and reports whether the user may use it, a nil ID is allowed.
+func (p PaymentCategoryPolicy) UseByID(repo *repositories.PaymentCategoryRepository, userID uint, id *uint) bool {
+	if id == nil {
+		return true
+	}
+
+	paymentCategory, err := repo.SelectByID(nil, *id, []string{"id", "user_id"})
+	return err == nil && p.Use(userID, paymentCategory)
+}
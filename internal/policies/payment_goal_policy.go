@@ -0,0 +1,19 @@
+package policies
+
+import "golang-api/internal/models"
+
+type PaymentGoalPolicy struct{}
+
+var PaymentGoal PaymentGoalPolicy
+
+func (PaymentGoalPolicy) View(userID uint, paymentGoal *models.PaymentGoal) bool {
+	return paymentGoal != nil && owns(userID, paymentGoal.UserID)
+}
+
+func (PaymentGoalPolicy) Update(userID uint, paymentGoal *models.PaymentGoal) bool {
+	return paymentGoal != nil && owns(userID, paymentGoal.UserID)
+}
+
+func (PaymentGoalPolicy) Delete(userID uint, paymentGoal *models.PaymentGoal) bool {
+	return paymentGoal != nil && owns(userID, paymentGoal.UserID)
+}
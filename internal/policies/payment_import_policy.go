@@ -0,0 +1,15 @@
+package policies
+
+import "golang-api/internal/models"
+
+type PaymentImportPolicy struct{}
+
+var PaymentImport PaymentImportPolicy
+
+func (PaymentImportPolicy) View(userID uint, paymentImport *models.PaymentImport) bool {
+	return paymentImport != nil && owns(userID, paymentImport.UserID)
+}
+
+func (PaymentImportPolicy) Confirm(userID uint, paymentImport *models.PaymentImport) bool {
+	return paymentImport != nil && owns(userID, paymentImport.UserID)
+}
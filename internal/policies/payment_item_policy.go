@@ -0,0 +1,16 @@
+package policies
+
+import "golang-api/internal/models"
+
+// PaymentItemPolicy has no owner of its own, a payment item belongs to whoever owns its payment.
+type PaymentItemPolicy struct{}
+
+var PaymentItem PaymentItemPolicy
+
+func (PaymentItemPolicy) View(userID uint, payment *models.Payment) bool {
+	return Payment.View(userID, payment)
+}
+
+func (PaymentItemPolicy) Attach(userID uint, payment *models.Payment) bool {
+	return Payment.Update(userID, payment)
+}
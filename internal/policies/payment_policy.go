@@ -0,0 +1,23 @@
+package policies
+
+import "golang-api/internal/models"
+
+type PaymentPolicy struct{}
+
+var Payment PaymentPolicy
+
+func (PaymentPolicy) View(userID uint, payment *models.Payment) bool {
+	return payment != nil && owns(userID, payment.UserID)
+}
+
+func (PaymentPolicy) Update(userID uint, payment *models.Payment) bool {
+	return payment != nil && owns(userID, payment.UserID)
+}
+
+func (PaymentPolicy) Delete(userID uint, payment *models.Payment) bool {
+	return payment != nil && owns(userID, payment.UserID)
+}
+
+func (PaymentPolicy) Publish(userID uint, payment *models.Payment) bool {
+	return payment != nil && owns(userID, payment.UserID)
+}
@@ -0,0 +1,19 @@
+package policies
+
+import "golang-api/internal/models"
+
+type PaymentTagPolicy struct{}
+
+var PaymentTag PaymentTagPolicy
+
+func (PaymentTagPolicy) View(userID uint, paymentTag *models.PaymentTag) bool {
+	return paymentTag != nil && owns(userID, paymentTag.UserID)
+}
+
+func (PaymentTagPolicy) Update(userID uint, paymentTag *models.PaymentTag) bool {
+	return paymentTag != nil && owns(userID, paymentTag.UserID)
+}
+
+func (PaymentTagPolicy) Delete(userID uint, paymentTag *models.PaymentTag) bool {
+	return paymentTag != nil && owns(userID, paymentTag.UserID)
+}
@@ -0,0 +1,22 @@
+/*
+ * Project Name: policies
+ * File: policies.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+// Package policies decides whether a user may read or change a user-scoped record.
+// Controllers and services load the record, ask its policy, and answer 404 when the policy denies,
+// so records of other users are indistinguishable from records that do not exist.
+package policies
+
+// owns reports whether the record belongs to the user. A zero ID on either side never matches, so a record without
+// an owner is not handed to a request that lost its user.
+func owns(userID uint, ownerID uint) bool {
+	return userID != 0 && ownerID == userID
+}
@@ -0,0 +1,171 @@
+package policies
+
+import (
+	"testing"
+
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	owner     uint = 1
+	otherUser uint = 2
+)
+
+// ownership is the case table every policy ability is checked against, they all only compare the owner of the record.
+var ownership = []struct {
+	name    string
+	userID  uint
+	ownerID uint
+	isNil   bool
+	want    bool
+}{
+	{name: "owner", userID: owner, ownerID: owner, want: true},
+	{name: "another user", userID: otherUser, ownerID: owner, want: false},
+	{name: "missing owner", userID: owner, ownerID: 0, want: false},
+	{name: "no user", userID: 0, ownerID: owner, want: false},
+	{name: "no user and no owner", userID: 0, ownerID: 0, want: false},
+	{name: "nil record", userID: owner, isNil: true, want: false},
+}
+
+// ability adapts a policy ability to the ownership cases, record builds the checked record for an owner ID.
+func ability[T any](allows func(uint, *T) bool, record func(ownerID uint) *T) func(userID, ownerID uint, isNil bool) bool {
+	return func(userID, ownerID uint, isNil bool) bool {
+		if isNil {
+			return allows(userID, nil)
+		}
+		return allows(userID, record(ownerID))
+	}
+}
+
+func TestOwnership(t *testing.T) {
+	payment := func(ownerID uint) *models.Payment { return &models.Payment{UserID: ownerID} }
+	paymentAccount := func(ownerID uint) *models.PaymentAccount { return &models.PaymentAccount{UserID: ownerID} }
+	paymentGoal := func(ownerID uint) *models.PaymentGoal { return &models.PaymentGoal{UserID: ownerID} }
+	paymentImport := func(ownerID uint) *models.PaymentImport { return &models.PaymentImport{UserID: ownerID} }
+	paymentCategory := func(ownerID uint) *models.PaymentCategory { return &models.PaymentCategory{UserID: ownerID} }
+	paymentTag := func(ownerID uint) *models.PaymentTag { return &models.PaymentTag{UserID: ownerID} }
+	budget := func(ownerID uint) *models.Budget { return &models.Budget{UserID: ownerID} }
+	// A file without an owner has no user_id at all.
+	file := func(ownerID uint) *models.File {
+		if ownerID == 0 {
+			return &models.File{}
+		}
+		return &models.File{UserID: &ownerID}
+	}
+
+	abilities := map[string]func(userID, ownerID uint, isNil bool) bool{
+		"Payment.View":           ability(Payment.View, payment),
+		"Payment.Update":         ability(Payment.Update, payment),
+		"Payment.Delete":         ability(Payment.Delete, payment),
+		"Payment.Publish":        ability(Payment.Publish, payment),
+		"PaymentItem.View":       ability(PaymentItem.View, payment),
+		"PaymentItem.Attach":     ability(PaymentItem.Attach, payment),
+		"PaymentAccount.View":    ability(PaymentAccount.View, paymentAccount),
+		"PaymentAccount.Update":  ability(PaymentAccount.Update, paymentAccount),
+		"PaymentAccount.Delete":  ability(PaymentAccount.Delete, paymentAccount),
+		"PaymentAccount.Use":     ability(PaymentAccount.Use, paymentAccount),
+		"PaymentGoal.View":       ability(PaymentGoal.View, paymentGoal),
+		"PaymentGoal.Update":     ability(PaymentGoal.Update, paymentGoal),
+		"PaymentGoal.Delete":     ability(PaymentGoal.Delete, paymentGoal),
+		"PaymentImport.View":     ability(PaymentImport.View, paymentImport),
+		"PaymentImport.Confirm":  ability(PaymentImport.Confirm, paymentImport),
+		"PaymentCategory.View":   ability(PaymentCategory.View, paymentCategory),
+		"PaymentCategory.Update": ability(PaymentCategory.Update, paymentCategory),
+		"PaymentCategory.Delete": ability(PaymentCategory.Delete, paymentCategory),
+		"PaymentCategory.Use":    ability(PaymentCategory.Use, paymentCategory),
+		"PaymentTag.View":        ability(PaymentTag.View, paymentTag),
+		"PaymentTag.Update":      ability(PaymentTag.Update, paymentTag),
+		"PaymentTag.Delete":      ability(PaymentTag.Delete, paymentTag),
+		"Budget.View":            ability(Budget.View, budget),
+		"Budget.Update":          ability(Budget.Update, budget),
+		"Budget.Delete":          ability(Budget.Delete, budget),
+		"File.Download":          ability(File.Download, file),
+	}
+
+	for name, allows := range abilities {
+		for _, tc := range ownership {
+			t.Run(name+"/"+tc.name, func(t *testing.T) {
+				if got := allows(tc.userID, tc.ownerID, tc.isNil); got != tc.want {
+					t.Errorf("got %v, want %v", got, tc.want)
+				}
+			})
+		}
+	}
+}
+
+// accountLookup is a payment account the repository is expected to load, ownerID 0 when it does not exist.
+type accountLookup struct {
+	id      uint
+	ownerID uint
+}
+
+func TestPaymentAccountPolicyUseByID(t *testing.T) {
+	id := func(value uint) *uint { return &value }
+
+	cases := []struct {
+		name    string
+		userID  uint
+		ids     []*uint
+		lookups []accountLookup
+		want    bool
+	}{
+		{name: "no ids", userID: owner, want: true},
+		{name: "nil id", userID: owner, ids: []*uint{nil}, want: true},
+		{name: "own account", userID: owner, ids: []*uint{id(10)}, lookups: []accountLookup{{10, owner}}, want: true},
+		{name: "own account and nil destination", userID: owner, ids: []*uint{id(10), nil}, lookups: []accountLookup{{10, owner}}, want: true},
+		{name: "account of another user", userID: otherUser, ids: []*uint{id(10)}, lookups: []accountLookup{{10, owner}}, want: false},
+		{name: "missing account", userID: owner, ids: []*uint{id(99)}, lookups: []accountLookup{{99, 0}}, want: false},
+		{name: "destination of another user", userID: owner, ids: []*uint{id(10), id(20)}, lookups: []accountLookup{{10, owner}, {20, otherUser}}, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newPaymentAccountRepository(t, tc.lookups)
+
+			if got := PaymentAccount.UseByID(repo, tc.userID, tc.ids...); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// newPaymentAccountRepository returns a repository on a database mock that answers the lookups in order.
+func newPaymentAccountRepository(t *testing.T, lookups []accountLookup) (*repositories.PaymentAccountRepository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, lookup := range lookups {
+		rows := sqlmock.NewRows([]string{"id", "user_id"})
+		if lookup.ownerID != 0 {
+			rows.AddRow(lookup.id, lookup.ownerID)
+		}
+
+		mock.ExpectQuery("SELECT `id`,`user_id` FROM `payment_accounts`").
+			WithArgs(lookup.id, 1).
+			WillReturnRows(rows)
+	}
+
+	return repositories.NewPaymentAccountRepository(db), mock
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AccountLockoutRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountLockoutRepository(db *gorm.DB) *AccountLockoutRepository {
+	return &AccountLockoutRepository{db: db}
+}
+
+func (r *AccountLockoutRepository) FindByEmail(email string) (*models.AccountLockout, error) {
+	var lockout models.AccountLockout
+
+	err := r.db.Where("email = ?", email).First(&lockout).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &lockout, nil
+}
+
+// RecordFailure counts a failed login of the email address and locks it for the duration lockFor returns for the new
+// count, if any. The counter is incremented by the database and read back under the row lock the increment holds, so
+// concurrent failures are all counted and each count is seen once.
+func (r *AccountLockoutRepository) RecordFailure(email string, lockFor func(failedAttempts int) time.Duration) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "email"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failed_attempts": gorm.Expr("failed_attempts + 1"),
+				"updated_at":      time.Now(),
+			}),
+		}).Create(&models.AccountLockout{Email: email, FailedAttempts: 1}).Error
+		if err != nil {
+			return err
+		}
+
+		var lockout models.AccountLockout
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("email = ?", email).First(&lockout).Error; err != nil {
+			return err
+		}
+
+		if duration := lockFor(lockout.FailedAttempts); duration > 0 {
+			return tx.Model(&lockout).Update("locked_until", time.Now().Add(duration)).Error
+		}
+
+		return nil
+	})
+}
+
+// DeleteByEmail clears the failed attempts and any lock of the email address.
+func (r *AccountLockoutRepository) DeleteByEmail(email string) error {
+	return r.db.Where("email = ?", email).Delete(&models.AccountLockout{}).Error
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BudgetRepository struct {
+	db *gorm.DB
+}
+
+func NewBudgetRepository(db *gorm.DB) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+// CurrencyAmount is a sum of payment amounts in one currency.
+type CurrencyAmount struct {
+	Currency string
+	Amount   int64
+}
+
+func (r *BudgetRepository) FindAllByUser(userID uint) ([]models.Budget, error) {
+	var budgets []models.Budget
+	err := r.preload(r.db).Where("user_id = ?", userID).Order("name ASC").Find(&budgets).Error
+	return budgets, err
+}
+
+func (r *BudgetRepository) FindByID(id uint) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.preload(r.db).First(&budget, id).Error
+	return &budget, err
+}
+
+// FindAffected returns the budgets of the user covering a payment from the account filed under one of the
+// categories, which are the category of the payment and its parents.
+func (r *BudgetRepository) FindAffected(userID uint, paymentAccountID uint, categoryIDs []uint) ([]models.Budget, error) {
+	var budgets []models.Budget
+
+	query := r.db.Where("user_id = ?", userID)
+	if len(categoryIDs) > 0 {
+		query = query.Where(
+			r.db.Where("scope = ? AND payment_account_id = ?", models.BudgetScopeAccount, paymentAccountID).
+				Or("scope = ? AND category_id IN ?", models.BudgetScopeCategory, categoryIDs),
+		)
+	} else {
+		query = query.Where("scope = ? AND payment_account_id = ?", models.BudgetScopeAccount, paymentAccountID)
+	}
+
+	err := query.Find(&budgets).Error
+	return budgets, err
+}
+
+func (r *BudgetRepository) Create(budget *models.Budget) error {
+	return r.db.Create(budget).Error
+}
+
+func (r *BudgetRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return r.db.Model(&models.Budget{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// Delete removes the budget with the alerts sent for it.
+func (r *BudgetRepository) Delete(tx *gorm.DB, id uint) error {
+	if err := tx.Where("budget_id = ?", id).Delete(&models.BudgetAlert{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Delete(&models.Budget{}, id).Error
+}
+
+// DeleteByCategory removes the budgets of a deleted category with the alerts sent for them.
+func (r *BudgetRepository) DeleteByCategory(tx *gorm.DB, categoryID uint) error {
+	return r.deleteWhere(tx, "category_id", categoryID)
+}
+
+// DeleteByAccount removes the budgets of a deleted payment account with the alerts sent for them.
+func (r *BudgetRepository) DeleteByAccount(tx *gorm.DB, paymentAccountID uint) error {
+	return r.deleteWhere(tx, "payment_account_id", paymentAccountID)
+}
+
+func (r *BudgetRepository) deleteWhere(tx *gorm.DB, column string, id uint) error {
+	budgets := tx.Model(&models.Budget{}).Select("id").Where(column+" = ?", id)
+	if err := tx.Where("budget_id IN (?)", budgets).Delete(&models.BudgetAlert{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Where(column+" = ?", id).Delete(&models.Budget{}).Error
+}
+
+// Exists reports whether the user already has a budget for the category or the payment account.
+func (r *BudgetRepository) Exists(userID uint, scope string, categoryID *uint, paymentAccountID *uint) bool {
+	query := r.db.Model(&models.Budget{}).Where("user_id = ? AND scope = ?", userID, scope)
+	if scope == models.BudgetScopeAccount {
+		query = query.Where("payment_account_id = ?", paymentAccountID)
+	} else {
+		query = query.Where("category_id = ?", categoryID)
+	}
+
+	var count int64
+	query.Count(&count)
+	return count > 0
+}
+
+// Spent sums the booked expenses between from and to, both inclusive, of the account or filed under one of the
+// categories, per currency. Drafts and scheduled payments do not count.
+func (r *BudgetRepository) Spent(userID uint, paymentAccountID *uint, categoryIDs []uint, from, to time.Time) ([]CurrencyAmount, error) {
+	var amounts []CurrencyAmount
+
+	query := r.db.Model(&models.Payment{}).
+		Select("currency, COALESCE(SUM(amount), 0) AS amount").
+		Where("user_id = ?", userID).
+		Where("type_id = ?", models.PaymentTypeExpense).
+		Where("is_draft = ? AND is_scheduled = ?", false, false).
+		Where("date BETWEEN ? AND ?", from.Format("2006-01-02"), to.Format("2006-01-02"))
+
+	if paymentAccountID != nil {
+		query = query.Where("payment_account_id = ?", *paymentAccountID)
+	} else {
+		query = query.Where("category_id IN ?", categoryIDs)
+	}
+
+	err := query.Group("currency").Scan(&amounts).Error
+	return amounts, err
+}
+
+// RecordAlert stores the alert unless it was recorded before, and reports whether it is new.
+func (r *BudgetRepository) RecordAlert(alert *models.BudgetAlert) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *BudgetRepository) preload(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Category").
+		Preload("PaymentAccount")
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExchangeRateRepository struct {
+	db *gorm.DB
+}
+
+func NewExchangeRateRepository(db *gorm.DB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+type ExchangeRateFilter struct {
+	BaseCurrency  string
+	QuoteCurrency string
+}
+
+func (r *ExchangeRateRepository) scopeFilter(query *gorm.DB, filter ExchangeRateFilter) *gorm.DB {
+	if filter.BaseCurrency != "" {
+		query = query.Where("base_currency = ?", filter.BaseCurrency)
+	}
+
+	if filter.QuoteCurrency != "" {
+		query = query.Where("quote_currency = ?", filter.QuoteCurrency)
+	}
+
+	return query
+}
+
+func (r *ExchangeRateRepository) Count(filter ExchangeRateFilter) (int64, error) {
+	var count int64
+	err := r.scopeFilter(r.db.Model(&models.ExchangeRate{}), filter).Count(&count).Error
+	return count, err
+}
+
+func (r *ExchangeRateRepository) FindAllPaginated(page, limit int, filter ExchangeRateFilter) ([]models.ExchangeRate, error) {
+	var rates []models.ExchangeRate
+	offset := (page - 1) * limit
+
+	err := r.scopeFilter(r.db, filter).
+		Order("date DESC, base_currency ASC, quote_currency ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rates).Error
+
+	return rates, err
+}
+
+func (r *ExchangeRateRepository) FindByID(id uint) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	err := r.db.First(&rate, id).Error
+	return &rate, err
+}
+
+// Upsert stores the rates, replacing the rate of a pair that already has one on the same day.
+func (r *ExchangeRateRepository) Upsert(tx *gorm.DB, rates ...models.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "source", "updated_at"}),
+	}).CreateInBatches(rates, 500).Error
+}
+
+func (r *ExchangeRateRepository) Delete(rate *models.ExchangeRate) error {
+	return r.db.Delete(rate).Error
+}
+
+// LatestOn returns the latest rate of the pair on or before the given day.
+func (r *ExchangeRateRepository) LatestOn(base, quote string, date time.Time) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+
+	err := r.db.
+		Where("base_currency = ? AND quote_currency = ? AND date <= ?", base, quote, date.Format("2006-01-02")).
+		Order("date DESC").
+		First(&rate).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+// Rate returns the price of one unit of from in to on the given day, using the inverse of the opposite pair
+// when only that one is known. The same currency always converts at 1.
+func (r *ExchangeRateRepository) Rate(from, to string, date time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	if rate, err := r.LatestOn(from, to, date); err == nil {
+		return rate.Rate, nil
+	}
+
+	rate, err := r.LatestOn(to, from, date)
+	if err != nil {
+		return 0, err
+	}
+
+	if rate.Rate == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return 1 / rate.Rate, nil
+}
@@ -22,3 +22,8 @@ func (r *FileDownloadRepository) GetByUID(uid string) (*models.FileDownload, err
 	}
 	return &fileDownload, nil
 }
+
+// Create stores a file download together with its files.
+func (r *FileDownloadRepository) Create(fileDownload *models.FileDownload) error {
+	return r.db.Create(fileDownload).Error
+}
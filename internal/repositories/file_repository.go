@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type FileRepository struct {
+	db *gorm.DB
+}
+
+func NewFileRepository(db *gorm.DB) *FileRepository {
+	return &FileRepository{db: db}
+}
+
+// ProcessExpired walks, in batches, over the files below the given path prefix whose scheduled deletion time has passed.
+func (r *FileRepository) ProcessExpired(pathPrefix string, now time.Time, batchSize int, callback func(files []models.File) error) error {
+	var files []models.File
+
+	return r.db.
+		Where("file_path LIKE ?", pathPrefix+"%").
+		Where("scheduled_deletion_time IS NOT NULL AND scheduled_deletion_time <= ?", now).
+		Where("has_been_deleted = ?", false).
+		FindInBatches(&files, batchSize, func(tx *gorm.DB, batch int) error {
+			return callback(files)
+		}).Error
+}
+
+func (r *FileRepository) MarkDeleted(id uint) error {
+	return r.db.Model(&models.File{}).Where("id = ?", id).Update("has_been_deleted", true).Error
+}
+
+func (r *FileRepository) FindByCode(code string) (*models.File, error) {
+	var file models.File
+	err := r.db.Where("code = ?", code).First(&file).Error
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
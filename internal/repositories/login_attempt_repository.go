@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (r *LoginAttemptRepository) Create(attempt *models.LoginAttempt) error {
+	return r.db.Create(attempt).Error
+}
+
+func (r *LoginAttemptRepository) Count(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.LoginAttempt{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *LoginAttemptRepository) FindAllPaginated(userID uint, page, limit int) ([]models.LoginAttempt, error) {
+	var attempts []models.LoginAttempt
+	offset := (page - 1) * limit
+
+	err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&attempts).Error
+
+	return attempts, err
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PasswordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{db: db}
+}
+
+func (r *PasswordResetTokenRepository) FindByEmail(email string) (*models.PasswordResetToken, error) {
+	var resetToken models.PasswordResetToken
+
+	err := r.db.Where("email = ?", email).First(&resetToken).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resetToken, nil
+}
+
+// Save replaces any earlier reset token of the email address, so only the latest emailed token works.
+func (r *PasswordResetTokenRepository) Save(email string, hashedToken string) error {
+	now := time.Now()
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "email"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "created_at"}),
+	}).Create(&models.PasswordResetToken{
+		Email:     email,
+		Token:     hashedToken,
+		CreatedAt: &now,
+	}).Error
+}
+
+func (r *PasswordResetTokenRepository) DeleteByEmail(email string) error {
+	return r.db.Where("email = ?", email).Delete(&models.PasswordResetToken{}).Error
+}
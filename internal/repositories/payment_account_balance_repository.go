@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PaymentAccountBalanceRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentAccountBalanceRepository(db *gorm.DB) *PaymentAccountBalanceRepository {
+	return &PaymentAccountBalanceRepository{db: db}
+}
+
+func (r *PaymentAccountBalanceRepository) Create(tx *gorm.DB, entries ...models.PaymentAccountBalance) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.CreateInBatches(entries, 500).Error
+}
+
+// LatestBefore returns the last entry recorded before the given time.
+func (r *PaymentAccountBalanceRepository) LatestBefore(paymentAccountID uint, before time.Time) (*models.PaymentAccountBalance, error) {
+	var entry models.PaymentAccountBalance
+
+	err := r.db.
+		Where("payment_account_id = ? AND recorded_at < ?", paymentAccountID, before).
+		Order("recorded_at DESC, id DESC").
+		First(&entry).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// First returns the oldest entry of the account.
+func (r *PaymentAccountBalanceRepository) First(paymentAccountID uint) (*models.PaymentAccountBalance, error) {
+	var entry models.PaymentAccountBalance
+
+	err := r.db.
+		Where("payment_account_id = ?", paymentAccountID).
+		Order("recorded_at ASC, id ASC").
+		First(&entry).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// FindBetween returns the entries recorded in [from, to), oldest first.
+func (r *PaymentAccountBalanceRepository) FindBetween(paymentAccountID uint, from, to time.Time) ([]models.PaymentAccountBalance, error) {
+	var entries []models.PaymentAccountBalance
+
+	err := r.db.
+		Where("payment_account_id = ? AND recorded_at >= ? AND recorded_at < ?", paymentAccountID, from, to).
+		Order("recorded_at ASC, id ASC").
+		Find(&entries).Error
+
+	return entries, err
+}
+
+// PaymentNet is the sum of the ledger entries a payment recorded on one payment account.
+type PaymentNet struct {
+	PaymentAccountID uint
+	Amount           int64
+}
+
+// NetByPayment returns, per payment account, the sum of the ledger entries recorded for a payment.
+func (r *PaymentAccountBalanceRepository) NetByPayment(tx *gorm.DB, paymentID uint) ([]PaymentNet, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var nets []PaymentNet
+	err := tx.Model(&models.PaymentAccountBalance{}).
+		Select("payment_account_id, SUM(amount) AS amount").
+		Where("payment_id = ?", paymentID).
+		Group("payment_account_id").
+		Order("payment_account_id").
+		Scan(&nets).Error
+
+	return nets, err
+}
+
+func (r *PaymentAccountBalanceRepository) Exists(paymentAccountID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.PaymentAccountBalance{}).Where("payment_account_id = ?", paymentAccountID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *PaymentAccountBalanceRepository) DeleteByPaymentAccountID(tx *gorm.DB, paymentAccountID uint) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.Where("payment_account_id = ?", paymentAccountID).Delete(&models.PaymentAccountBalance{}).Error
+}
@@ -18,43 +18,202 @@ import (
 	"golang-api/internal/models"
 	"golang-api/pkg/utils"
 	"log"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentAccountRepository struct {
 	activityLogRepository *ActivityLogRepository
+	balanceRepository     *PaymentAccountBalanceRepository
 	db                    *gorm.DB
 }
 
 func NewPaymentAccountRepository(db *gorm.DB) *PaymentAccountRepository {
 	return &PaymentAccountRepository{
 		activityLogRepository: NewActivityLogRepository(db),
+		balanceRepository:     NewPaymentAccountBalanceRepository(db),
 		db:                    db,
 	}
 }
 
-func (r *PaymentAccountRepository) Count(userID uint) (int64, error) {
+// Count and FindAllPaginated leave archived accounts out unless includeArchived is set.
+func (r *PaymentAccountRepository) Count(userID uint, includeArchived bool) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.PaymentAccount{}).Where("user_id = ?", userID).Count(&count).Error
+	err := r.scopeArchived(r.db.Model(&models.PaymentAccount{}).Where("user_id = ?", userID), includeArchived).Count(&count).Error
 	return count, err
 }
 
-func (r *PaymentAccountRepository) FindAllPaginated(userID uint, page, limit int) ([]models.PaymentAccount, error) {
+func (r *PaymentAccountRepository) FindAllPaginated(userID uint, page, limit int, includeArchived bool) ([]models.PaymentAccount, error) {
 	var paymentAccounts []models.PaymentAccount
 
 	offset := (page - 1) * limit
 
-	err := r.db.Where("user_id = ?", userID).Offset(offset).Limit(limit).Find(&paymentAccounts).Error
+	err := r.scopeArchived(r.db.Where("user_id = ?", userID), includeArchived).Offset(offset).Limit(limit).Find(&paymentAccounts).Error
 
 	return paymentAccounts, err
 }
 
+func (r *PaymentAccountRepository) scopeArchived(query *gorm.DB, includeArchived bool) *gorm.DB {
+	if includeArchived {
+		return query
+	}
+	return query.Where("archived_at IS NULL")
+}
+
+// IsReferenced reports whether any payment or recurring payment still books on the account.
+func (r *PaymentAccountRepository) IsReferenced(id uint) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&models.Payment{}).
+		Where("payment_account_id = ? OR payment_account_to_id = ?", id, id).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = r.db.Model(&models.RecurringPayment{}).
+		Where("payment_account_id = ? OR payment_account_to_id = ?", id, id).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// UpdateFields changes columns other than the balance, balance changes go through Update so they are logged.
+func (r *PaymentAccountRepository) UpdateFields(tx *gorm.DB, id uint, fields map[string]interface{}) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.Model(&models.PaymentAccount{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// ! Create
+func (r *PaymentAccountRepository) Create(tx *gorm.DB, userId uint, userName string, paymentAccount *models.PaymentAccount) (*models.PaymentAccount, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	if err := db.Create(paymentAccount).Error; err != nil {
+		return nil, err
+	}
+
+	opening := models.PaymentAccountBalance{
+		UserID:           paymentAccount.UserID,
+		PaymentAccountID: paymentAccount.ID,
+		Source:           models.PaymentAccountBalanceSourceOpening,
+		Amount:           paymentAccount.Deposit,
+		Balance:          paymentAccount.Deposit,
+		RecordedAt:       time.Now(),
+	}
+
+	if err := r.balanceRepository.Create(db, opening); err != nil {
+		return nil, err
+	}
+
+	r.afterCreate(userId, userName, paymentAccount)
+
+	return paymentAccount, nil
+}
+
+func (r *PaymentAccountRepository) afterCreate(userId uint, userName string, paymentAccount *models.PaymentAccount) error {
+	properties, _ := json.Marshal(paymentAccountLogProperties(paymentAccount, nil))
+
+	err := r.activityLogRepository.Store(&models.ActivityLog{
+		Event:       "Created",
+		LogName:     "Resource",
+		Description: "Payment Account Created by " + userName,
+		SubjectType: utils.String("App\\Models\\PaymentAccount"),
+		SubjectID:   &paymentAccount.ID,
+		CauserType:  "App\\Models\\User",
+		CauserID:    userId,
+		Properties:  properties,
+	})
+
+	if err != nil {
+		log.Println("Payment Account afterCreate error: ", err)
+	}
+
+	return err
+}
+
+// ! End Create
+
+// ! Delete
+func (r *PaymentAccountRepository) Delete(tx *gorm.DB, userId uint, userName string, paymentAccount *models.PaymentAccount) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	if err := db.Delete(&models.PaymentAccount{}, paymentAccount.ID).Error; err != nil {
+		return err
+	}
+
+	r.afterDelete(userId, userName, paymentAccount)
+
+	return nil
+}
+
+func (r *PaymentAccountRepository) afterDelete(userId uint, userName string, paymentAccount *models.PaymentAccount) error {
+	prevProperties, _ := json.Marshal(paymentAccountLogProperties(paymentAccount, nil))
+
+	err := r.activityLogRepository.Store(&models.ActivityLog{
+		Event:          "Deleted",
+		LogName:        "Resource",
+		Description:    "Payment Account Deleted by " + userName,
+		SubjectType:    utils.String("App\\Models\\PaymentAccount"),
+		SubjectID:      &paymentAccount.ID,
+		CauserType:     "App\\Models\\User",
+		CauserID:       userId,
+		PrevProperties: (*json.RawMessage)(&prevProperties),
+		Properties:     json.RawMessage("{}"),
+	})
+
+	if err != nil {
+		log.Println("Payment Account afterDelete error: ", err)
+	}
+
+	return err
+}
+
+// ! End Delete
+
 // ! Update
+func (r *PaymentAccountRepository) FindAllByUserID(userID uint) ([]models.PaymentAccount, error) {
+	var paymentAccounts []models.PaymentAccount
+	err := r.db.Where("user_id = ?", userID).Order("name asc").Find(&paymentAccounts).Error
+	return paymentAccounts, err
+}
+
 func (r *PaymentAccountRepository) Update(tx *gorm.DB, userId uint, userName string, paymentAccount *models.PaymentAccount, prevPaymentAccount *models.PaymentAccount) (*models.PaymentAccount, error) {
-	before, _ := r.beforeUpdate(tx, paymentAccount, prevPaymentAccount)
+	return r.UpdateForPayment(tx, userId, userName, paymentAccount, prevPaymentAccount, nil)
+}
+
+// UpdateForPayment is Update for balance changes caused by a payment, the payment is kept on the balance ledger entry.
+func (r *PaymentAccountRepository) UpdateForPayment(tx *gorm.DB, userId uint, userName string, paymentAccount *models.PaymentAccount, prevPaymentAccount *models.PaymentAccount, paymentID *uint) (*models.PaymentAccount, error) {
+	before, err := r.beforeUpdate(tx, paymentAccount, prevPaymentAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{"deposit": paymentAccount.Deposit}
+
+	if paymentAccount.Name != "" {
+		fields["name"] = paymentAccount.Name
+	}
+
+	if paymentAccount.Logo != "" {
+		fields["logo"] = paymentAccount.Logo
+	}
+
+	if err := tx.Model(&models.PaymentAccount{}).Where("id = ?", paymentAccount.ID).Updates(fields).Error; err != nil {
+		return nil, err
+	}
 
-	if err := tx.Where("id = ?", paymentAccount.ID).Updates(paymentAccount).Error; err != nil {
+	if err := r.recordBalance(tx, before, paymentAccount.Deposit, paymentID); err != nil {
 		return nil, err
 	}
 
@@ -63,6 +222,28 @@ func (r *PaymentAccountRepository) Update(tx *gorm.DB, userId uint, userName str
 	return paymentAccount, nil
 }
 
+// recordBalance writes a balance ledger entry when the deposit changed.
+func (r *PaymentAccountRepository) recordBalance(tx *gorm.DB, before *models.PaymentAccount, deposit int64, paymentID *uint) error {
+	if deposit == before.Deposit {
+		return nil
+	}
+
+	source := models.PaymentAccountBalanceSourceManual
+	if paymentID != nil {
+		source = models.PaymentAccountBalanceSourcePayment
+	}
+
+	return r.balanceRepository.Create(tx, models.PaymentAccountBalance{
+		UserID:           before.UserID,
+		PaymentAccountID: before.ID,
+		PaymentID:        paymentID,
+		Source:           source,
+		Amount:           deposit - before.Deposit,
+		Balance:          deposit,
+		RecordedAt:       time.Now(),
+	})
+}
+
 func (r *PaymentAccountRepository) beforeUpdate(tx *gorm.DB, paymentAccount *models.PaymentAccount, prevPaymentAccount *models.PaymentAccount) (*models.PaymentAccount, error) {
 	var err error
 	before := prevPaymentAccount
@@ -89,21 +270,8 @@ func (r *PaymentAccountRepository) afterUpdate(tx *gorm.DB, userId uint, userNam
 
 	difference := prevPaymentAccount.Deposit - paymentAccount.Deposit
 
-	logPrevProps := dto.PaymentAccountLogProperties{
-		ID:         prevPaymentAccount.ID,
-		UserID:     prevPaymentAccount.UserID,
-		Name:       prevPaymentAccount.Name,
-		Deposit:    prevPaymentAccount.Deposit,
-		Difference: &difference,
-	}
-
-	logProps := dto.PaymentAccountLogProperties{
-		ID:         paymentAccount.ID,
-		UserID:     paymentAccount.UserID,
-		Name:       paymentAccount.Name,
-		Deposit:    paymentAccount.Deposit,
-		Difference: &difference,
-	}
+	logPrevProps := paymentAccountLogProperties(prevPaymentAccount, &difference)
+	logProps := paymentAccountLogProperties(paymentAccount, &difference)
 
 	properties, _ := json.Marshal(logProps)
 	prevProperties, _ := json.Marshal(logPrevProps)
@@ -129,6 +297,17 @@ func (r *PaymentAccountRepository) afterUpdate(tx *gorm.DB, userId uint, userNam
 
 // ! End Update
 
+// ProcessAll calls the callback with every payment account, in batches.
+func (r *PaymentAccountRepository) ProcessAll(batchSize int, callback func(paymentAccounts []models.PaymentAccount) error) error {
+	var paymentAccounts []models.PaymentAccount
+
+	return r.db.
+		Select("id", "user_id", "name", "deposit").
+		FindInBatches(&paymentAccounts, batchSize, func(tx *gorm.DB, batch int) error {
+			return callback(paymentAccounts)
+		}).Error
+}
+
 func (r *PaymentAccountRepository) FindByID(id uint) (*models.PaymentAccount, error) {
 	var paymentAccount models.PaymentAccount
 	err := r.db.First(&paymentAccount, id).Error
@@ -145,3 +324,22 @@ func (r *PaymentAccountRepository) SelectByID(tx *gorm.DB, id uint, fields []str
 	err := tx.Select(fields).First(&paymentAccount, id).Error
 	return &paymentAccount, err
 }
+
+// SelectForUpdate is SelectByID inside a transaction, locking the row until the transaction ends so the balance read
+// is not overwritten by a concurrent write.
+func (r *PaymentAccountRepository) SelectForUpdate(tx *gorm.DB, id uint, fields []string) (*models.PaymentAccount, error) {
+	var paymentAccount models.PaymentAccount
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select(fields).First(&paymentAccount, id).Error
+	return &paymentAccount, err
+}
+
+func paymentAccountLogProperties(paymentAccount *models.PaymentAccount, difference *int64) dto.PaymentAccountLogProperties {
+	return dto.PaymentAccountLogProperties{
+		ID:         paymentAccount.ID,
+		UserID:     paymentAccount.UserID,
+		Name:       paymentAccount.Name,
+		Deposit:    paymentAccount.Deposit,
+		Difference: difference,
+	}
+}
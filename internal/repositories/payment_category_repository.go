@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type PaymentCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentCategoryRepository(db *gorm.DB) *PaymentCategoryRepository {
+	return &PaymentCategoryRepository{db: db}
+}
+
+// CategorySuggestion is a category used by past payments with a name like the one being suggested for.
+type CategorySuggestion struct {
+	CategoryID uint
+	Name       string
+	Count      int64
+}
+
+func (r *PaymentCategoryRepository) FindAllByUser(userID uint) ([]models.PaymentCategory, error) {
+	var categories []models.PaymentCategory
+	err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+func (r *PaymentCategoryRepository) FindByID(id uint) (*models.PaymentCategory, error) {
+	var category models.PaymentCategory
+	err := r.db.First(&category, id).Error
+	return &category, err
+}
+
+// SelectByID loads only the given columns of a category, inside tx when one is given.
+func (r *PaymentCategoryRepository) SelectByID(tx *gorm.DB, id uint, fields []string) (*models.PaymentCategory, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var category models.PaymentCategory
+	err := tx.Select(fields).First(&category, id).Error
+	return &category, err
+}
+
+// Subtree returns the IDs of the category and its descendants, or nil when the category is not one of the user.
+func (r *PaymentCategoryRepository) Subtree(userID uint, id uint) ([]uint, error) {
+	categories, err := r.FindAllByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, category := range categories {
+		if category.ID == id {
+			return models.CategoryDescendants(categories, id), nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// FilterIDs returns the category IDs a payment filter on the category should match: the category and its
+// subcategories, or a match-nothing list when the category is not one of the user. It returns nil without a category.
+func (r *PaymentCategoryRepository) FilterIDs(userID uint, id int) []uint {
+	if id <= 0 {
+		return nil
+	}
+
+	ids, err := r.Subtree(userID, uint(id))
+	if err != nil {
+		return []uint{0}
+	}
+
+	return ids
+}
+
+// ExistsByName reports whether the user has a category with the name under the same parent, ignoring case.
+func (r *PaymentCategoryRepository) ExistsByName(userID uint, parentID *uint, name string, exceptID uint) bool {
+	query := r.db.Model(&models.PaymentCategory{}).
+		Where("user_id = ?", userID).
+		Where("LOWER(name) = ?", strings.ToLower(name)).
+		Where("id <> ?", exceptID)
+
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	var count int64
+	query.Count(&count)
+	return count > 0
+}
+
+func (r *PaymentCategoryRepository) HasChildren(id uint) bool {
+	var count int64
+	r.db.Model(&models.PaymentCategory{}).Where("parent_id = ?", id).Count(&count)
+	return count > 0
+}
+
+func (r *PaymentCategoryRepository) Create(category *models.PaymentCategory) error {
+	return r.db.Create(category).Error
+}
+
+func (r *PaymentCategoryRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	return r.db.Model(&models.PaymentCategory{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// Delete removes the category and leaves its payments uncategorized.
+func (r *PaymentCategoryRepository) Delete(tx *gorm.DB, id uint) error {
+	if err := tx.Model(&models.Payment{}).Where("category_id = ?", id).Update("category_id", nil).Error; err != nil {
+		return err
+	}
+
+	return tx.Delete(&models.PaymentCategory{}, id).Error
+}
+
+// Suggestions counts the categories of past payments of the user whose name contains any of the words, most
+// used first. Exact name matches count ten times, so a payment named like an earlier one takes its category.
+func (r *PaymentCategoryRepository) Suggestions(userID uint, name string, words []string, limit int) ([]CategorySuggestion, error) {
+	var suggestions []CategorySuggestion
+
+	if len(words) == 0 {
+		return suggestions, nil
+	}
+
+	conditions := make([]string, 0, len(words))
+	args := make([]interface{}, 0, len(words))
+	for _, word := range words {
+		conditions = append(conditions, "LOWER(payments.name) LIKE ?")
+		args = append(args, "%"+word+"%")
+	}
+
+	err := r.db.Table("payments").
+		Select("payments.category_id, payment_categories.name, SUM(CASE WHEN LOWER(payments.name) = ? THEN 10 ELSE 1 END) AS count", strings.ToLower(name)).
+		Joins("INNER JOIN payment_categories ON payment_categories.id = payments.category_id").
+		Where("payments.user_id = ?", userID).
+		Where("payments.category_id IS NOT NULL").
+		Where("("+strings.Join(conditions, " OR ")+")", args...).
+		Group("payments.category_id, payment_categories.name").
+		Order("count DESC").
+		Limit(limit).
+		Scan(&suggestions).Error
+
+	return suggestions, err
+}
@@ -1,9 +1,14 @@
 package repositories
 
 import (
+	"encoding/json"
+	"golang-api/internal/dto"
 	"golang-api/internal/models"
+	"golang-api/pkg/utils"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentGoalRepository struct {
@@ -56,3 +61,144 @@ func (r *PaymentGoalRepository) GetOverview(userID uint) (int64, int64, error) {
 
 	return totalGoals, completedGoals, err
 }
+
+// LockByID loads the goal for an update inside the transaction, so concurrent contributions add up.
+func (r *PaymentGoalRepository) LockByID(tx *gorm.DB, id uint) (*models.PaymentGoal, error) {
+	var goal models.PaymentGoal
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&goal, id).Error
+	return &goal, err
+}
+
+// ! Create
+func (r *PaymentGoalRepository) Create(tx *gorm.DB, userId uint, userName string, goal *models.PaymentGoal) error {
+	if err := tx.Create(goal).Error; err != nil {
+		return err
+	}
+
+	properties, _ := json.Marshal(paymentGoalLogProperties(goal))
+
+	return r.log(tx, &models.ActivityLog{
+		Event:       "Created",
+		LogName:     "Resource",
+		Description: "Payment Goal Created by " + userName,
+		SubjectType: utils.String("App\\Models\\PaymentGoal"),
+		SubjectID:   &goal.ID,
+		CauserType:  "App\\Models\\User",
+		CauserID:    userId,
+		Properties:  properties,
+	})
+}
+
+// ! End Create
+
+// ! Update
+// Update writes the goal fields and logs the change against prev, the goal as it was before.
+func (r *PaymentGoalRepository) Update(tx *gorm.DB, userId uint, userName string, goal *models.PaymentGoal, prev *models.PaymentGoal) error {
+	err := tx.Model(&models.PaymentGoal{}).Where("id = ?", goal.ID).Updates(map[string]interface{}{
+		"name":             goal.Name,
+		"description":      goal.Description,
+		"status_id":        goal.StatusID,
+		"amount":           goal.Amount,
+		"target_amount":    goal.TargetAmount,
+		"progress_percent": goal.ProgressPercent,
+		"start_date":       time.Time(goal.StartDate),
+		"target_date":      time.Time(goal.TargetDate),
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	properties, _ := json.Marshal(paymentGoalLogProperties(goal))
+	prevProperties, _ := json.Marshal(paymentGoalLogProperties(prev))
+
+	return r.log(tx, &models.ActivityLog{
+		Event:          "Updated",
+		LogName:        "Resource",
+		Description:    "Payment Goal Updated by " + userName,
+		SubjectType:    utils.String("App\\Models\\PaymentGoal"),
+		SubjectID:      &goal.ID,
+		CauserType:     "App\\Models\\User",
+		CauserID:       userId,
+		PrevProperties: (*json.RawMessage)(&prevProperties),
+		Properties:     properties,
+	})
+}
+
+// ! End Update
+
+// ! Delete
+// Delete removes the goal and its contribution links, the contributed payments themselves are kept.
+func (r *PaymentGoalRepository) Delete(tx *gorm.DB, userId uint, userName string, goal *models.PaymentGoal) error {
+	if err := tx.Where("payment_goal_id = ?", goal.ID).Delete(&models.PaymentGoalContribution{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Delete(&models.PaymentGoal{}, goal.ID).Error; err != nil {
+		return err
+	}
+
+	prevProperties, _ := json.Marshal(paymentGoalLogProperties(goal))
+
+	return r.log(tx, &models.ActivityLog{
+		Event:          "Deleted",
+		LogName:        "Resource",
+		Description:    "Payment Goal Deleted by " + userName,
+		SubjectType:    utils.String("App\\Models\\PaymentGoal"),
+		SubjectID:      &goal.ID,
+		CauserType:     "App\\Models\\User",
+		CauserID:       userId,
+		PrevProperties: (*json.RawMessage)(&prevProperties),
+		Properties:     json.RawMessage("{}"),
+	})
+}
+
+// ! End Delete
+
+func (r *PaymentGoalRepository) FindContributions(goalID uint) ([]models.PaymentGoalContribution, error) {
+	var contributions []models.PaymentGoalContribution
+
+	err := r.db.
+		Preload("Payment").
+		Where("payment_goal_id = ?", goalID).
+		Order("created_at desc").
+		Find(&contributions).Error
+
+	return contributions, err
+}
+
+func (r *PaymentGoalRepository) FindContributionByPayment(tx *gorm.DB, paymentID uint) (*models.PaymentGoalContribution, error) {
+	var contribution models.PaymentGoalContribution
+	err := tx.Where("payment_id = ?", paymentID).First(&contribution).Error
+	return &contribution, err
+}
+
+func (r *PaymentGoalRepository) CreateContribution(tx *gorm.DB, contribution *models.PaymentGoalContribution) error {
+	return tx.Create(contribution).Error
+}
+
+func (r *PaymentGoalRepository) UpdateContribution(tx *gorm.DB, id uint, amount int64) error {
+	return tx.Model(&models.PaymentGoalContribution{}).Where("id = ?", id).Update("amount", amount).Error
+}
+
+func (r *PaymentGoalRepository) DeleteContribution(tx *gorm.DB, id uint) error {
+	return tx.Delete(&models.PaymentGoalContribution{}, id).Error
+}
+
+// log stores the activity log inside the transaction, so it is rolled back with the change it describes.
+func (r *PaymentGoalRepository) log(tx *gorm.DB, activityLog *models.ActivityLog) error {
+	return NewActivityLogRepository(tx).Store(activityLog)
+}
+
+func paymentGoalLogProperties(goal *models.PaymentGoal) dto.PaymentGoalLogProperties {
+	return dto.PaymentGoalLogProperties{
+		ID:              goal.ID,
+		Code:            goal.Code,
+		Name:            goal.Name,
+		StatusID:        goal.StatusID,
+		Amount:          goal.Amount,
+		TargetAmount:    goal.TargetAmount,
+		ProgressPercent: goal.ProgressPercent,
+		StartDate:       time.Time(goal.StartDate).Format("2006-01-02"),
+		TargetDate:      time.Time(goal.TargetDate).Format("2006-01-02"),
+	}
+}
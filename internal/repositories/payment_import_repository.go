@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentImportRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentImportRepository(db *gorm.DB) *PaymentImportRepository {
+	return &PaymentImportRepository{db: db}
+}
+
+func (r *PaymentImportRepository) Create(paymentImport *models.PaymentImport) error {
+	return r.db.Create(paymentImport).Error
+}
+
+// FindForUpdate loads an import of a payment account inside a transaction and locks its row,
+// so the same import cannot be confirmed twice at the same time.
+func (r *PaymentImportRepository) FindForUpdate(tx *gorm.DB, id int, paymentAccountID uint) (*models.PaymentImport, error) {
+	var paymentImport models.PaymentImport
+
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("payment_account_id = ?", paymentAccountID).
+		First(&paymentImport, id).Error
+
+	return &paymentImport, err
+}
+
+func (r *PaymentImportRepository) Update(tx *gorm.DB, paymentImport *models.PaymentImport) error {
+	return tx.Save(paymentImport).Error
+}
+
+func (r *PaymentImportRepository) FindMapping(paymentAccountID uint) (*models.PaymentImportMapping, error) {
+	var mapping models.PaymentImportMapping
+	err := r.db.Where("payment_account_id = ?", paymentAccountID).First(&mapping).Error
+	return &mapping, err
+}
+
+func (r *PaymentImportRepository) SaveMapping(mapping *models.PaymentImportMapping) error {
+	return r.db.Save(mapping).Error
+}
@@ -27,6 +27,12 @@ func (r *PaymentItemRepository) FindByPaymentID(paymentID uint) ([]models.Paymen
 	return paymentItems, err
 }
 
+func (r *PaymentItemRepository) FindByPaymentIDs(paymentIDs []uint) ([]models.PaymentItem, error) {
+	var paymentItems []models.PaymentItem
+	err := r.db.Where("payment_id IN ?", paymentIDs).Preload("Item").Order("payment_id asc, id asc").Find(&paymentItems).Error
+	return paymentItems, err
+}
+
 func (r *PaymentItemRepository) FindByID(id uint) (*models.PaymentItem, error) {
 	var paymentItem models.PaymentItem
 	err := r.db.First(&paymentItem, id).Error
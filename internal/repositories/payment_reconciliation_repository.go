@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PaymentReconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentReconciliationRepository(db *gorm.DB) *PaymentReconciliationRepository {
+	return &PaymentReconciliationRepository{db: db}
+}
+
+func (r *PaymentReconciliationRepository) Create(tx *gorm.DB, reconciliation *models.PaymentReconciliation) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.Create(reconciliation).Error
+}
+
+func (r *PaymentReconciliationRepository) Latest(paymentAccountID uint) (*models.PaymentReconciliation, error) {
+	var reconciliation models.PaymentReconciliation
+
+	err := r.db.
+		Where("payment_account_id = ?", paymentAccountID).
+		Order("created_at DESC, id DESC").
+		First(&reconciliation).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reconciliation, nil
+}
+
+func (r *PaymentReconciliationRepository) Count(paymentAccountID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.PaymentReconciliation{}).Where("payment_account_id = ?", paymentAccountID).Count(&count).Error
+	return count, err
+}
+
+func (r *PaymentReconciliationRepository) FindAllPaginated(paymentAccountID uint, page, limit int) ([]models.PaymentReconciliation, error) {
+	var reconciliations []models.PaymentReconciliation
+	offset := (page - 1) * limit
+
+	err := r.db.
+		Where("payment_account_id = ?", paymentAccountID).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&reconciliations).Error
+
+	return reconciliations, err
+}
+
+// Flag marks the reconciliation as changed after the fact, keeping the time it was first flagged.
+func (r *PaymentReconciliationRepository) Flag(tx *gorm.DB, id uint) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.Model(&models.PaymentReconciliation{}).
+		Where("id = ? AND flagged_at IS NULL", id).
+		Update("flagged_at", time.Now()).Error
+}
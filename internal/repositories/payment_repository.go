@@ -6,17 +6,24 @@ import (
 	"golang-api/internal/models"
 	"golang-api/pkg/utils"
 	"log"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PaymentFilter struct {
-	DateFrom  string
-	DateTo    string
-	Type      int
-	AccountID int
-	Search    string
-	UserID    uint
+	DateFrom    string
+	DateTo      string
+	Type        int
+	AccountID   int
+	Search      string
+	UserID      uint
+	CategoryIDs []uint
+	Tags        []string
+
+	// PublishFailed only matches scheduled payments the scheduler failed to publish.
+	PublishFailed bool
 }
 
 type PaymentRepository struct {
@@ -31,87 +38,266 @@ func NewPaymentRepository(db *gorm.DB) *PaymentRepository {
 	}
 }
 
-func (r *PaymentRepository) FindAllPaginated(page, limit int, filter PaymentFilter) ([]models.Payment, error) {
-	var payments []models.Payment
-	offset := (page - 1) * limit
+// apply narrows a payments query down to the filter, columns are qualified so the query may join other tables.
+func (f PaymentFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.DateFrom != "" {
+		query = query.Where("payments.date >= ?", f.DateFrom)
+	}
 
-	query := r.db.
-		Select("payments.*, (SELECT COUNT(*) FROM payment_item WHERE payment_item.payment_id = payments.id) as items_count").
-		Preload("PaymentType").
-		Preload("PaymentAccount").
-		Preload("PaymentAccountTo")
+	if f.DateTo != "" {
+		query = query.Where("payments.date <= ?", f.DateTo)
+	}
+
+	if f.Type > 0 {
+		query = query.Where("payments.type_id = ?", f.Type)
+	}
 
-	if filter.DateFrom != "" {
-		query = query.Where("payments.date >= ?", filter.DateFrom)
+	if f.AccountID > 0 {
+		query = query.Where("payments.payment_account_id = ?", f.AccountID)
 	}
 
-	if filter.DateTo != "" {
-		query = query.Where("payments.date <= ?", filter.DateTo)
+	if f.Search != "" {
+		query = query.Where("payments.name LIKE ?", "%"+f.Search+"%")
 	}
 
-	if filter.Type > 0 {
-		query = query.Where("payments.type_id = ?", filter.Type)
+	if f.UserID > 0 {
+		query = query.Where("payments.user_id = ?", f.UserID)
 	}
 
-	if filter.AccountID > 0 {
-		query = query.Where("payments.payment_account_id = ?", filter.AccountID)
+	if len(f.CategoryIDs) > 0 {
+		query = query.Where("payments.category_id IN ?", f.CategoryIDs)
 	}
 
-	if filter.Search != "" {
-		query = query.Where("payments.name LIKE ?", "%"+filter.Search+"%")
+	if f.PublishFailed {
+		query = query.Where("payments.is_scheduled = ? AND payments.publish_failed_at IS NOT NULL", true)
 	}
 
-	if filter.UserID > 0 {
-		query = query.Where("payments.user_id = ?", filter.UserID)
+	if len(f.Tags) > 0 {
+		query = query.Where(`EXISTS (SELECT 1 FROM payment_payment_tag
+			INNER JOIN payment_tags ON payment_tags.id = payment_payment_tag.payment_tag_id
+			WHERE payment_payment_tag.payment_id = payments.id AND payment_tags.name IN ?)`, f.Tags)
+	}
+
+	return query
+}
+
+func (r *PaymentRepository) FindAllPaginated(page, limit int, filter PaymentFilter) ([]models.Payment, error) {
+	var payments []models.Payment
+	offset := (page - 1) * limit
+
+	query := r.db.
+		Select("payments.*, (SELECT COUNT(*) FROM payment_item WHERE payment_item.payment_id = payments.id) as items_count").
+		Preload("PaymentType").
+		Preload("PaymentAccount").
+		Preload("PaymentAccountTo").
+		Preload("Category").
+		Preload("Tags")
+
+	err := filter.apply(query).Offset(offset).Limit(limit).Order("updated_at desc").Find(&payments).Error
+	if err == nil {
+		r.fillCategoryPaths(payments...)
 	}
 
-	err := query.Offset(offset).Limit(limit).Order("updated_at desc").Find(&payments).Error
 	return payments, err
 }
 
 func (r *PaymentRepository) Count(filter PaymentFilter) (int64, error) {
 	var count int64
 
-	query := r.db.Model(&models.Payment{})
+	err := filter.apply(r.db.Model(&models.Payment{})).Count(&count).Error
+	return count, err
+}
 
-	if filter.DateFrom != "" {
-		query = query.Where("date >= ?", filter.DateFrom)
+// StreamFiltered reads the filtered payments row by row, with the type and account names joined in,
+// so exports never hold the whole result set in memory.
+func (r *PaymentRepository) StreamFiltered(filter PaymentFilter, callback func(row *dto.PaymentExportRow) error) error {
+	query := r.db.
+		Table("payments").
+		Select(`payments.id, payments.code, payments.date, payments.name, payments.amount, payments.currency, payments.is_scheduled, payments.is_draft,
+			payment_types.name AS type_name,
+			payment_account.name AS account_name,
+			payment_account_to.name AS account_to_name,
+			(SELECT COUNT(*) FROM payment_item WHERE payment_item.payment_id = payments.id) AS items_count`).
+		Joins("LEFT JOIN payment_types ON payment_types.id = payments.type_id").
+		Joins("LEFT JOIN payment_accounts AS payment_account ON payment_account.id = payments.payment_account_id").
+		Joins("LEFT JOIN payment_accounts AS payment_account_to ON payment_account_to.id = payments.payment_account_to_id")
+
+	rows, err := filter.apply(query).Order("payments.date asc, payments.id asc").Rows()
+	if err != nil {
+		return err
 	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dto.PaymentExportRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
 
-	if filter.DateTo != "" {
-		query = query.Where("date <= ?", filter.DateTo)
+		if err := callback(&row); err != nil {
+			return err
+		}
 	}
 
-	if filter.Type > 0 {
-		query = query.Where("type_id = ?", filter.Type)
+	return rows.Err()
+}
+
+func (r *PaymentRepository) FindByID(id int) (*models.Payment, error) {
+	var payment models.Payment
+
+	err := r.db.
+		Preload("PaymentType").
+		Preload("PaymentAccount").
+		Preload("PaymentAccountTo").
+		Preload("Category").
+		Preload("Tags").
+		First(&payment, id).Error
+
+	if err == nil {
+		r.fillCategoryPaths(payment)
 	}
 
-	if filter.AccountID > 0 {
-		query = query.Where("payment_account_id = ?", filter.AccountID)
+	return &payment, err
+}
+
+// fillCategoryPaths sets the full path, e.g. "Food > Groceries", of the preloaded payment categories.
+func (r *PaymentRepository) fillCategoryPaths(payments ...models.Payment) {
+	userIDs := []uint{}
+	for _, payment := range payments {
+		if payment.Category != nil {
+			userIDs = append(userIDs, payment.UserID)
+		}
 	}
 
-	if filter.Search != "" {
-		query = query.Where("name LIKE ?", "%"+filter.Search+"%")
+	if len(userIDs) == 0 {
+		return
 	}
 
-	if filter.UserID > 0 {
-		query = query.Where("user_id = ?", filter.UserID)
+	var categories []models.PaymentCategory
+	if err := r.db.Select("id", "parent_id", "name").Where("user_id IN ?", userIDs).Find(&categories).Error; err != nil {
+		return
 	}
 
-	err := query.Count(&count).Error
-	return count, err
+	paths := models.CategoryPaths(categories)
+	for i := range payments {
+		if payments[i].Category != nil {
+			payments[i].Category.Path = paths[payments[i].Category.ID]
+		}
+	}
 }
 
-func (r *PaymentRepository) FindByID(id int) (*models.Payment, error) {
+// FindForUpdate loads a payment inside a transaction and locks its row until the transaction ends.
+func (r *PaymentRepository) FindForUpdate(tx *gorm.DB, id uint) (*models.Payment, error) {
 	var payment models.Payment
 
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&payment, id).Error
+
+	return &payment, err
+}
+
+func (r *PaymentRepository) ProcessDueScheduled(date string, batchSize int, callback func(payments []models.Payment) error) error {
+	var payments []models.Payment
+
+	return r.db.
+		Where("is_scheduled = ?", true).
+		Where("is_draft = ?", false).
+		Where("date <= ?", date).
+		Where("publish_attempts < ?", models.MaxPublishAttempts).
+		FindInBatches(&payments, batchSize, func(tx *gorm.DB, batch int) error {
+			return callback(payments)
+		}).Error
+}
+
+// RecordPublishFailure counts a failed attempt of the scheduler to publish a payment, without logging an activity.
+func (r *PaymentRepository) RecordPublishFailure(paymentID uint, reason string) error {
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+
+	return r.db.Model(&models.Payment{}).Where("id = ?", paymentID).UpdateColumns(map[string]interface{}{
+		"publish_attempts":  gorm.Expr("publish_attempts + 1"),
+		"publish_failed_at": time.Now(),
+		"publish_error":     reason,
+	}).Error
+}
+
+// FindBookedBetween returns the booked (non draft, non scheduled) payments of a user within a date range, oldest first.
+func (r *PaymentRepository) FindBookedBetween(userID uint, startDate, endDate string) ([]models.Payment, error) {
+	var payments []models.Payment
+
 	err := r.db.
 		Preload("PaymentType").
 		Preload("PaymentAccount").
 		Preload("PaymentAccountTo").
-		First(&payment, id).Error
+		Where("user_id = ?", userID).
+		Where("is_draft = ?", false).
+		Where("is_scheduled = ?", false).
+		Where("date BETWEEN ? AND ?", startDate, endDate).
+		Order("date asc, id asc").
+		Find(&payments).Error
 
-	return &payment, err
+	return payments, err
+}
+
+// FindByAccountBetween returns the payments of a user touching a payment account, either side of a transfer, within a date range.
+func (r *PaymentRepository) FindByAccountBetween(userID uint, paymentAccountID uint, startDate, endDate string) ([]models.Payment, error) {
+	var payments []models.Payment
+
+	err := r.db.
+		Where("user_id = ?", userID).
+		Where("payment_account_id = ? OR payment_account_to_id = ?", paymentAccountID, paymentAccountID).
+		Where("date BETWEEN ? AND ?", startDate, endDate).
+		Find(&payments).Error
+
+	return payments, err
+}
+
+// FindBookedByAccount returns every booked payment touching a payment account, either side of a transfer, oldest first.
+func (r *PaymentRepository) FindBookedByAccount(paymentAccountID uint) ([]models.Payment, error) {
+	var payments []models.Payment
+
+	err := r.db.
+		Where("payment_account_id = ? OR payment_account_to_id = ?", paymentAccountID, paymentAccountID).
+		Where("is_draft = ?", false).
+		Where("is_scheduled = ?", false).
+		Order("date asc, id asc").
+		Find(&payments).Error
+
+	return payments, err
+}
+
+// FindUnreconciledByAccount returns the booked payments touching a payment account that no reconciliation cleared yet, oldest first.
+func (r *PaymentRepository) FindUnreconciledByAccount(paymentAccountID uint) ([]models.Payment, error) {
+	var payments []models.Payment
+
+	err := r.db.
+		Preload("PaymentType").
+		Preload("PaymentAccount").
+		Preload("PaymentAccountTo").
+		Where("payment_account_id = ? OR payment_account_to_id = ?", paymentAccountID, paymentAccountID).
+		Where("is_draft = ?", false).
+		Where("is_scheduled = ?", false).
+		Where("reconciliation_id IS NULL").
+		Order("date asc, id asc").
+		Find(&payments).Error
+
+	return payments, err
+}
+
+// MarkReconciled clears the payments as part of the given reconciliation.
+func (r *PaymentRepository) MarkReconciled(tx *gorm.DB, paymentIDs []uint, reconciliationID uint) error {
+	if len(paymentIDs) == 0 {
+		return nil
+	}
+
+	return tx.Model(&models.Payment{}).
+		Where("id IN ?", paymentIDs).
+		Updates(map[string]interface{}{
+			"cleared_at":        time.Now(),
+			"reconciliation_id": reconciliationID,
+		}).Error
 }
 
 // ! Create
@@ -124,33 +310,20 @@ func (r *PaymentRepository) Create(tx *gorm.DB, userId uint, userName string, pa
 		Preload("PaymentType").
 		Preload("PaymentAccount").
 		Preload("PaymentAccountTo").
+		Preload("Category").
+		Preload("Tags").
 		First(payment, payment.ID).Error; err != nil {
 		return nil, err
 	}
 
+	r.fillCategoryPaths(*payment)
 	r.afterCreate(userId, userName, payment)
 
 	return payment, nil
 }
 
 func (r *PaymentRepository) afterCreate(userId uint, userName string, payment *models.Payment) (*models.Payment, error) {
-	logProps := dto.PaymentLogProperties{
-		ID:                 payment.ID,
-		UserID:             payment.UserID,
-		Code:               payment.Code,
-		Name:               payment.Name,
-		Date:               payment.Date,
-		Amount:             payment.Amount,
-		HasItems:           payment.HasItems,
-		IsScheduled:        payment.IsScheduled,
-		IsDraft:            payment.IsDraft,
-		Attachments:        payment.Attachments,
-		TypeID:             payment.TypeID,
-		PaymentAccountID:   payment.PaymentAccountID,
-		PaymentAccountToID: payment.PaymentAccountToID,
-	}
-
-	properties, _ := json.Marshal(logProps)
+	properties, _ := json.Marshal(paymentLogProperties(payment))
 
 	err := r.activityLogRepository.Store(&models.ActivityLog{
 		Event:       "Created",
@@ -185,7 +358,9 @@ func (r *PaymentRepository) UpdateFields(tx *gorm.DB, paymentID uint, userId uin
 		return err
 	}
 
-	r.afterUpdateFields(db, userId, userName, before)
+	if before != nil {
+		r.afterUpdateFields(db, userId, userName, before)
+	}
 
 	return nil
 }
@@ -208,40 +383,8 @@ func (r *PaymentRepository) afterUpdateFields(db *gorm.DB, userId uint, userName
 		return err
 	}
 
-	prevProps := dto.PaymentLogProperties{
-		ID:                 prevPayment.ID,
-		UserID:             prevPayment.UserID,
-		Code:               prevPayment.Code,
-		Name:               prevPayment.Name,
-		Date:               prevPayment.Date,
-		Amount:             prevPayment.Amount,
-		HasItems:           prevPayment.HasItems,
-		IsScheduled:        prevPayment.IsScheduled,
-		IsDraft:            prevPayment.IsDraft,
-		Attachments:        prevPayment.Attachments,
-		TypeID:             prevPayment.TypeID,
-		PaymentAccountID:   prevPayment.PaymentAccountID,
-		PaymentAccountToID: prevPayment.PaymentAccountToID,
-	}
-
-	newProps := dto.PaymentLogProperties{
-		ID:                 payment.ID,
-		UserID:             payment.UserID,
-		Code:               payment.Code,
-		Name:               payment.Name,
-		Date:               payment.Date,
-		Amount:             payment.Amount,
-		HasItems:           payment.HasItems,
-		IsScheduled:        payment.IsScheduled,
-		IsDraft:            payment.IsDraft,
-		Attachments:        payment.Attachments,
-		TypeID:             payment.TypeID,
-		PaymentAccountID:   payment.PaymentAccountID,
-		PaymentAccountToID: payment.PaymentAccountToID,
-	}
-
-	properties, _ := json.Marshal(newProps)
-	prevProperties, _ := json.Marshal(prevProps)
+	properties, _ := json.Marshal(paymentLogProperties(&payment))
+	prevProperties, _ := json.Marshal(paymentLogProperties(prevPayment))
 
 	err = r.activityLogRepository.Store(&models.ActivityLog{
 		Event:          "Updated",
@@ -263,3 +406,62 @@ func (r *PaymentRepository) afterUpdateFields(db *gorm.DB, userId uint, userName
 }
 
 // ! End UpdateFields
+
+// ! Delete
+func (r *PaymentRepository) Delete(tx *gorm.DB, payment *models.Payment, userId uint, userName string) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	if err := db.Delete(&models.Payment{}, payment.ID).Error; err != nil {
+		return err
+	}
+
+	r.afterDelete(userId, userName, payment)
+
+	return nil
+}
+
+func (r *PaymentRepository) afterDelete(userId uint, userName string, payment *models.Payment) error {
+	prevProperties, _ := json.Marshal(paymentLogProperties(payment))
+
+	err := r.activityLogRepository.Store(&models.ActivityLog{
+		Event:          "Deleted",
+		LogName:        "Resource",
+		Description:    "Payment Deleted by " + userName,
+		SubjectType:    utils.String("App\\Models\\Payment"),
+		SubjectID:      &payment.ID,
+		CauserType:     "App\\Models\\User",
+		CauserID:       userId,
+		PrevProperties: (*json.RawMessage)(&prevProperties),
+		Properties:     json.RawMessage("{}"),
+	})
+
+	if err != nil {
+		log.Println("Payment afterDelete: failed to save activity log", err)
+	}
+
+	return err
+}
+
+// ! End Delete
+
+func paymentLogProperties(payment *models.Payment) dto.PaymentLogProperties {
+	return dto.PaymentLogProperties{
+		ID:                 payment.ID,
+		UserID:             payment.UserID,
+		Code:               payment.Code,
+		Name:               payment.Name,
+		Date:               payment.Date,
+		Amount:             payment.Amount,
+		HasItems:           payment.HasItems,
+		IsScheduled:        payment.IsScheduled,
+		IsDraft:            payment.IsDraft,
+		Attachments:        payment.Attachments,
+		TypeID:             payment.TypeID,
+		PaymentAccountID:   payment.PaymentAccountID,
+		PaymentAccountToID: payment.PaymentAccountToID,
+		CategoryID:         payment.CategoryID,
+	}
+}
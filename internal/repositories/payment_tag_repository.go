@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentTagRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentTagRepository(db *gorm.DB) *PaymentTagRepository {
+	return &PaymentTagRepository{db: db}
+}
+
+// FindAllByUser returns the tags of the user with the number of payments using them, optionally filtered by name.
+func (r *PaymentTagRepository) FindAllByUser(userID uint, search string) ([]models.PaymentTag, error) {
+	var tags []models.PaymentTag
+
+	query := r.db.
+		Select("payment_tags.*, (SELECT COUNT(*) FROM payment_payment_tag WHERE payment_payment_tag.payment_tag_id = payment_tags.id) AS payments_count").
+		Where("user_id = ?", userID)
+
+	if search != "" {
+		query = query.Where("name LIKE ?", "%"+search+"%")
+	}
+
+	err := query.Order("name ASC").Find(&tags).Error
+	return tags, err
+}
+
+func (r *PaymentTagRepository) FindByID(id uint) (*models.PaymentTag, error) {
+	var tag models.PaymentTag
+	err := r.db.First(&tag, id).Error
+	return &tag, err
+}
+
+func (r *PaymentTagRepository) FindByName(userID uint, name string) (*models.PaymentTag, error) {
+	var tag models.PaymentTag
+	err := r.db.Where("user_id = ? AND name = ?", userID, name).First(&tag).Error
+	return &tag, err
+}
+
+func (r *PaymentTagRepository) Create(tag *models.PaymentTag) error {
+	return r.db.Create(tag).Error
+}
+
+func (r *PaymentTagRepository) UpdateName(id uint, name string) error {
+	return r.db.Model(&models.PaymentTag{}).Where("id = ?", id).Update("name", name).Error
+}
+
+// Delete removes the tag from its payments and deletes it.
+func (r *PaymentTagRepository) Delete(tx *gorm.DB, id uint) error {
+	if err := tx.Where("payment_tag_id = ?", id).Delete(&models.PaymentPaymentTag{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Delete(&models.PaymentTag{}, id).Error
+}
+
+// FirstOrCreate returns the tags of the user with the given names, creating the missing ones.
+func (r *PaymentTagRepository) FirstOrCreate(tx *gorm.DB, userID uint, names []string) ([]models.PaymentTag, error) {
+	var tags []models.PaymentTag
+
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	missing := make([]models.PaymentTag, 0, len(names))
+	for _, name := range names {
+		missing = append(missing, models.PaymentTag{UserID: userID, Name: name})
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&missing).Error; err != nil {
+		return nil, err
+	}
+
+	err := tx.Where("user_id = ? AND name IN ?", userID, names).Order("name ASC").Find(&tags).Error
+	return tags, err
+}
+
+// Sync replaces the tags of the payment.
+func (r *PaymentTagRepository) Sync(tx *gorm.DB, paymentID uint, tags []models.PaymentTag) error {
+	if err := tx.Where("payment_id = ?", paymentID).Delete(&models.PaymentPaymentTag{}).Error; err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	pivots := make([]models.PaymentPaymentTag, 0, len(tags))
+	for _, tag := range tags {
+		pivots = append(pivots, models.PaymentPaymentTag{PaymentID: paymentID, PaymentTagID: tag.ID})
+	}
+
+	return tx.Create(&pivots).Error
+}
+
+// DetachPayment removes every tag from the payment, it is called when the payment is deleted.
+func (r *PaymentTagRepository) DetachPayment(tx *gorm.DB, paymentID uint) error {
+	return tx.Where("payment_id = ?", paymentID).Delete(&models.PaymentPaymentTag{}).Error
+}
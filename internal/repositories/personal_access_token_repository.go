@@ -1,7 +1,10 @@
 package repositories
 
 import (
+	"encoding/json"
 	"golang-api/internal/models"
+	"golang-api/internal/tokencache"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -16,7 +19,11 @@ func NewPersonalAccessTokenRepository(db *gorm.DB) *PersonalAccessTokenRepositor
 
 type TokenWithUser struct {
 	models.PersonalAccessToken
-	UserName string
+	UserName          string
+	UserRole          string
+	UserLocale        *string
+	UserTimezone      *string
+	UserDeactivatedAt *time.Time
 }
 
 func (repo PersonalAccessTokenRepository) FindByIDAndHashedToken(id uint64, hashedToken string) (*models.PersonalAccessToken, error) {
@@ -34,8 +41,8 @@ func (repo PersonalAccessTokenRepository) FindByIDAndHashedTokenWithUser(id uint
 	var result TokenWithUser
 
 	err := repo.db.Table("personal_access_tokens").
-		Select("personal_access_tokens.*, users.name as user_name").
-		Joins("INNER JOIN users ON users.id = personal_access_tokens.tokenable_id").
+		Select("personal_access_tokens.*, users.name as user_name, users.role as user_role, users.locale as user_locale, users.timezone as user_timezone, users.deactivated_at as user_deactivated_at").
+		Joins("INNER JOIN users ON users.id = personal_access_tokens.tokenable_id AND users.deleted_at IS NULL").
 		Where("personal_access_tokens.id = ? AND personal_access_tokens.token = ?", id, hashedToken).
 		Scan(&result).Error
 
@@ -43,10 +50,15 @@ func (repo PersonalAccessTokenRepository) FindByIDAndHashedTokenWithUser(id uint
 		return nil, err
 	}
 
+	if result.ID == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
 	return &result, nil
 }
 
 func (repo PersonalAccessTokenRepository) Delete(token *models.PersonalAccessToken) error {
+	defer tokencache.Tokens.Forget(token.ID)
 	return repo.db.Delete(token).Error
 }
 
@@ -54,10 +66,76 @@ func (repo PersonalAccessTokenRepository) Create(token *models.PersonalAccessTok
 	return repo.db.Create(token).Error
 }
 
-func (repo PersonalAccessTokenRepository) DeleteByUserID(userID uint) error {
-	return repo.db.Where("tokenable_type = ? AND tokenable_id = ?", "App\\Models\\User", userID).Delete(&models.PersonalAccessToken{}).Error
+// DeleteByUserID revokes every token of the user. The cached tokens are not dropped, call tokencache.Tokens.ForgetUser
+// once the transaction is committed so no request can cache a revoked token again.
+func (repo PersonalAccessTokenRepository) DeleteByUserID(tx *gorm.DB, userID uint) error {
+	if tx == nil {
+		tx = repo.db
+	}
+
+	return tx.Where("tokenable_type = ? AND tokenable_id = ?", "App\\Models\\User", userID).Delete(&models.PersonalAccessToken{}).Error
+}
+
+func (repo PersonalAccessTokenRepository) FindAllByUserID(userID uint) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+
+	err := repo.db.
+		Where("tokenable_type = ? AND tokenable_id = ?", "App\\Models\\User", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+
+	return tokens, err
+}
+
+func (repo PersonalAccessTokenRepository) FindByIDAndUserID(id uint64, userID uint) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+
+	err := repo.db.
+		Where("tokenable_type = ? AND tokenable_id = ?", "App\\Models\\User", userID).
+		First(&token, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// DeleteByUserIDExcept revokes every token of the user except the given one and returns how many were revoked.
+func (repo PersonalAccessTokenRepository) DeleteByUserIDExcept(userID uint, exceptID uint) (int64, error) {
+	defer tokencache.Tokens.ForgetUser(userID)
+
+	result := repo.db.
+		Where("tokenable_type = ? AND tokenable_id = ? AND id <> ?", "App\\Models\\User", userID, exceptID).
+		Delete(&models.PersonalAccessToken{})
+
+	return result.RowsAffected, result.Error
 }
 
 func (repo PersonalAccessTokenRepository) UpdateFields(token *models.PersonalAccessToken, fields map[string]interface{}) error {
+	defer tokencache.Tokens.Forget(token.ID)
 	return repo.db.Model(token).Updates(fields).Error
 }
+
+// RestrictToUnverified narrows every token of the user down to the unverified abilities it already had. The cached
+// tokens are not dropped, call tokencache.Tokens.ForgetUser once the transaction is committed.
+func (repo PersonalAccessTokenRepository) RestrictToUnverified(tx *gorm.DB, userID uint) error {
+	if tx == nil {
+		tx = repo.db
+	}
+
+	var tokens []models.PersonalAccessToken
+	if err := tx.Where("tokenable_type = ? AND tokenable_id = ?", "App\\Models\\User", userID).Find(&tokens).Error; err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		abilities, _ := json.Marshal(models.UnverifiedAbilitiesOf(token.AbilityList()))
+
+		if err := tx.Model(&models.PersonalAccessToken{}).Where("id = ?", token.ID).Update("abilities", string(abilities)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RecurringPaymentRepository struct {
+	db *gorm.DB
+}
+
+func NewRecurringPaymentRepository(db *gorm.DB) *RecurringPaymentRepository {
+	return &RecurringPaymentRepository{db: db}
+}
+
+func (r *RecurringPaymentRepository) Count(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RecurringPayment{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *RecurringPaymentRepository) FindAllPaginated(userID uint, page, limit int) ([]models.RecurringPayment, error) {
+	var recurringPayments []models.RecurringPayment
+	offset := (page - 1) * limit
+
+	err := r.db.
+		Where("user_id = ?", userID).
+		Offset(offset).
+		Limit(limit).
+		Order("updated_at desc").
+		Find(&recurringPayments).Error
+
+	return recurringPayments, err
+}
+
+func (r *RecurringPaymentRepository) FindByID(id int, userID uint) (*models.RecurringPayment, error) {
+	var recurringPayment models.RecurringPayment
+
+	err := r.db.
+		Where("user_id = ?", userID).
+		First(&recurringPayment, id).Error
+
+	return &recurringPayment, err
+}
+
+func (r *RecurringPaymentRepository) Create(recurringPayment *models.RecurringPayment) error {
+	return r.db.Create(recurringPayment).Error
+}
+
+func (r *RecurringPaymentRepository) Update(tx *gorm.DB, recurringPayment *models.RecurringPayment) error {
+	if tx != nil {
+		return tx.Save(recurringPayment).Error
+	}
+	return r.db.Save(recurringPayment).Error
+}
+
+func (r *RecurringPaymentRepository) Delete(tx *gorm.DB, id uint) error {
+	if tx != nil {
+		return tx.Delete(&models.RecurringPayment{}, id).Error
+	}
+	return r.db.Delete(&models.RecurringPayment{}, id).Error
+}
+
+func (r *RecurringPaymentRepository) UpdateProgress(tx *gorm.DB, recurringPayment *models.RecurringPayment) error {
+	return tx.Model(&models.RecurringPayment{}).
+		Where("id = ?", recurringPayment.ID).
+		UpdateColumns(map[string]interface{}{
+			"generated_count": recurringPayment.GeneratedCount,
+			"next_date":       recurringPayment.NextDate,
+		}).Error
+}
+
+// ProcessDue walks, in batches, over every active rule with an occurrence due on or before the given date.
+func (r *RecurringPaymentRepository) ProcessDue(until time.Time, batchSize int, callback func(recurringPayments []models.RecurringPayment) error) error {
+	var recurringPayments []models.RecurringPayment
+
+	return r.db.
+		Where("is_active = ?", true).
+		Where("next_date IS NOT NULL AND next_date <= ?", until.Format("2006-01-02")).
+		FindInBatches(&recurringPayments, batchSize, func(tx *gorm.DB, batch int) error {
+			return callback(recurringPayments)
+		}).Error
+}
+
+func (r *RecurringPaymentRepository) FindOccurrences(recurringPaymentID uint) ([]models.RecurringPaymentOccurrence, error) {
+	var occurrences []models.RecurringPaymentOccurrence
+
+	err := r.db.
+		Where("recurring_payment_id = ?", recurringPaymentID).
+		Order("date asc").
+		Find(&occurrences).Error
+
+	return occurrences, err
+}
+
+func (r *RecurringPaymentRepository) FindOccurrenceByDate(tx *gorm.DB, recurringPaymentID uint, date time.Time) (*models.RecurringPaymentOccurrence, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var occurrence models.RecurringPaymentOccurrence
+
+	err := tx.
+		Where("recurring_payment_id = ?", recurringPaymentID).
+		Where("date = ?", date.Format("2006-01-02")).
+		First(&occurrence).Error
+
+	return &occurrence, err
+}
+
+func (r *RecurringPaymentRepository) SaveOccurrence(tx *gorm.DB, occurrence *models.RecurringPaymentOccurrence) error {
+	if tx != nil {
+		return tx.Save(occurrence).Error
+	}
+	return r.db.Save(occurrence).Error
+}
+
+func (r *RecurringPaymentRepository) DeleteOccurrence(id uint) error {
+	return r.db.Delete(&models.RecurringPaymentOccurrence{}, id).Error
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(tx *gorm.DB, refreshToken *models.RefreshToken) error {
+	return tx.Create(refreshToken).Error
+}
+
+// FindForUpdate locks the refresh token so two concurrent refreshes cannot both rotate it.
+func (r *RefreshTokenRepository) FindForUpdate(tx *gorm.DB, id uint64, hashedToken string) (*models.RefreshToken, error) {
+	var refreshToken models.RefreshToken
+
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND token = ?", id, hashedToken).
+		First(&refreshToken).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &refreshToken, nil
+}
+
+func (r *RefreshTokenRepository) MarkRotated(tx *gorm.DB, id uint) error {
+	return tx.Model(&models.RefreshToken{}).
+		Where("id = ?", id).
+		Update("rotated_at", time.Now()).Error
+}
+
+// RevokeFamily revokes every refresh token of the family and deletes the access tokens issued with them, returning
+// the IDs of those access tokens. The caller drops them from the token cache once the transaction is committed, a
+// request in between could otherwise cache a deleted token again.
+func (r *RefreshTokenRepository) RevokeFamily(tx *gorm.DB, family string) ([]uint, error) {
+	var accessTokenIDs []uint
+
+	if err := tx.Model(&models.RefreshToken{}).
+		Where("family = ?", family).
+		Pluck("personal_access_token_id", &accessTokenIDs).Error; err != nil {
+		return nil, err
+	}
+
+	if len(accessTokenIDs) > 0 {
+		if err := tx.Where("id IN ?", accessTokenIDs).Delete(&models.PersonalAccessToken{}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	err := tx.Model(&models.RefreshToken{}).
+		Where("family = ? AND revoked_at IS NULL", family).
+		Update("revoked_at", time.Now()).Error
+
+	return accessTokenIDs, err
+}
+
+// RevokeByAccessTokenIDs revokes the refresh tokens that were issued together with the given access tokens.
+func (r *RefreshTokenRepository) RevokeByAccessTokenIDs(accessTokenIDs ...uint) error {
+	if len(accessTokenIDs) == 0 {
+		return nil
+	}
+
+	return r.db.Model(&models.RefreshToken{}).
+		Where("personal_access_token_id IN ? AND revoked_at IS NULL", accessTokenIDs).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepository) RevokeByUserID(tx *gorm.DB, userID uint) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeByUserIDExcept revokes the refresh tokens of the user except the one issued with the given access token.
+func (r *RefreshTokenRepository) RevokeByUserIDExcept(userID uint, accessTokenID uint) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND personal_access_token_id <> ? AND revoked_at IS NULL", userID, accessTokenID).
+		Update("revoked_at", time.Now()).Error
+}
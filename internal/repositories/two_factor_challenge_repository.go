@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"golang-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorChallengeRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorChallengeRepository(db *gorm.DB) *TwoFactorChallengeRepository {
+	return &TwoFactorChallengeRepository{db: db}
+}
+
+// Consume records the challenge as completed and reports whether it was not completed before.
+func (r *TwoFactorChallengeRepository) Consume(challenge *models.TwoFactorChallenge) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(challenge)
+	return result.RowsAffected == 1, result.Error
+}
+
+// DeleteExpired removes the challenges that expired before now, their tokens are refused anyway.
+func (r *TwoFactorChallengeRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at < ?", now).Delete(&models.TwoFactorChallenge{}).Error
+}
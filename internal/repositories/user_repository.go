@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"golang-api/internal/models"
+	"golang-api/internal/tokencache"
 
 	"gorm.io/gorm"
 )
@@ -51,6 +52,13 @@ func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+// EmailTaken reports whether another user, deleted ones included, already uses the email address.
+func (r *UserRepository) EmailTaken(email string, exceptID uint) bool {
+	var count int64
+	r.db.Unscoped().Model(&models.User{}).Where("email = ? AND id <> ?", email, exceptID).Count(&count)
+	return count > 0
+}
+
 func (r *UserRepository) Create(user *models.User) error {
 	return r.db.Create(user).Error
 }
@@ -59,14 +67,43 @@ func (r *UserRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
 
-func (r *UserRepository) UpdatePassword(userID uint, password string) error {
-	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("password", password).Error
+// UpdatePassword leaves the token cache alone, the tokens of the user are revoked with it and forgotten after the commit.
+func (r *UserRepository) UpdatePassword(tx *gorm.DB, userID uint, password string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.Model(&models.User{}).Where("id = ?", userID).Update("password", password).Error
 }
 
 func (r *UserRepository) Delete(id uint) error {
+	defer tokencache.Tokens.ForgetUser(id)
 	return r.db.Delete(&models.User{}, id).Error
 }
 
+// UpdateFields also drops the cached tokens of the user, so a changed role, name or deactivation applies on the next request.
 func (r *UserRepository) UpdateFields(id uint, fields map[string]interface{}) error {
+	defer tokencache.Tokens.ForgetUser(id)
 	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(fields).Error
 }
+
+// UseTwoFactorStep records the TOTP time step of an accepted code and reports whether it is later than the last
+// recorded one, so each code, and every code of an earlier step, is only accepted once.
+func (r *UserRepository) UseTwoFactorStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ?", id).
+		Where("two_factor_last_step IS NULL OR two_factor_last_step < ?", step).
+		Update("two_factor_last_step", step)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReplaceRecoveryCodes swaps the encrypted two-factor recovery codes only when they still hold the previous value,
+// and reports whether they did, so a recovery code is used once even by concurrent logins.
+func (r *UserRepository) ReplaceRecoveryCodes(id uint, previous, recoveryCodes string) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND two_factor_recovery_codes = ?", id, previous).
+		Update("two_factor_recovery_codes", recoveryCodes)
+
+	return result.RowsAffected == 1, result.Error
+}
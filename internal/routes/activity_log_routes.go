@@ -3,6 +3,7 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -10,8 +11,8 @@ import (
 
 func ActivityLogRoutes(api fiber.Router, db *gorm.DB) {
 	ctrl := controllers.NewActivityLogController(db)
-	activityLogs := api.Group("/activity-logs", middleware.Auth(db))
+	activityLogs := api.Group("/activity-logs", middleware.Auth(db), middleware.RequireAdmin())
 
-	activityLogs.Get("/", ctrl.Index)
-	activityLogs.Post("/", ctrl.Store)
+	activityLogs.Get("/", middleware.RequireAbility(models.AbilityActivityLogsRead), ctrl.Index)
+	activityLogs.Post("/", middleware.RequireAbility(models.AbilityActivityLogsWrite), ctrl.Store)
 }
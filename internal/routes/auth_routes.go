@@ -3,6 +3,7 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -15,8 +16,27 @@ func AuthRoutes(api fiber.Router, db *gorm.DB) {
 	auth.Use(middleware.AuthLimiter())
 
 	auth.Post("/login", authController.Login)
+	auth.Post("/refresh", authController.Refresh)
+	auth.Post("/register", authController.Register)
+	auth.Post("/verify-email", authController.VerifyEmail)
+	auth.Post("/verify-email/resend", middleware.Auth(db), authController.ResendVerification)
+	auth.Post("/forgot-password", authController.ForgotPassword)
+	auth.Post("/reset-password", authController.ResetPassword)
+	auth.Post("/two-factor/challenge", authController.TwoFactorChallenge)
 	auth.Get("/validate-token", middleware.Auth(db), authController.ValidateToken)
 	auth.Post("/logout", middleware.Auth(db), authController.Logout)
-	auth.Post("/change-password", middleware.Auth(db), authController.ChangePassword)
-	auth.Put("/profile", middleware.Auth(db), authController.UpdateProfile)
+	auth.Post("/change-password", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.ChangePassword)
+	auth.Put("/profile", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.UpdateProfile)
+
+	auth.Get("/login-history", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileRead), authController.LoginHistory)
+	auth.Get("/tokens", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileRead), authController.Tokens)
+	auth.Post("/tokens", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.StoreToken)
+	auth.Delete("/tokens", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.DestroyOtherTokens)
+	auth.Delete("/tokens/:id", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.DestroyToken)
+
+	auth.Post("/two-factor", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.EnableTwoFactor)
+	auth.Post("/two-factor/confirm", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.ConfirmTwoFactor)
+	auth.Delete("/two-factor", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.DisableTwoFactor)
+	auth.Get("/two-factor/recovery-codes", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.TwoFactorRecoveryCodes)
+	auth.Post("/two-factor/recovery-codes", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite), authController.RegenerateTwoFactorRecoveryCodes)
 }
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"golang-api/internal/controllers"
+	"golang-api/internal/middleware"
+	"golang-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func BudgetRoutes(api fiber.Router, db *gorm.DB) {
+	budgetController := controllers.NewBudgetController(db)
+
+	read := middleware.RequireAbility(models.AbilityPaymentsRead)
+	write := middleware.RequireAbility(models.AbilityPaymentsWrite)
+
+	budgets := api.Group("/budgets", middleware.Auth(db))
+	budgets.Get("/", read, budgetController.Index)
+	budgets.Get("/status", read, budgetController.Status)
+	budgets.Post("/", write, budgetController.Store)
+	budgets.Get("/:id", read, budgetController.Show)
+	budgets.Put("/:id", write, budgetController.Update)
+	budgets.Delete("/:id", write, budgetController.Destroy)
+}
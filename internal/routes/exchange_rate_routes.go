@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"golang-api/internal/controllers"
+	"golang-api/internal/middleware"
+	"golang-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func ExchangeRateRoutes(api fiber.Router, db *gorm.DB) {
+	ctrl := controllers.NewExchangeRateController(db)
+	exchangeRates := api.Group("/exchange-rates", middleware.Auth(db))
+
+	exchangeRates.Get("/", middleware.RequireAbility(models.AbilityPaymentsRead), ctrl.Index)
+	exchangeRates.Post("/", middleware.RequireAdmin(), ctrl.Store)
+	exchangeRates.Post("/import", middleware.RequireAdmin(), ctrl.Import)
+	exchangeRates.Delete("/:id", middleware.RequireAdmin(), ctrl.Destroy)
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
+	"golang-api/internal/models"
 	"golang-api/internal/repositories"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,8 +12,10 @@ import (
 
 func FileRoutes(api fiber.Router, db *gorm.DB) {
 	fileDownloadRepo := repositories.NewFileDownloadRepository(db)
-	fileDownloadController := controllers.NewFileDownloadController(fileDownloadRepo)
+	fileRepo := repositories.NewFileRepository(db)
+	fileDownloadController := controllers.NewFileDownloadController(fileDownloadRepo, fileRepo)
 
-	files := api.Group("/files/d", middleware.Auth(db))
-	files.Get("/:uid", fileDownloadController.GetFiles)
+	files := api.Group("/files", middleware.Auth(db), middleware.RequireAbility(models.AbilityPaymentsRead))
+	files.Get("/d/:uid", fileDownloadController.GetFiles)
+	files.Get("/:code/download", fileDownloadController.Download)
 }
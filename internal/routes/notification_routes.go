@@ -3,6 +3,7 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -11,6 +12,6 @@ import (
 func NotificationRoutes(api fiber.Router, db *gorm.DB) {
 	notificationController := controllers.NewNotificationController()
 
-	notifications := api.Group("/notifications", middleware.Auth(db))
+	notifications := api.Group("/notifications", middleware.Auth(db), middleware.RequireAbility(models.AbilityProfileWrite))
 	notifications.Put("/settings", notificationController.UpdateSettings)
 }
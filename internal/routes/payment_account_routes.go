@@ -3,16 +3,37 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
-	"golang-api/internal/repositories"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
 func PaymentAccountRoutes(api fiber.Router, db *gorm.DB) {
-	paymentAccountRepo := repositories.NewPaymentAccountRepository(db)
-	paymentAccountController := controllers.NewPaymentAccountController(paymentAccountRepo)
+	paymentAccountController := controllers.NewPaymentAccountController(db)
+	paymentImportController := controllers.NewPaymentImportController(db)
+
+	read := middleware.RequireAbility(models.AbilityAccountsRead)
+	write := middleware.RequireAbility(models.AbilityAccountsWrite)
+	importPayments := middleware.RequireAbility(models.AbilityPaymentsWrite)
 
 	paymentAccounts := api.Group("/payment-accounts", middleware.Auth(db))
-	paymentAccounts.Get("/", paymentAccountController.Index)
+	paymentAccounts.Get("/", read, paymentAccountController.Index)
+	paymentAccounts.Post("/", write, paymentAccountController.Store)
+	paymentAccounts.Get("/:id", read, paymentAccountController.Show)
+	paymentAccounts.Get("/:id/balance", read, paymentAccountController.BalanceAt)
+	paymentAccounts.Get("/:id/balance-history", read, paymentAccountController.BalanceHistory)
+	paymentAccounts.Put("/:id", write, paymentAccountController.Update)
+	paymentAccounts.Delete("/:id", write, paymentAccountController.Destroy)
+	paymentAccounts.Post("/:id/archive", write, paymentAccountController.Archive)
+	paymentAccounts.Post("/:id/unarchive", write, paymentAccountController.Unarchive)
+	paymentAccounts.Get("/:id/reconcile", read, paymentAccountController.ReconcilePreview)
+	paymentAccounts.Post("/:id/reconcile", write, paymentAccountController.Reconcile)
+	paymentAccounts.Get("/:id/reconciliations", read, paymentAccountController.Reconciliations)
+	paymentAccounts.Post("/:id/pay", importPayments, paymentAccountController.Pay)
+
+	paymentAccounts.Get("/:id/import-mapping", read, paymentImportController.ShowMapping)
+	paymentAccounts.Put("/:id/import-mapping", write, paymentImportController.SaveMapping)
+	paymentAccounts.Post("/:id/import", importPayments, paymentImportController.Import)
+	paymentAccounts.Post("/:id/import/:importId/confirm", importPayments, paymentImportController.Confirm)
 }
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"golang-api/internal/controllers"
+	"golang-api/internal/middleware"
+	"golang-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func PaymentCategoryRoutes(api fiber.Router, db *gorm.DB) {
+	paymentCategoryController := controllers.NewPaymentCategoryController(db)
+	paymentTagController := controllers.NewPaymentTagController(db)
+
+	read := middleware.RequireAbility(models.AbilityPaymentsRead)
+	write := middleware.RequireAbility(models.AbilityPaymentsWrite)
+
+	paymentCategories := api.Group("/payment-categories", middleware.Auth(db))
+	paymentCategories.Get("/", read, paymentCategoryController.Index)
+	paymentCategories.Get("/suggest", read, paymentCategoryController.Suggest)
+	paymentCategories.Post("/", write, paymentCategoryController.Store)
+	paymentCategories.Put("/:id", write, paymentCategoryController.Update)
+	paymentCategories.Delete("/:id", write, paymentCategoryController.Destroy)
+
+	paymentTags := api.Group("/payment-tags", middleware.Auth(db))
+	paymentTags.Get("/", read, paymentTagController.Index)
+	paymentTags.Post("/", write, paymentTagController.Store)
+	paymentTags.Put("/:id", write, paymentTagController.Update)
+	paymentTags.Delete("/:id", write, paymentTagController.Destroy)
+}
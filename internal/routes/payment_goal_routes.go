@@ -3,18 +3,26 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
-	"golang-api/internal/repositories"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
 func PaymentGoalRoutes(api fiber.Router, db *gorm.DB) {
-	paymentGoalRepo := repositories.NewPaymentGoalRepository(db)
-	paymentGoalController := controllers.NewPaymentGoalController(paymentGoalRepo)
+	paymentGoalController := controllers.NewPaymentGoalController(db)
+
+	read := middleware.RequireAbility(models.AbilityGoalsRead)
+	write := middleware.RequireAbility(models.AbilityGoalsWrite)
+	contribute := middleware.RequireAbility(models.AbilityGoalsWrite, models.AbilityPaymentsWrite)
 
 	paymentGoals := api.Group("/payment-goals", middleware.Auth(db))
-	paymentGoals.Get("/", paymentGoalController.Index)
-	paymentGoals.Get("/overview", paymentGoalController.Overview)
-	paymentGoals.Get("/:id", paymentGoalController.Show)
+	paymentGoals.Get("/", read, paymentGoalController.Index)
+	paymentGoals.Get("/overview", read, paymentGoalController.Overview)
+	paymentGoals.Post("/", write, paymentGoalController.Store)
+	paymentGoals.Get("/:id", read, paymentGoalController.Show)
+	paymentGoals.Put("/:id", write, paymentGoalController.Update)
+	paymentGoals.Delete("/:id", write, paymentGoalController.Destroy)
+	paymentGoals.Get("/:id/contributions", read, paymentGoalController.Contributions)
+	paymentGoals.Post("/:id/contributions", contribute, paymentGoalController.Contribute)
 }
@@ -3,6 +3,7 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -11,17 +12,24 @@ import (
 func PaymentRoutes(api fiber.Router, db *gorm.DB) {
 	paymentController := controllers.NewPaymentController(db)
 
+	read := middleware.RequireAbility(models.AbilityPaymentsRead)
+	write := middleware.RequireAbility(models.AbilityPaymentsWrite)
+
 	payments := api.Group("/payments", middleware.Auth(db))
 
-	payments.Get("/", paymentController.Index)
-	payments.Get("/summary", paymentController.Summary)
+	payments.Get("/", read, paymentController.Index)
+	payments.Get("/summary", read, paymentController.Summary)
+	payments.Get("/export", read, paymentController.Export)
 
-	payments.Post("/", paymentController.Store)
-	payments.Post("/generate-report", paymentController.GenerateReport)
+	payments.Post("/", write, paymentController.Store)
+	payments.Post("/generate-report", read, paymentController.GenerateReport)
 
-	payments.Get("/:id", paymentController.Show)
-	payments.Get("/:id/items/summary", paymentController.GetItemsSummary)
-	payments.Get("/:id/items/attached", paymentController.GetItemsAttached)
-	payments.Get("/:id/items/not-attached", paymentController.GetItemsNotAttached)
-	payments.Post("/:id/items/attach-multiple", paymentController.AttachMultipleItems)
+	payments.Get("/:id", read, paymentController.Show)
+	payments.Put("/:id", write, paymentController.Update)
+	payments.Delete("/:id", write, paymentController.Destroy)
+	payments.Post("/:id/publish", write, paymentController.Publish)
+	payments.Get("/:id/items/summary", read, paymentController.GetItemsSummary)
+	payments.Get("/:id/items/attached", read, paymentController.GetItemsAttached)
+	payments.Get("/:id/items/not-attached", read, paymentController.GetItemsNotAttached)
+	payments.Post("/:id/items/attach-multiple", write, paymentController.AttachMultipleItems)
 }
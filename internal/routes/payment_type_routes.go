@@ -3,6 +3,7 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
+	"golang-api/internal/models"
 	"golang-api/internal/repositories"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,6 +14,6 @@ func PaymentTypeRoutes(api fiber.Router, db *gorm.DB) {
 	paymentTypeRepo := repositories.NewPaymentTypeRepository(db)
 	paymentTypeController := controllers.NewPaymentTypeController(paymentTypeRepo)
 
-	paymentTypes := api.Group("/payment-types", middleware.Auth(db))
+	paymentTypes := api.Group("/payment-types", middleware.Auth(db), middleware.RequireAbility(models.AbilityPaymentsRead))
 	paymentTypes.Get("/", paymentTypeController.Index)
 }
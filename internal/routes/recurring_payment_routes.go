@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"golang-api/internal/controllers"
+	"golang-api/internal/middleware"
+	"golang-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RecurringPaymentRoutes(api fiber.Router, db *gorm.DB) {
+	ctrl := controllers.NewRecurringPaymentController(db)
+	read := middleware.RequireAbility(models.AbilityPaymentsRead)
+	write := middleware.RequireAbility(models.AbilityPaymentsWrite)
+
+	recurringPayments := api.Group("/recurring-payments", middleware.Auth(db))
+
+	recurringPayments.Get("/", read, ctrl.Index)
+	recurringPayments.Post("/", write, ctrl.Store)
+	recurringPayments.Get("/:id", read, ctrl.Show)
+	recurringPayments.Put("/:id", write, ctrl.Update)
+	recurringPayments.Delete("/:id", write, ctrl.Destroy)
+	recurringPayments.Get("/:id/occurrences", read, ctrl.Occurrences)
+	recurringPayments.Post("/:id/occurrences", write, ctrl.StoreOccurrence)
+	recurringPayments.Delete("/:id/occurrences/:occurrenceId", write, ctrl.DestroyOccurrence)
+}
@@ -31,7 +31,7 @@ func SetupRoutes(app *fiber.App) {
 		})
 	})
 
-	api.Get("/test-email", middleware.Auth(db), func(c *fiber.Ctx) error {
+	api.Get("/test-email", middleware.Auth(db), middleware.RequireAdmin(), func(c *fiber.Ctx) error {
 		to := c.Query("to", "admin@novadev.my.id")
 
 		err := utils.SendEmail(to, "Testing Golang Email", map[string]any{
@@ -55,11 +55,15 @@ func SetupRoutes(app *fiber.App) {
 	AuthRoutes(api, db)
 	UserRoutes(api, db)
 	PaymentRoutes(api, db)
+	PaymentCategoryRoutes(api, db)
+	BudgetRoutes(api, db)
+	RecurringPaymentRoutes(api, db)
 	NotificationRoutes(api, db)
 	FileRoutes(api, db)
 	PaymentGoalRoutes(api, db)
 	PaymentAccountRoutes(api, db)
 	PaymentTypeRoutes(api, db)
+	ExchangeRateRoutes(api, db)
 	ActivityLogRoutes(api, db)
 	UptimeMonitorRoutes(api, db)
 	UptimeMonitorLogRoutes(api, db)
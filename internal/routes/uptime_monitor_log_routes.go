@@ -15,6 +15,7 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -22,7 +23,7 @@ import (
 
 func UptimeMonitorLogRoutes(api fiber.Router, db *gorm.DB) {
 	ctrl := controllers.NewUptimeMonitorLogController(db)
-	logs := api.Group("/uptime-monitor-logs", middleware.Auth(db))
+	logs := api.Group("/uptime-monitor-logs", middleware.Auth(db), middleware.RequireAdmin(), middleware.RequireAbility(models.AbilityUptimeManage))
 
 	logs.Get("/", ctrl.Index)
 	logs.Post("/", ctrl.Store)
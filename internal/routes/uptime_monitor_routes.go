@@ -3,6 +3,7 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -10,7 +11,7 @@ import (
 
 func UptimeMonitorRoutes(api fiber.Router, db *gorm.DB) {
 	ctrl := controllers.NewUptimeMonitorController(db)
-	monitors := api.Group("/uptime-monitors", middleware.Auth(db))
+	monitors := api.Group("/uptime-monitors", middleware.Auth(db), middleware.RequireAdmin(), middleware.RequireAbility(models.AbilityUptimeManage))
 
 	monitors.Get("/", ctrl.Index)
 	monitors.Post("/run-checks", ctrl.RunChecks)
@@ -3,18 +3,25 @@ package routes
 import (
 	"golang-api/internal/controllers"
 	"golang-api/internal/middleware"
-	"golang-api/internal/repositories"
+	"golang-api/internal/models"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
 func UserRoutes(api fiber.Router, db *gorm.DB) {
-	userRepo := repositories.NewUserRepository(db)
-	userController := controllers.NewUserController(userRepo)
+	userController := controllers.NewUserController(db)
+
+	admin := middleware.RequireAdmin()
 
 	users := api.Group("/users", middleware.Auth(db))
-	users.Get("/", userController.Index)
-	users.Get("/me", userController.ShowMe)
-	users.Get("/:id", userController.Show)
+	users.Get("/", middleware.RequireAbility(models.AbilityUsersRead), userController.Index)
+	users.Get("/me", middleware.RequireAbility(models.AbilityProfileRead), userController.ShowMe)
+	users.Get("/:id", middleware.RequireAbility(models.AbilityUsersRead), userController.Show)
+	users.Post("/", admin, userController.Store)
+	users.Put("/:id", admin, userController.Update)
+	users.Delete("/:id", admin, userController.Destroy)
+	users.Post("/:id/deactivate", admin, userController.Deactivate)
+	users.Post("/:id/activate", admin, userController.Activate)
+	users.Post("/:id/unlock", admin, userController.Unlock)
 }
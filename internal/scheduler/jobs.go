@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"golang-api/internal/service"
+	"golang-api/internal/service/payment_service"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func RegisterJobs(s *Scheduler, db *gorm.DB) {
+	paymentPublisher := payment_service.NewPublishService(db)
+	recurringGenerator := payment_service.NewRecurringService(db)
+
+	s.Every(time.Minute, "generate-recurring-payments", func() {
+		results := recurringGenerator.GenerateDue()
+		if results["total"] > 0 {
+			log.Printf("[scheduler] recurring payments: %d generated, %d skipped, %d failed\n", results["generated"], results["skipped"], results["failed"])
+		}
+	})
+
+	s.Every(time.Minute, "publish-scheduled-payments", func() {
+		results := paymentPublisher.PublishDue()
+		if results["total"] > 0 {
+			log.Printf("[scheduler] scheduled payments: %d published, %d skipped, %d failed\n", results["published"], results["skipped"], results["failed"])
+		}
+	})
+
+	fileCleanup := service.NewFileCleanupService(db)
+
+	s.Every(time.Hour, "delete-expired-files", func() {
+		if deleted := fileCleanup.DeleteExpired(); deleted > 0 {
+			log.Printf("[scheduler] expired files: %d deleted\n", deleted)
+		}
+	})
+}
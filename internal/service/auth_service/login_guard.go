@@ -0,0 +1,101 @@
+package auth_service
+
+import (
+	"fmt"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"math"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// lockoutThreshold failed logins in a row lock the account, each further threshold locks it for the next, longer step.
+const lockoutThreshold = 5
+
+var lockoutSteps = []time.Duration{
+	1 * time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	1 * time.Hour,
+}
+
+// loginGuard records login attempts and locks an email address after repeated failures.
+// The lock follows the account, so spreading attempts over many IP addresses does not avoid it.
+type loginGuard struct {
+	attemptRepo *repositories.LoginAttemptRepository
+	lockoutRepo *repositories.AccountLockoutRepository
+}
+
+func newLoginGuard(db *gorm.DB) *loginGuard {
+	return &loginGuard{
+		attemptRepo: repositories.NewLoginAttemptRepository(db),
+		lockoutRepo: repositories.NewAccountLockoutRepository(db),
+	}
+}
+
+// Locked returns how long the email address stays locked, zero when it is not locked.
+func (g *loginGuard) Locked(email string) time.Duration {
+	lockout, err := g.lockoutRepo.FindByEmail(email)
+	if err != nil || !lockout.IsLocked() {
+		return 0
+	}
+	return time.Until(*lockout.LockedUntil)
+}
+
+func (g *loginGuard) Succeeded(c *fiber.Ctx, email string, userID *uint, reason string) {
+	g.record(c, email, userID, true, reason)
+
+	if reason != models.LoginAttemptReasonTwoFactorRequired {
+		g.lockoutRepo.DeleteByEmail(email)
+	}
+}
+
+func (g *loginGuard) Failed(c *fiber.Ctx, email string, userID *uint, reason string) {
+	g.record(c, email, userID, false, reason)
+
+	if reason == models.LoginAttemptReasonLocked || reason == models.LoginAttemptReasonDeactivated {
+		return
+	}
+
+	if err := g.lockoutRepo.RecordFailure(email, lockoutDuration); err != nil {
+		log.Println("Failed to save account lockout: ", err)
+	}
+}
+
+// lockoutDuration returns how long the account is locked after the given number of failed logins in a row, zero
+// unless the count reaches another threshold.
+func lockoutDuration(failedAttempts int) time.Duration {
+	if failedAttempts%lockoutThreshold != 0 {
+		return 0
+	}
+
+	step := int(math.Min(float64(failedAttempts/lockoutThreshold-1), float64(len(lockoutSteps)-1)))
+	return lockoutSteps[step]
+}
+
+func (g *loginGuard) record(c *fiber.Ctx, email string, userID *uint, successful bool, reason string) {
+	attempt := models.LoginAttempt{
+		UserID:     userID,
+		Email:      email,
+		IPAddress:  utils.String(c.IP()),
+		UserAgent:  utils.String(c.Get(fiber.HeaderUserAgent)),
+		Successful: successful,
+	}
+
+	if reason != "" {
+		attempt.Reason = utils.String(reason)
+	}
+
+	if err := g.attemptRepo.Create(&attempt); err != nil {
+		log.Println("Failed to record login attempt: ", err)
+	}
+}
+
+func lockedMessage(remaining time.Duration) string {
+	minutes := int(math.Ceil(remaining.Minutes()))
+	return fmt.Sprintf("Too many failed login attempts, please try again in %d minute(s)", minutes)
+}
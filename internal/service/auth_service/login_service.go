@@ -9,7 +9,9 @@ import (
 	"golang-api/internal/service"
 	"golang-api/pkg/utils"
 	"io"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,12 +22,14 @@ import (
 
 type LoginService interface {
 	Login(c *fiber.Ctx) error
+	History(c *fiber.Ctx) error
 }
 
 type loginService struct {
 	userRepo        *repositories.UserRepository
 	activityLogRepo *repositories.ActivityLogRepository
 	authService     service.AuthService
+	guard           *loginGuard
 }
 
 func NewLoginService(db *gorm.DB) LoginService {
@@ -33,48 +37,113 @@ func NewLoginService(db *gorm.DB) LoginService {
 		userRepo:        repositories.NewUserRepository(db),
 		activityLogRepo: repositories.NewActivityLogRepository(db),
 		authService:     service.NewAuthService(db),
+		guard:           newLoginGuard(db),
 	}
 }
 
 func (s *loginService) Login(c *fiber.Ctx) error {
-	data, errs := s.validate(c)
+	payload, errs := s.validate(c)
 	if errs != nil {
 		return utils.ValidationError(c, errs)
 	}
 
-	_, token, err := s.authenticate(data["email"].(string), data["password"].(string))
+	if remaining := s.guard.Locked(payload.Email); remaining > 0 {
+		s.guard.Failed(c, payload.Email, nil, models.LoginAttemptReasonLocked)
+		return utils.ErrorResponse(c, fiber.StatusTooManyRequests, lockedMessage(remaining))
+	}
+
+	user, session, err := s.authenticate(payload)
+
+	var userID *uint
+	if user != nil {
+		userID = &user.ID
+	}
+
 	if err != nil {
-		if err.Error() == "invalid_credentials" {
+		switch err.Error() {
+		case "invalid_credentials":
+			s.guard.Failed(c, payload.Email, userID, models.LoginAttemptReasonInvalidCredentials)
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid credentials")
+		case "account_deactivated":
+			s.guard.Failed(c, payload.Email, userID, models.LoginAttemptReasonDeactivated)
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Your account has been deactivated")
+		case "two_factor_required":
+			challenge, err := newTwoFactorChallenge(user, payload.TokenName, payload.Abilities)
+			if err != nil {
+				log.Println("Failed to create two-factor challenge: ", err)
+				return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create token")
+			}
+			s.guard.Succeeded(c, payload.Email, userID, models.LoginAttemptReasonTwoFactorRequired)
+			return utils.SuccessResponse(c, "Two-factor authentication required", challenge)
 		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create token")
 	}
 
-	s.sendNotification(token)
+	s.guard.Succeeded(c, payload.Email, userID, "")
+	s.sendNotification(session.Token)
 
-	return utils.SuccessResponse(c, "Login successful", dto.LoginResponse{
-		Token: token,
-	})
+	return utils.SuccessResponse(c, "Login successful", session)
 }
 
-func (s *loginService) validate(c *fiber.Ctx) (map[string]interface{}, map[string][]string) {
-	data := make(map[string]interface{})
+// History lists the login attempts of the current user, newest first.
+func (s *loginService) History(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	perPage, _ := strconv.Atoi(c.Query("per_page", "15"))
+
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 15
+	}
+
+	total, err := s.guard.attemptRepo.Count(userId)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to count login attempts")
+	}
+
+	attempts, err := s.guard.attemptRepo.FindAllPaginated(userId, page, perPage)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve login history")
+	}
+
+	return utils.PaginatedSuccessResponse(c, "Login history retrieved successfully", attempts, page, perPage, total, len(attempts))
+}
+
+func (s *loginService) validate(c *fiber.Ctx) (*dto.LoginRequest, map[string][]string) {
+	var payload dto.LoginRequest
 
 	rules := govalidator.MapData{
-		"email":    []string{"required", "email"},
-		"password": []string{"required", "min:6"},
+		"email":      []string{"required", "email"},
+		"password":   []string{"required", "min:6"},
+		"token_name": []string{"max:255"},
 	}
 
-	errs := utils.ValidateJSON(c, &data, rules)
+	errs := utils.ValidateJSON(c, &payload, rules)
 	if errs != nil {
 		return nil, errs
 	}
 
-	return data, nil
+	for _, ability := range payload.Abilities {
+		if !models.IsAbility(ability) {
+			return nil, map[string][]string{
+				"abilities": {fmt.Sprintf("The ability %q is invalid", ability)},
+			}
+		}
+	}
+
+	payload.Email = strings.ToLower(strings.TrimSpace(payload.Email))
+
+	if payload.TokenName == "" {
+		payload.TokenName = "auth_token"
+	}
+
+	return &payload, nil
 }
 
-func (s *loginService) authenticate(email, password string) (*models.User, string, error) {
-	return s.authService.Login(email, password)
+func (s *loginService) authenticate(payload *dto.LoginRequest) (*models.User, *dto.LoginResponse, error) {
+	return s.authService.Login(payload.Email, payload.Password, payload.TokenName, payload.Abilities)
 }
 
 func (s *loginService) sendNotification(token string) {
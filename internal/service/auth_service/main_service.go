@@ -1,24 +1,126 @@
 package auth_service
 
 import (
+	"golang-api/internal/models"
+
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
 type MainService interface {
 	Login(c *fiber.Ctx) error
+	LoginHistory(c *fiber.Ctx) error
+	Refresh(c *fiber.Ctx) error
+	Register(c *fiber.Ctx) error
+	VerifyEmail(c *fiber.Ctx) error
+	ResendVerification(c *fiber.Ctx) error
+	SendVerification(user models.User)
+	ForgotPassword(c *fiber.Ctx) error
+	ResetPassword(c *fiber.Ctx) error
+	EnableTwoFactor(c *fiber.Ctx) error
+	ConfirmTwoFactor(c *fiber.Ctx) error
+	DisableTwoFactor(c *fiber.Ctx) error
+	TwoFactorRecoveryCodes(c *fiber.Ctx) error
+	RegenerateTwoFactorRecoveryCodes(c *fiber.Ctx) error
+	TwoFactorChallenge(c *fiber.Ctx) error
+	Tokens(c *fiber.Ctx) error
+	StoreToken(c *fiber.Ctx) error
+	DestroyToken(c *fiber.Ctx) error
+	DestroyOtherTokens(c *fiber.Ctx) error
 }
 
 type mainService struct {
-	loginService LoginService
+	loginService    LoginService
+	refreshService  RefreshService
+	registerService RegisterService
+	passwordService PasswordResetService
+	twoFactor       TwoFactorService
+	tokenService    TokenService
 }
 
 func NewMainService(db *gorm.DB) MainService {
 	return &mainService{
-		loginService: NewLoginService(db),
+		loginService:    NewLoginService(db),
+		refreshService:  NewRefreshService(db),
+		registerService: NewRegisterService(db),
+		passwordService: NewPasswordResetService(db),
+		twoFactor:       NewTwoFactorService(db),
+		tokenService:    NewTokenService(db),
 	}
 }
 
 func (s *mainService) Login(c *fiber.Ctx) error {
 	return s.loginService.Login(c)
 }
+
+func (s *mainService) LoginHistory(c *fiber.Ctx) error {
+	return s.loginService.History(c)
+}
+
+func (s *mainService) Refresh(c *fiber.Ctx) error {
+	return s.refreshService.Refresh(c)
+}
+
+func (s *mainService) Register(c *fiber.Ctx) error {
+	return s.registerService.Register(c)
+}
+
+func (s *mainService) VerifyEmail(c *fiber.Ctx) error {
+	return s.registerService.VerifyEmail(c)
+}
+
+func (s *mainService) ResendVerification(c *fiber.Ctx) error {
+	return s.registerService.ResendVerification(c)
+}
+
+func (s *mainService) SendVerification(user models.User) {
+	s.registerService.SendVerification(user)
+}
+
+func (s *mainService) ForgotPassword(c *fiber.Ctx) error {
+	return s.passwordService.ForgotPassword(c)
+}
+
+func (s *mainService) ResetPassword(c *fiber.Ctx) error {
+	return s.passwordService.ResetPassword(c)
+}
+
+func (s *mainService) EnableTwoFactor(c *fiber.Ctx) error {
+	return s.twoFactor.Enable(c)
+}
+
+func (s *mainService) ConfirmTwoFactor(c *fiber.Ctx) error {
+	return s.twoFactor.Confirm(c)
+}
+
+func (s *mainService) DisableTwoFactor(c *fiber.Ctx) error {
+	return s.twoFactor.Disable(c)
+}
+
+func (s *mainService) TwoFactorRecoveryCodes(c *fiber.Ctx) error {
+	return s.twoFactor.RecoveryCodes(c)
+}
+
+func (s *mainService) RegenerateTwoFactorRecoveryCodes(c *fiber.Ctx) error {
+	return s.twoFactor.RegenerateRecoveryCodes(c)
+}
+
+func (s *mainService) TwoFactorChallenge(c *fiber.Ctx) error {
+	return s.twoFactor.Challenge(c)
+}
+
+func (s *mainService) Tokens(c *fiber.Ctx) error {
+	return s.tokenService.Index(c)
+}
+
+func (s *mainService) StoreToken(c *fiber.Ctx) error {
+	return s.tokenService.Store(c)
+}
+
+func (s *mainService) DestroyToken(c *fiber.Ctx) error {
+	return s.tokenService.Destroy(c)
+}
+
+func (s *mainService) DestroyOtherTokens(c *fiber.Ctx) error {
+	return s.tokenService.DestroyOthers(c)
+}
@@ -0,0 +1,170 @@
+package auth_service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/internal/service"
+	"golang-api/internal/tokencache"
+	"golang-api/pkg/utils"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+const (
+	passwordResetLifetime = 60 * time.Minute
+	passwordResetThrottle = 60 * time.Second
+)
+
+type PasswordResetService interface {
+	ForgotPassword(c *fiber.Ctx) error
+	ResetPassword(c *fiber.Ctx) error
+}
+
+type passwordResetService struct {
+	userRepo         *repositories.UserRepository
+	resetTokenRepo   *repositories.PasswordResetTokenRepository
+	tokenRepo        *repositories.PersonalAccessTokenRepository
+	refreshTokenRepo *repositories.RefreshTokenRepository
+}
+
+func NewPasswordResetService(db *gorm.DB) PasswordResetService {
+	return &passwordResetService{
+		userRepo:         repositories.NewUserRepository(db),
+		resetTokenRepo:   repositories.NewPasswordResetTokenRepository(db),
+		tokenRepo:        repositories.NewPersonalAccessTokenRepository(db),
+		refreshTokenRepo: repositories.NewRefreshTokenRepository(db),
+	}
+}
+
+// ForgotPassword emails a reset link. The response is the same whether or not the address exists,
+// and an address only gets one email per throttle window.
+func (s *passwordResetService) ForgotPassword(c *fiber.Ctx) error {
+	var payload dto.ForgotPasswordRequest
+
+	rules := govalidator.MapData{
+		"email": []string{"required", "email"},
+	}
+
+	errs := utils.ValidateJSON(c, &payload, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(payload.Email))
+	response := utils.SimpleSuccessResponse(c, "If the email address is registered, a password reset link has been sent")
+
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil {
+		return response
+	}
+
+	if existing, err := s.resetTokenRepo.FindByEmail(user.Email); err == nil && existing.CreatedAt != nil && time.Since(*existing.CreatedAt) < passwordResetThrottle {
+		return response
+	}
+
+	plainToken := make([]byte, 32)
+	rand.Read(plainToken)
+	token := hex.EncodeToString(plainToken)
+
+	if err := s.resetTokenRepo.Save(user.Email, hashResetToken(token)); err != nil {
+		log.Println("Failed to save password reset token: ", err)
+		return response
+	}
+
+	go s.sendResetEmail(*user, token)
+
+	return response
+}
+
+// ResetPassword sets the new password, consumes the reset token and revokes every token of the user.
+func (s *passwordResetService) ResetPassword(c *fiber.Ctx) error {
+	var payload dto.ResetPasswordRequest
+
+	rules := govalidator.MapData{
+		"email":                 []string{"required", "email"},
+		"token":                 []string{"required"},
+		"password":              []string{"required", "min:6"},
+		"password_confirmation": []string{"required", "min:6"},
+	}
+
+	errs := utils.ValidateJSON(c, &payload, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if payload.Password != payload.PasswordConfirmation {
+		return utils.ValidationError(c, map[string][]string{
+			"password": {"Password confirmation does not match"},
+		})
+	}
+
+	email := strings.ToLower(strings.TrimSpace(payload.Email))
+
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil || !s.validToken(user.Email, payload.Token) {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "This password reset token is invalid or has expired")
+	}
+
+	hashedPassword, err := service.HashPassword(payload.Password)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reset password")
+	}
+
+	if err := s.userRepo.UpdatePassword(nil, user.ID, hashedPassword); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to reset password")
+	}
+
+	s.resetTokenRepo.DeleteByEmail(user.Email)
+	s.tokenRepo.DeleteByUserID(nil, user.ID)
+	s.refreshTokenRepo.RevokeByUserID(nil, user.ID)
+	tokencache.Tokens.ForgetUser(user.ID)
+
+	return utils.SimpleSuccessResponse(c, "Password has been reset successfully, please log in with your new password")
+}
+
+func (s *passwordResetService) validToken(email string, token string) bool {
+	resetToken, err := s.resetTokenRepo.FindByEmail(email)
+	if err != nil || resetToken.CreatedAt == nil {
+		return false
+	}
+
+	if time.Since(*resetToken.CreatedAt) > passwordResetLifetime {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(resetToken.Token), []byte(hashResetToken(token))) == 1
+}
+
+func (s *passwordResetService) sendResetEmail(user models.User, token string) {
+	query := url.Values{
+		"token": {token},
+		"email": {user.Email},
+	}
+
+	err := utils.SendEmail(user.Email, "Reset Password", map[string]any{
+		"Name":      user.Name,
+		"ResetUrl":  utils.GetExternalUrl("main", "reset-password?"+query.Encode()),
+		"ExpiresIn": fmt.Sprintf("%d minutes", int(passwordResetLifetime.Minutes())),
+	}, "resources/views/emails/main.html", "resources/views/emails/reset_password.html")
+
+	if err != nil {
+		log.Printf("Failed to send password reset email to user %d: %v", user.ID, err)
+	}
+}
+
+func hashResetToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
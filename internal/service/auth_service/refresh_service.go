@@ -0,0 +1,55 @@
+package auth_service
+
+import (
+	"golang-api/internal/dto"
+	"golang-api/internal/service"
+	"golang-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type RefreshService interface {
+	Refresh(c *fiber.Ctx) error
+}
+
+type refreshService struct {
+	authService service.AuthService
+}
+
+func NewRefreshService(db *gorm.DB) RefreshService {
+	return &refreshService{
+		authService: service.NewAuthService(db),
+	}
+}
+
+func (s *refreshService) Refresh(c *fiber.Ctx) error {
+	var payload dto.RefreshTokenRequest
+
+	rules := govalidator.MapData{
+		"refresh_token": []string{"required"},
+	}
+
+	errs := utils.ValidateJSON(c, &payload, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	session, err := s.authService.Refresh(payload.RefreshToken)
+	if err != nil {
+		switch err.Error() {
+		case "invalid_refresh_token":
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: Invalid refresh token")
+		case "refresh_token_expired":
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: Refresh token expired")
+		case "account_deactivated":
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Your account has been deactivated")
+		case "refresh_token_reused":
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: Refresh token already used, the session has been revoked")
+		}
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to refresh token")
+	}
+
+	return utils.SuccessResponse(c, "Token refreshed successfully", session)
+}
@@ -0,0 +1,188 @@
+package auth_service
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/internal/service"
+	"golang-api/pkg/utils"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+const verificationLinkLifetime = 60 * time.Minute
+
+type RegisterService interface {
+	Register(c *fiber.Ctx) error
+	VerifyEmail(c *fiber.Ctx) error
+	ResendVerification(c *fiber.Ctx) error
+	SendVerification(user models.User)
+}
+
+type registerService struct {
+	userRepo     *repositories.UserRepository
+	generateRepo *repositories.GenerateRepository
+}
+
+func NewRegisterService(db *gorm.DB) RegisterService {
+	return &registerService{
+		userRepo:     repositories.NewUserRepository(db),
+		generateRepo: repositories.NewGenerateRepository(db),
+	}
+}
+
+func (s *registerService) Register(c *fiber.Ctx) error {
+	var payload dto.RegisterRequest
+
+	rules := govalidator.MapData{
+		"name":                  []string{"required", "min:3", "max:255"},
+		"email":                 []string{"required", "email", "max:255"},
+		"password":              []string{"required", "min:6"},
+		"password_confirmation": []string{"required", "min:6"},
+	}
+
+	errs := utils.ValidateJSON(c, &payload, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	payload.Email = strings.ToLower(strings.TrimSpace(payload.Email))
+
+	if payload.Password != payload.PasswordConfirmation {
+		return utils.ValidationError(c, map[string][]string{
+			"password": {"Password confirmation does not match"},
+		})
+	}
+
+	if s.userRepo.EmailTaken(payload.Email, 0) {
+		return utils.ValidationError(c, map[string][]string{
+			"email": {"The email has already been taken"},
+		})
+	}
+
+	hashedPassword, err := service.HashPassword(payload.Password)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to register user")
+	}
+
+	user := models.User{
+		Code:     s.generateRepo.GetCode("user", true),
+		Name:     payload.Name,
+		Email:    payload.Email,
+		Password: hashedPassword,
+	}
+
+	if err := s.userRepo.Create(&user); err != nil {
+		log.Println("Failed to register user: ", err)
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to register user")
+	}
+
+	go s.sendVerificationEmail(user)
+
+	return utils.CreatedResponse(c, "Registration successful, please check your email to verify your account", user)
+}
+
+// VerifyEmail marks the email address as verified. The link is bound to the address it was sent to,
+// so changing the email address invalidates links sent earlier.
+func (s *registerService) VerifyEmail(c *fiber.Ctx) error {
+	var payload dto.VerifyEmailRequest
+
+	rules := govalidator.MapData{
+		"id":        []string{"required"},
+		"hash":      []string{"required"},
+		"expires":   []string{"required"},
+		"signature": []string{"required"},
+	}
+
+	errs := utils.ValidateJSON(c, &payload, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	values := url.Values{
+		"id":        {payload.ID},
+		"hash":      {payload.Hash},
+		"expires":   {payload.Expires},
+		"signature": {payload.Signature},
+	}
+
+	if !utils.ValidSignature(values) {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "The verification link is invalid or has expired")
+	}
+
+	userID, _ := strconv.ParseUint(payload.ID, 10, 64)
+
+	user, err := s.userRepo.FindByID(uint(userID))
+	if err != nil || emailHash(user.Email) != payload.Hash {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "The verification link is invalid or has expired")
+	}
+
+	if user.IsVerified() {
+		return utils.SimpleSuccessResponse(c, "Email address is already verified")
+	}
+
+	if err := s.userRepo.UpdateFields(user.ID, map[string]interface{}{"email_verified_at": time.Now()}); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to verify email address")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Email address verified successfully, please log in again to get full access")
+}
+
+func (s *registerService) ResendVerification(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+
+	user, err := s.userRepo.FindByID(userId)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+	}
+
+	if user.IsVerified() {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Email address is already verified")
+	}
+
+	go s.sendVerificationEmail(*user)
+
+	return utils.SimpleSuccessResponse(c, "Verification email has been sent")
+}
+
+// SendVerification emails a verification link in the background, e.g. after the email address was changed.
+func (s *registerService) SendVerification(user models.User) {
+	go s.sendVerificationEmail(user)
+}
+
+func (s *registerService) sendVerificationEmail(user models.User) {
+	values, err := utils.SignValues(url.Values{
+		"id":   {strconv.FormatUint(uint64(user.ID), 10)},
+		"hash": {emailHash(user.Email)},
+	}, time.Now().Add(verificationLinkLifetime))
+	if err != nil {
+		log.Printf("Failed to sign verification link for user %d: %v", user.ID, err)
+		return
+	}
+
+	err = utils.SendEmail(user.Email, "Verify Email Address", map[string]any{
+		"Name":            user.Name,
+		"VerificationUrl": utils.GetExternalUrl("main", "verify-email?"+values.Encode()),
+		"ExpiresIn":       fmt.Sprintf("%d minutes", int(verificationLinkLifetime.Minutes())),
+	}, "resources/views/emails/main.html", "resources/views/emails/verify_email.html")
+
+	if err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+}
+
+// emailHash is the sha1 of the email address, the same hash Laravel puts in its verification links.
+func emailHash(email string) string {
+	hash := sha1.Sum([]byte(strings.ToLower(email)))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,175 @@
+package auth_service
+
+import (
+	"fmt"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/internal/service"
+	"golang-api/pkg/utils"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type TokenService interface {
+	Index(c *fiber.Ctx) error
+	Store(c *fiber.Ctx) error
+	Destroy(c *fiber.Ctx) error
+	DestroyOthers(c *fiber.Ctx) error
+}
+
+type tokenService struct {
+	tokenRepo        *repositories.PersonalAccessTokenRepository
+	refreshTokenRepo *repositories.RefreshTokenRepository
+	userRepo         *repositories.UserRepository
+	authService      service.AuthService
+}
+
+func NewTokenService(db *gorm.DB) TokenService {
+	return &tokenService{
+		tokenRepo:        repositories.NewPersonalAccessTokenRepository(db),
+		refreshTokenRepo: repositories.NewRefreshTokenRepository(db),
+		userRepo:         repositories.NewUserRepository(db),
+		authService:      service.NewAuthService(db),
+	}
+}
+
+func (s *tokenService) Index(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	current := c.Locals("token").(models.PersonalAccessToken)
+
+	tokens, err := s.tokenRepo.FindAllByUserID(userId)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve tokens")
+	}
+
+	result := make([]dto.TokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, tokenResponse(token, current.ID))
+	}
+
+	return utils.SuccessResponse(c, "Tokens retrieved successfully", result)
+}
+
+// Store mints a named API token. The new token cannot have an ability the current token lacks.
+func (s *tokenService) Store(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	current := c.Locals("token").(models.PersonalAccessToken)
+
+	var payload dto.StoreTokenRequest
+
+	expiresAt, errs := s.validate(c, current, &payload)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	user, err := s.userRepo.FindByID(userId)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+	}
+
+	token, plainToken, err := s.authService.CreateToken(user, payload.Name, payload.Abilities, expiresAt)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create token")
+	}
+
+	return utils.SuccessResponse(c, "Token created successfully", dto.StoreTokenResponse{
+		Token:       plainToken,
+		AccessToken: tokenResponse(*token, current.ID),
+	})
+}
+
+func (s *tokenService) Destroy(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+
+	tokenID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid token ID")
+	}
+
+	token, err := s.tokenRepo.FindByIDAndUserID(tokenID, userId)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Token not found")
+	}
+
+	if err := s.authService.RevokeTokens(*token); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to revoke token")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Token revoked successfully")
+}
+
+// DestroyOthers revokes every token of the user except the one that made the request.
+func (s *tokenService) DestroyOthers(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	current := c.Locals("token").(models.PersonalAccessToken)
+
+	revoked, err := s.tokenRepo.DeleteByUserIDExcept(userId, current.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to revoke tokens")
+	}
+
+	if err := s.refreshTokenRepo.RevokeByUserIDExcept(userId, current.ID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to revoke tokens")
+	}
+
+	return utils.SimpleSuccessResponse(c, fmt.Sprintf("%d token(s) revoked successfully", revoked))
+}
+
+func (s *tokenService) validate(c *fiber.Ctx, current models.PersonalAccessToken, payload *dto.StoreTokenRequest) (*time.Time, map[string][]string) {
+	rules := govalidator.MapData{
+		"name":       []string{"required", "max:255"},
+		"expires_at": []string{"date:yyyy-mm-dd"},
+	}
+
+	errs := utils.ValidateJSON(c, payload, rules)
+	if errs != nil {
+		return nil, errs
+	}
+
+	validationErrs := make(map[string][]string)
+
+	if len(payload.Abilities) == 0 {
+		validationErrs["abilities"] = []string{"This field is required"}
+	}
+
+	for _, ability := range payload.Abilities {
+		if !models.IsAbility(ability) {
+			validationErrs["abilities"] = append(validationErrs["abilities"], fmt.Sprintf("The ability %q is invalid", ability))
+		} else if !current.Can(ability) {
+			validationErrs["abilities"] = append(validationErrs["abilities"], fmt.Sprintf("The current token does not have the %q ability", ability))
+		}
+	}
+
+	var expiresAt *time.Time
+
+	if payload.ExpiresAt != nil && *payload.ExpiresAt != "" {
+		date, _ := time.ParseInLocation("2006-01-02", *payload.ExpiresAt, time.Local)
+		if !date.After(time.Now()) {
+			validationErrs["expires_at"] = []string{"The expiry date must be in the future"}
+		}
+		expiresAt = &date
+	}
+
+	if len(validationErrs) > 0 {
+		return nil, validationErrs
+	}
+
+	return expiresAt, nil
+}
+
+func tokenResponse(token models.PersonalAccessToken, currentID uint) dto.TokenResponse {
+	return dto.TokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Abilities:  token.AbilityList(),
+		LastUsedAt: token.LastUsedAt,
+		ExpiresAt:  token.ExpiresAt,
+		CreatedAt:  token.CreatedAt,
+		IsCurrent:  token.ID == currentID,
+	}
+}
@@ -0,0 +1,470 @@
+package auth_service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"golang-api/internal/config"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/internal/service"
+	"golang-api/pkg/utils"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	twoFactorChallengeLifetime = 5 * time.Minute
+	twoFactorChallengePurpose  = "two-factor-login"
+	recoveryCodeCount          = 8
+)
+
+type TwoFactorService interface {
+	Enable(c *fiber.Ctx) error
+	Confirm(c *fiber.Ctx) error
+	Disable(c *fiber.Ctx) error
+	RecoveryCodes(c *fiber.Ctx) error
+	RegenerateRecoveryCodes(c *fiber.Ctx) error
+	Challenge(c *fiber.Ctx) error
+}
+
+type twoFactorService struct {
+	userRepo      *repositories.UserRepository
+	challengeRepo *repositories.TwoFactorChallengeRepository
+	authService   service.AuthService
+	guard         *loginGuard
+}
+
+func NewTwoFactorService(db *gorm.DB) TwoFactorService {
+	return &twoFactorService{
+		userRepo:      repositories.NewUserRepository(db),
+		challengeRepo: repositories.NewTwoFactorChallengeRepository(db),
+		authService:   service.NewAuthService(db),
+		guard:         newLoginGuard(db),
+	}
+}
+
+// Enable starts the enrollment with a new secret and recovery codes. Two-factor authentication is only
+// enforced once the first code is confirmed, so an abandoned enrollment never locks the user out.
+func (s *twoFactorService) Enable(c *fiber.Ctx) error {
+	user, err := s.currentUser(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+	}
+
+	if user.HasTwoFactor() {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Two-factor authentication is already enabled")
+	}
+
+	secret := utils.GenerateTOTPSecret()
+	recoveryCodes := generateRecoveryCodes()
+
+	encryptedSecret, err := utils.Encrypt(secret)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to enable two-factor authentication")
+	}
+
+	encryptedCodes, err := encryptRecoveryCodes(recoveryCodes)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to enable two-factor authentication")
+	}
+
+	err = s.userRepo.UpdateFields(user.ID, map[string]interface{}{
+		"two_factor_secret":         encryptedSecret,
+		"two_factor_recovery_codes": encryptedCodes,
+		"two_factor_confirmed_at":   nil,
+	})
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to enable two-factor authentication")
+	}
+
+	return utils.SuccessResponse(c, "Scan the QR code and confirm with a code from your authenticator app", dto.TwoFactorSetupResponse{
+		Secret:        secret,
+		OtpauthURI:    utils.TOTPURI(config.AppName, user.Email, secret),
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+func (s *twoFactorService) Confirm(c *fiber.Ctx) error {
+	var payload dto.TwoFactorCodeRequest
+
+	errs := utils.ValidateJSON(c, &payload, govalidator.MapData{
+		"code": []string{"required"},
+	})
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	user, err := s.currentUser(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+	}
+
+	if user.HasTwoFactor() {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Two-factor authentication is already enabled")
+	}
+
+	if user.TwoFactorSecret == nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Two-factor authentication has not been set up")
+	}
+
+	if !s.useTOTP(user, payload.Code) {
+		return utils.ValidationError(c, map[string][]string{
+			"code": {"The provided two-factor authentication code was invalid"},
+		})
+	}
+
+	if err := s.userRepo.UpdateFields(user.ID, map[string]interface{}{"two_factor_confirmed_at": time.Now()}); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to confirm two-factor authentication")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Two-factor authentication enabled successfully")
+}
+
+func (s *twoFactorService) Disable(c *fiber.Ctx) error {
+	var payload dto.DisableTwoFactorRequest
+
+	errs := utils.ValidateJSON(c, &payload, govalidator.MapData{
+		"password": []string{"required"},
+	})
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	user, err := s.currentUser(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Your current password is incorrect")
+	}
+
+	err = s.userRepo.UpdateFields(user.ID, map[string]interface{}{
+		"two_factor_secret":         nil,
+		"two_factor_recovery_codes": nil,
+		"two_factor_confirmed_at":   nil,
+	})
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to disable two-factor authentication")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Two-factor authentication disabled successfully")
+}
+
+func (s *twoFactorService) RecoveryCodes(c *fiber.Ctx) error {
+	user, err := s.currentUser(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+	}
+
+	if user.TwoFactorRecoveryCodes == nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Two-factor authentication has not been set up")
+	}
+
+	recoveryCodes, err := decryptRecoveryCodes(*user.TwoFactorRecoveryCodes)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to read the recovery codes")
+	}
+
+	return utils.SuccessResponse(c, "Recovery codes retrieved successfully", dto.TwoFactorRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	user, err := s.currentUser(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: User not found")
+	}
+
+	if user.TwoFactorSecret == nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Two-factor authentication has not been set up")
+	}
+
+	recoveryCodes := generateRecoveryCodes()
+
+	encryptedCodes, err := encryptRecoveryCodes(recoveryCodes)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to regenerate the recovery codes")
+	}
+
+	if err := s.userRepo.UpdateFields(user.ID, map[string]interface{}{"two_factor_recovery_codes": encryptedCodes}); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to regenerate the recovery codes")
+	}
+
+	return utils.SuccessResponse(c, "Recovery codes regenerated successfully", dto.TwoFactorRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// Challenge completes a two-step login with a TOTP code or a recovery code. Each challenge, TOTP code and recovery
+// code works once, so a captured challenge token and code cannot open another session.
+func (s *twoFactorService) Challenge(c *fiber.Ctx) error {
+	var payload dto.TwoFactorChallengeRequest
+
+	errs := utils.ValidateJSON(c, &payload, govalidator.MapData{
+		"challenge_token": []string{"required"},
+	})
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if payload.Code == "" && payload.RecoveryCode == "" {
+		return utils.ValidationError(c, map[string][]string{
+			"code": {"A two-factor authentication code or a recovery code is required"},
+		})
+	}
+
+	challenge, ok := parseTwoFactorChallenge(payload.ChallengeToken)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: The login challenge is invalid or has expired")
+	}
+
+	userID, _ := strconv.ParseUint(challenge.Get("user_id"), 10, 64)
+
+	user, err := s.userRepo.FindByID(uint(userID))
+	if err != nil || !user.HasTwoFactor() {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: The login challenge is invalid or has expired")
+	}
+
+	email := strings.ToLower(user.Email)
+
+	if remaining := s.guard.Locked(email); remaining > 0 {
+		s.guard.Failed(c, email, &user.ID, models.LoginAttemptReasonLocked)
+		return utils.ErrorResponse(c, fiber.StatusTooManyRequests, lockedMessage(remaining))
+	}
+
+	// The code is only checked here, the challenge is consumed before the code is used up, so a stale challenge
+	// never costs a recovery code.
+	var step int64
+	var recoveryCodes string
+
+	if payload.Code != "" {
+		if step, ok = matchTOTP(user, payload.Code); !ok {
+			s.guard.Failed(c, email, &user.ID, models.LoginAttemptReasonTwoFactorFailed)
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "The provided two-factor authentication code was invalid")
+		}
+	} else if recoveryCodes, ok = matchRecoveryCode(user, payload.RecoveryCode); !ok {
+		s.guard.Failed(c, email, &user.ID, models.LoginAttemptReasonTwoFactorFailed)
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "The provided two-factor recovery code was invalid")
+	}
+
+	if !s.consumeChallenge(user, challenge) {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Unauthorized: The login challenge is invalid or has expired")
+	}
+
+	if payload.Code != "" {
+		if !s.useTOTPStep(user, step) {
+			s.guard.Failed(c, email, &user.ID, models.LoginAttemptReasonTwoFactorFailed)
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "The provided two-factor authentication code was invalid")
+		}
+	} else if !s.useRecoveryCode(user, recoveryCodes) {
+		s.guard.Failed(c, email, &user.ID, models.LoginAttemptReasonTwoFactorFailed)
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "The provided two-factor recovery code was invalid")
+	}
+
+	s.guard.Succeeded(c, email, &user.ID, "")
+
+	var abilities []string
+	if challenge.Get("abilities") != "" {
+		abilities = strings.Split(challenge.Get("abilities"), ",")
+	}
+
+	session, err := s.authService.StartSession(user, challenge.Get("token_name"), abilities)
+	if err != nil && err.Error() == "account_deactivated" {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, "Your account has been deactivated")
+	}
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create token")
+	}
+
+	return utils.SuccessResponse(c, "Login successful", session)
+}
+
+func (s *twoFactorService) currentUser(c *fiber.Ctx) (*models.User, error) {
+	return s.userRepo.FindByID(c.Locals("user_id").(uint))
+}
+
+// consumeChallenge records the challenge as completed and reports whether it was not completed before.
+func (s *twoFactorService) consumeChallenge(user *models.User, challenge url.Values) bool {
+	expires, _ := strconv.ParseInt(challenge.Get("expires"), 10, 64)
+
+	consumed, err := s.challengeRepo.Consume(&models.TwoFactorChallenge{
+		ID:        challenge.Get("id"),
+		UserID:    user.ID,
+		ExpiresAt: time.Unix(expires, 0),
+	})
+	if err != nil {
+		log.Println("Failed to record two-factor challenge: ", err)
+		return false
+	}
+
+	if err := s.challengeRepo.DeleteExpired(time.Now()); err != nil {
+		log.Println("Failed to delete expired two-factor challenges: ", err)
+	}
+
+	return consumed
+}
+
+// useTOTP accepts a valid code only when its time step is later than the one of the last accepted code, so a code
+// cannot be replayed while it is still valid.
+func (s *twoFactorService) useTOTP(user *models.User, code string) bool {
+	step, ok := matchTOTP(user, code)
+	if !ok {
+		return false
+	}
+
+	return s.useTOTPStep(user, step)
+}
+
+func (s *twoFactorService) useTOTPStep(user *models.User, step int64) bool {
+	used, err := s.userRepo.UseTwoFactorStep(user.ID, step)
+	if err != nil {
+		log.Println("Failed to record two-factor code: ", err)
+		return false
+	}
+
+	return used
+}
+
+// useRecoveryCode stores the remaining recovery codes returned by matchRecoveryCode. It only succeeds when the codes
+// were not changed since they were read, so two logins racing with the same code cannot both use it.
+func (s *twoFactorService) useRecoveryCode(user *models.User, remaining string) bool {
+	used, err := s.userRepo.ReplaceRecoveryCodes(user.ID, *user.TwoFactorRecoveryCodes, remaining)
+	if err != nil {
+		log.Println("Failed to record two-factor recovery code: ", err)
+		return false
+	}
+
+	return used
+}
+
+// matchRecoveryCode returns the encrypted recovery codes without the code when it matches one of them.
+func matchRecoveryCode(user *models.User, code string) (string, bool) {
+	if user.TwoFactorRecoveryCodes == nil {
+		return "", false
+	}
+
+	recoveryCodes, err := decryptRecoveryCodes(*user.TwoFactorRecoveryCodes)
+	if err != nil {
+		return "", false
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	for i, recoveryCode := range recoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(strings.ToLower(recoveryCode)), []byte(code)) != 1 {
+			continue
+		}
+
+		encryptedCodes, err := encryptRecoveryCodes(append(recoveryCodes[:i:i], recoveryCodes[i+1:]...))
+		if err != nil {
+			return "", false
+		}
+
+		return encryptedCodes, true
+	}
+
+	return "", false
+}
+
+// newTwoFactorChallenge signs what is needed to finish the login. Only completed challenges are stored, by their
+// random id, to refuse the token afterwards.
+func newTwoFactorChallenge(user *models.User, tokenName string, abilities []string) (dto.TwoFactorChallengeResponse, error) {
+	expiresAt := time.Now().Add(twoFactorChallengeLifetime)
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return dto.TwoFactorChallengeResponse{}, err
+	}
+
+	values, err := utils.SignValues(url.Values{
+		"id":         {hex.EncodeToString(id)},
+		"purpose":    {twoFactorChallengePurpose},
+		"user_id":    {strconv.FormatUint(uint64(user.ID), 10)},
+		"token_name": {tokenName},
+		"abilities":  {strings.Join(abilities, ",")},
+	}, expiresAt)
+	if err != nil {
+		return dto.TwoFactorChallengeResponse{}, err
+	}
+
+	return dto.TwoFactorChallengeResponse{
+		TwoFactor:      true,
+		ChallengeToken: base64.RawURLEncoding.EncodeToString([]byte(values.Encode())),
+		ExpiresAt:      expiresAt,
+	}, nil
+}
+
+func parseTwoFactorChallenge(token string) (url.Values, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, false
+	}
+
+	values, err := url.ParseQuery(string(decoded))
+	if err != nil || !utils.ValidSignature(values) || values.Get("purpose") != twoFactorChallengePurpose || values.Get("id") == "" {
+		return nil, false
+	}
+
+	return values, true
+}
+
+func matchTOTP(user *models.User, code string) (int64, bool) {
+	if user.TwoFactorSecret == nil {
+		return 0, false
+	}
+
+	secret, err := utils.Decrypt(*user.TwoFactorSecret)
+	if err != nil {
+		return 0, false
+	}
+
+	return utils.MatchTOTP(secret, code, time.Now())
+}
+
+func generateRecoveryCodes() []string {
+	recoveryCodes := make([]string, recoveryCodeCount)
+
+	for i := range recoveryCodes {
+		random := make([]byte, 5)
+		rand.Read(random)
+		code := hex.EncodeToString(random)
+		recoveryCodes[i] = code[:5] + "-" + code[5:]
+	}
+
+	return recoveryCodes
+}
+
+func encryptRecoveryCodes(recoveryCodes []string) (string, error) {
+	encoded, err := json.Marshal(recoveryCodes)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.Encrypt(string(encoded))
+}
+
+func decryptRecoveryCodes(encrypted string) ([]string, error) {
+	decrypted, err := utils.Decrypt(encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	err = json.Unmarshal([]byte(decrypted), &recoveryCodes)
+
+	return recoveryCodes, err
+}
@@ -4,87 +4,216 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"golang-api/internal/config"
+	"golang-api/internal/dto"
 	"golang-api/internal/models"
 	"golang-api/internal/repositories"
+	"golang-api/internal/tokencache"
+	"log"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type AuthService interface {
-	Login(email, password string) (*models.User, string, error)
-	ChangePassword(user *models.User, currentPassword, newPassword string) (string, error)
-	UpdateProfile(user *models.User, name, email string) error
+	Login(email, password, tokenName string, abilities []string) (*models.User, *dto.LoginResponse, error)
+	StartSession(user *models.User, tokenName string, abilities []string) (*dto.LoginResponse, error)
+	Refresh(refreshToken string) (*dto.LoginResponse, error)
+	ChangePassword(user *models.User, currentPassword, newPassword string, abilities []string) (*dto.LoginResponse, error)
+	UpdateProfile(user *models.User, name, email string, locale, timezone *string) (bool, error)
+	CreateToken(user *models.User, name string, abilities []string, expiresAt *time.Time) (*models.PersonalAccessToken, string, error)
+	RevokeTokens(tokens ...models.PersonalAccessToken) error
 }
 
 type authService struct {
-	UserRepo  *repositories.UserRepository
-	TokenRepo *repositories.PersonalAccessTokenRepository
+	UserRepo         *repositories.UserRepository
+	TokenRepo        *repositories.PersonalAccessTokenRepository
+	RefreshTokenRepo *repositories.RefreshTokenRepository
+	db               *gorm.DB
 }
 
 func NewAuthService(db *gorm.DB) AuthService {
 	return &authService{
-		UserRepo:  repositories.NewUserRepository(db),
-		TokenRepo: repositories.NewPersonalAccessTokenRepository(db),
+		UserRepo:         repositories.NewUserRepository(db),
+		TokenRepo:        repositories.NewPersonalAccessTokenRepository(db),
+		RefreshTokenRepo: repositories.NewRefreshTokenRepository(db),
+		db:               db,
 	}
 }
 
-func (s *authService) Login(email, password string) (*models.User, string, error) {
+// Login checks the credentials and starts a session. Users with two-factor authentication get the
+// "two_factor_required" error instead, the session is started once the challenge is passed.
+// The user is returned with the "invalid_credentials" error when only the password was wrong
+// and with the "account_deactivated" error when the account was deactivated by an admin.
+func (s *authService) Login(email, password, tokenName string, abilities []string) (*models.User, *dto.LoginResponse, error) {
 	user, err := s.UserRepo.FindByEmail(email)
 	if err != nil {
-		return nil, "", errors.New("invalid_credentials")
+		return nil, nil, errors.New("invalid_credentials")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, "", errors.New("invalid_credentials")
+		return user, nil, errors.New("invalid_credentials")
+	}
+
+	if !user.IsActive() {
+		return user, nil, errors.New("account_deactivated")
+	}
+
+	if user.HasTwoFactor() {
+		return user, nil, errors.New("two_factor_required")
+	}
+
+	session, err := s.StartSession(user, tokenName, abilities)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, session, nil
+}
+
+// StartSession issues an access and refresh token pair for a user whose credentials were already checked.
+// Users who have not verified their email address only get the unverified abilities, whatever abilities were requested.
+func (s *authService) StartSession(user *models.User, tokenName string, abilities []string) (*dto.LoginResponse, error) {
+	if !user.IsActive() {
+		return nil, errors.New("account_deactivated")
+	}
+
+	if !user.IsVerified() {
+		abilities = models.UnverifiedAbilities
+	}
+
+	return s.startSession(user.ID, tokenName, abilities)
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token pair.
+// A refresh token can only be used once, presenting it again revokes every token of its family.
+func (s *authService) Refresh(refreshToken string) (*dto.LoginResponse, error) {
+	id, hashedToken, err := splitToken(refreshToken)
+	if err != nil {
+		return nil, errors.New("invalid_refresh_token")
+	}
+
+	var session *dto.LoginResponse
+	var revokedTokenIDs []uint
+	reused := false
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		current, err := s.RefreshTokenRepo.FindForUpdate(tx, id, hashedToken)
+		if err != nil {
+			return errors.New("invalid_refresh_token")
+		}
+
+		if current.RevokedAt != nil {
+			return errors.New("invalid_refresh_token")
+		}
+
+		if current.RotatedAt != nil {
+			reused = true
+			revokedTokenIDs, err = s.RefreshTokenRepo.RevokeFamily(tx, current.Family)
+			return err
+		}
+
+		if current.ExpiresAt.Before(time.Now()) {
+			return errors.New("refresh_token_expired")
+		}
+
+		user, err := s.UserRepo.FindByID(current.UserID)
+		if err != nil || !user.IsActive() {
+			return errors.New("account_deactivated")
+		}
+
+		if err := s.RefreshTokenRepo.MarkRotated(tx, current.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.PersonalAccessToken{}, current.PersonalAccessTokenID).Error; err != nil {
+			return err
+		}
+		revokedTokenIDs = append(revokedTokenIDs, current.PersonalAccessTokenID)
+
+		var abilities []string
+		json.Unmarshal([]byte(current.Abilities), &abilities)
+
+		// The abilities of a session started before the email address changed shrink until the new one is verified.
+		if !user.IsVerified() {
+			abilities = models.UnverifiedAbilitiesOf(abilities)
+		}
+
+		session, err = s.issueSession(tx, current.UserID, current.Name, abilities, current.Family)
+		return err
+	})
+
+	// Only after the commit, so no request can cache a revoked token again before it is gone from the database.
+	tokencache.Tokens.Forget(revokedTokenIDs...)
+
+	if reused {
+		if err != nil {
+			log.Println("Failed to revoke refresh token family: ", err)
+		}
+		return nil, errors.New("refresh_token_reused")
 	}
 
-	token, err := s.generateAuthToken(user, 7)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	return user, token, nil
+	return session, nil
 }
 
-func (s *authService) ChangePassword(user *models.User, currentPassword, newPassword string) (string, error) {
+// ChangePassword revokes every token of the user and starts a new session with the abilities of the token that made the request.
+func (s *authService) ChangePassword(user *models.User, currentPassword, newPassword string, abilities []string) (*dto.LoginResponse, error) {
 	err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword))
 	if err != nil {
-		return "", errors.New("current_password_incorrect")
+		return nil, errors.New("current_password_incorrect")
 	}
 
-	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), 12)
+	hashedPassword, err := HashPassword(newPassword)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	hashedPassword := strings.Replace(string(hashed), "$2a$", "$2y$", 1)
-	s.UserRepo.UpdatePassword(user.ID, hashedPassword)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.UserRepo.UpdatePassword(tx, user.ID, hashedPassword); err != nil {
+			return err
+		}
+
+		if err := s.TokenRepo.DeleteByUserID(tx, user.ID); err != nil {
+			return err
+		}
 
-	s.TokenRepo.DeleteByUserID(user.ID)
-	newToken, err := s.generateAuthToken(user, 7)
+		return s.RefreshTokenRepo.RevokeByUserID(tx, user.ID)
+	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return newToken, nil
+	// Only after the commit, so no request can cache a revoked token again before it is gone from the database.
+	tokencache.Tokens.ForgetUser(user.ID)
+
+	return s.startSession(user.ID, "auth_token", abilities)
 }
 
-func (s *authService) UpdateProfile(user *models.User, name, email string) error {
+// UpdateProfile reports whether the email address changed. A new address is unverified, so the tokens of the user are
+// narrowed down to the unverified abilities until it is verified and the user logs in again.
+func (s *authService) UpdateProfile(user *models.User, name, email string, locale, timezone *string) (bool, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
 	if email == "" {
 		email = user.Email
 	}
 
-	if user.Email != email {
-		if _, err := s.UserRepo.FindByEmail(email); err == nil {
-			return errors.New("email_already_used")
-		}
+	emailChanged := !strings.EqualFold(user.Email, email)
+
+	if emailChanged && s.UserRepo.EmailTaken(email, user.ID) {
+		return false, errors.New("email_already_used")
 	}
 
 	updateFields := map[string]interface{}{
@@ -92,40 +221,180 @@ func (s *authService) UpdateProfile(user *models.User, name, email string) error
 		"email": email,
 	}
 
-	if err := s.UserRepo.UpdateFields(user.ID, updateFields); err != nil {
+	if emailChanged {
+		updateFields["email_verified_at"] = nil
+	}
+
+	// An empty locale or timezone clears the preference, falling back to Accept-Language and the default timezone.
+	if locale != nil {
+		updateFields["locale"] = nullableString(*locale)
+	}
+
+	if timezone != nil {
+		updateFields["timezone"] = nullableString(*timezone)
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(updateFields).Error; err != nil {
+			return err
+		}
+
+		if !emailChanged {
+			return nil
+		}
+
+		return s.TokenRepo.RestrictToUnverified(tx, user.ID)
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	// Only after the commit, so no request can cache the old name, email or abilities again.
+	tokencache.Tokens.ForgetUser(user.ID)
+
+	return emailChanged, nil
+}
+
+// CreateToken issues a Sanctum compatible token and returns it with its "id|plaintext" form.
+// An empty ability list issues a token with every ability and a nil expiresAt a token that never expires.
+func (s *authService) CreateToken(user *models.User, name string, abilities []string, expiresAt *time.Time) (*models.PersonalAccessToken, string, error) {
+	return createAccessToken(s.db, user.ID, name, abilities, expiresAt)
+}
+
+// RevokeTokens deletes the access tokens together with the refresh tokens issued with them.
+func (s *authService) RevokeTokens(tokens ...models.PersonalAccessToken) error {
+	ids := make([]uint, 0, len(tokens))
+
+	for i := range tokens {
+		if err := s.TokenRepo.Delete(&tokens[i]); err != nil {
+			return err
+		}
+		ids = append(ids, tokens[i].ID)
+	}
+
+	return s.RefreshTokenRepo.RevokeByAccessTokenIDs(ids...)
+}
+
+func (s *authService) startSession(userID uint, name string, abilities []string) (*dto.LoginResponse, error) {
+	var session *dto.LoginResponse
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		session, err = s.issueSession(tx, userID, name, abilities, uuid.NewString())
 		return err
+	})
+
+	return session, err
+}
+
+// issueSession creates a short lived access token and the refresh token that renews it.
+func (s *authService) issueSession(tx *gorm.DB, userID uint, name string, abilities []string, family string) (*dto.LoginResponse, error) {
+	expiresAt := time.Now().Add(config.AccessTokenTTL)
+
+	accessToken, plainAccessToken, err := createAccessToken(tx, userID, name, abilities, &expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	plainRefreshToken, hashedRefreshToken := generateToken()
+	refreshToken := models.RefreshToken{
+		UserID:                userID,
+		PersonalAccessTokenID: accessToken.ID,
+		Family:                family,
+		Token:                 hashedRefreshToken,
+		Name:                  accessToken.Name,
+		Abilities:             accessToken.Abilities,
+		ExpiresAt:             time.Now().Add(config.RefreshTokenTTL),
 	}
 
-	return nil
+	if err := s.RefreshTokenRepo.Create(tx, &refreshToken); err != nil {
+		return nil, errors.New("token_creation_failed")
+	}
+
+	return &dto.LoginResponse{
+		Token:                 plainAccessToken,
+		ExpiresAt:             expiresAt,
+		RefreshToken:          fmt.Sprintf("%d|%s", refreshToken.ID, plainRefreshToken),
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+	}, nil
 }
 
-func (s *authService) generateAuthToken(user *models.User, expireDays int) (string, error) {
-	length := 40
-	bytes := make([]byte, length)
-	rand.Read(bytes)
+func createAccessToken(db *gorm.DB, userID uint, name string, abilities []string, expiresAt *time.Time) (*models.PersonalAccessToken, string, error) {
+	plainToken, hashedToken := generateToken()
 
-	plainToken := hex.EncodeToString(bytes)[:length]
-	hash := sha256.Sum256([]byte(plainToken))
-	hashedToken := hex.EncodeToString(hash[:])
+	if len(abilities) == 0 {
+		abilities = []string{models.AbilityAll}
+	}
 
-	expiration := time.Now().AddDate(0, 0, expireDays)
+	encodedAbilities, err := json.Marshal(abilities)
+	if err != nil {
+		return nil, "", errors.New("token_creation_failed")
+	}
 
 	token := models.PersonalAccessToken{
 		TokenableType: "App\\Models\\User",
-		TokenableID:   user.ID,
-		Name:          "auth_token",
+		TokenableID:   userID,
+		Name:          name,
 		Token:         hashedToken,
-		Abilities:     "[\"*\"]",
-		ExpiresAt:     &expiration,
+		Abilities:     string(encodedAbilities),
+		ExpiresAt:     expiresAt,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
 
-	if err := s.TokenRepo.Create(&token); err != nil {
-		return "", errors.New("token_creation_failed")
+	if err := repositories.NewPersonalAccessTokenRepository(db).Create(&token); err != nil {
+		return nil, "", errors.New("token_creation_failed")
 	}
 
 	fullToken := fmt.Sprintf("%d|%s", token.ID, plainToken)
 
-	return fullToken, nil
+	return &token, fullToken, nil
+}
+
+// HashPassword hashes with bcrypt using the "$2y$" prefix, so the main Laravel app accepts the hash.
+func HashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Replace(string(hashed), "$2a$", "$2y$", 1), nil
+}
+
+// generateToken returns a random 40 character token and its sha256 hash, the same shape Sanctum uses.
+func generateToken() (string, string) {
+	length := 40
+	bytes := make([]byte, length)
+	rand.Read(bytes)
+
+	plainToken := hex.EncodeToString(bytes)[:length]
+	hash := sha256.Sum256([]byte(plainToken))
+
+	return plainToken, hex.EncodeToString(hash[:])
+}
+
+// splitToken parses an "id|plaintext" token into its id and the sha256 hash of the plaintext.
+func splitToken(token string) (uint64, string, error) {
+	parts := strings.SplitN(token, "|", 2)
+	if len(parts) != 2 {
+		return 0, "", errors.New("invalid_token_format")
+	}
+
+	id, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", errors.New("invalid_token_format")
+	}
+
+	hash := sha256.Sum256([]byte(parts[1]))
+
+	return id, hex.EncodeToString(hash[:]), nil
+}
+
+// nullableString returns nil for an empty string, so it is stored as NULL.
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
 }
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"errors"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"io/fs"
+	"log"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GeneratedFilesDirectory is where files produced by this service (e.g. payment reports) are stored. It is outside of
+// the public directory, the files are only served through the authenticated download route.
+// Only files below it are removed by the cleanup, the rest of the files table belongs to the main app.
+const GeneratedFilesDirectory = "storage/files/"
+
+type FileCleanupService struct {
+	fileRepo *repositories.FileRepository
+}
+
+func NewFileCleanupService(db *gorm.DB) *FileCleanupService {
+	return &FileCleanupService{
+		fileRepo: repositories.NewFileRepository(db),
+	}
+}
+
+// DeleteExpired removes generated files whose scheduled deletion time has passed and returns how many were removed.
+func (s *FileCleanupService) DeleteExpired() int {
+	deleted := 0
+
+	err := s.fileRepo.ProcessExpired(GeneratedFilesDirectory, time.Now(), 100, func(files []models.File) error {
+		for _, file := range files {
+			if err := os.Remove(file.FilePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.Printf("Failed to delete file %s: %v\n", file.FilePath, err)
+				continue
+			}
+
+			if err := s.fileRepo.MarkDeleted(file.ID); err != nil {
+				log.Printf("Failed to mark file %s as deleted: %v\n", file.Code, err)
+				continue
+			}
+
+			deleted++
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Println("Failed to process expired files: ", err)
+	}
+
+	return deleted
+}
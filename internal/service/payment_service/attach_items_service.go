@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"golang-api/internal/dto"
 	"golang-api/internal/models"
+	"golang-api/internal/policies"
 	"golang-api/internal/repositories"
 	"strconv"
 	"strings"
@@ -25,21 +26,30 @@ type attachItemsService struct {
 	paymentAccount *repositories.PaymentAccountRepository
 	item           *repositories.ItemRepository
 	generate       *repositories.GenerateRepository
+	reconciliation *repositories.PaymentReconciliationRepository
+	budget         *budgetService
 	db             *gorm.DB
 }
 
 func NewAttachItemsService(db *gorm.DB) AttachItemsService {
+	return newAttachItemsService(db)
+}
+
+func newAttachItemsService(db *gorm.DB) *attachItemsService {
 	return &attachItemsService{
 		payment:        repositories.NewPaymentRepository(db),
 		paymentItem:    repositories.NewPaymentItemRepository(db),
 		paymentAccount: repositories.NewPaymentAccountRepository(db),
 		item:           repositories.NewItemRepository(db),
 		generate:       repositories.NewGenerateRepository(db),
+		reconciliation: repositories.NewPaymentReconciliationRepository(db),
+		budget:         newBudgetService(db),
 		db:             db,
 	}
 }
 
 func (s *attachItemsService) AttachMultipleItems(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
 	userName := c.Locals("user_name").(string)
 	paymentID, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
@@ -47,7 +57,7 @@ func (s *attachItemsService) AttachMultipleItems(c *fiber.Ctx) error {
 	}
 
 	payment, err := s.payment.FindByID(paymentID)
-	if err != nil {
+	if err != nil || !policies.PaymentItem.Attach(userId, payment) {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
 	}
 
@@ -102,6 +112,12 @@ func (s *attachItemsService) AttachMultipleItems(c *fiber.Ctx) error {
 			return errors.New("Failed to update payment")
 		}
 
+		if payment.IsReconciled() {
+			if txErr = s.reconciliation.Flag(tx, *payment.ReconciliationID); txErr != nil {
+				return errors.New("Failed to update payment")
+			}
+		}
+
 		return s.updateDeposit(tx, payment, oldAmount, newAmount, userName)
 	})
 
@@ -109,6 +125,8 @@ func (s *attachItemsService) AttachMultipleItems(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
 	}
 
+	go s.budget.Alert(payment)
+
 	return utils.SimpleSuccessResponse(c, "Items attached successfully")
 }
 
@@ -180,8 +198,14 @@ func (s *attachItemsService) resolveAndCreateItems(tx *gorm.DB, paymentID uint,
 	return paymentItems, itemNotes, nil
 }
 
+// updateDeposit moves the balance by the attached items total. Draft and scheduled payments have no effect on the
+// balance until publish applies their whole amount, so their balance is left alone.
 func (s *attachItemsService) updateDeposit(tx *gorm.DB, payment *models.Payment, oldAmount int64, newAmount int64, userName string) error {
-	paymentAccount, err := s.paymentAccount.SelectByID(tx, payment.PaymentAccountID, []string{"id", "user_id", "name", "deposit"})
+	if !isBooked(payment) {
+		return nil
+	}
+
+	paymentAccount, err := s.paymentAccount.SelectForUpdate(tx, payment.PaymentAccountID, []string{"id", "user_id", "name", "deposit"})
 	if err != nil {
 		return errors.New("Payment account not found")
 	}
@@ -190,10 +214,10 @@ func (s *attachItemsService) updateDeposit(tx *gorm.DB, payment *models.Payment,
 	deposit += oldAmount
 	deposit -= newAmount
 
-	_, err = s.paymentAccount.Update(tx, payment.UserID, userName, &models.PaymentAccount{
+	_, err = s.paymentAccount.UpdateForPayment(tx, payment.UserID, userName, &models.PaymentAccount{
 		ID:      payment.PaymentAccountID,
 		Deposit: deposit,
-	}, paymentAccount)
+	}, paymentAccount, &payment.ID)
 
 	if err != nil {
 		return errors.New("Failed to update payment account balance")
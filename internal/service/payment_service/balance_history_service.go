@@ -0,0 +1,344 @@
+package payment_service
+
+import (
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// balanceHistoryMaxPoints bounds the length of a balance history time series.
+const balanceHistoryMaxPoints = 1000
+
+type BalanceHistoryService interface {
+	History(c *fiber.Ctx) error
+	BalanceAt(c *fiber.Ctx) error
+	Backfill(paymentAccountID uint, force bool) (map[string]int, error)
+}
+
+type balanceHistoryService struct {
+	paymentAccount *repositories.PaymentAccountRepository
+	balance        *repositories.PaymentAccountBalanceRepository
+	payment        *repositories.PaymentRepository
+	db             *gorm.DB
+}
+
+func NewBalanceHistoryService(db *gorm.DB) BalanceHistoryService {
+	return &balanceHistoryService{
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		balance:        repositories.NewPaymentAccountBalanceRepository(db),
+		payment:        repositories.NewPaymentRepository(db),
+		db:             db,
+	}
+}
+
+// History returns the closing balance of every day, week or month between from and to, both inclusive.
+func (s *balanceHistoryService) History(c *fiber.Ctx) error {
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	today := time.Now().Format("2006-01-02")
+	from, fromErr := time.ParseInLocation("2006-01-02", c.Query("from", time.Now().AddDate(0, 0, -30).Format("2006-01-02")), time.Local)
+	to, toErr := time.ParseInLocation("2006-01-02", c.Query("to", today), time.Local)
+	interval := c.Query("interval", "day")
+
+	errs := map[string][]string{}
+	if fromErr != nil {
+		errs["from"] = []string{"The from field must be a date in the yyyy-mm-dd format"}
+	}
+	if toErr != nil {
+		errs["to"] = []string{"The to field must be a date in the yyyy-mm-dd format"}
+	}
+	if fromErr == nil && toErr == nil && to.Before(from) {
+		errs["to"] = []string{"The to field must be a date after or equal to from"}
+	}
+	if interval != "day" && interval != "week" && interval != "month" {
+		errs["interval"] = []string{"The interval field must be one of day, week, month"}
+	}
+	if len(errs) > 0 {
+		return utils.ValidationError(c, errs)
+	}
+
+	periods := balancePeriods(from, to.AddDate(0, 0, 1), interval)
+	if len(periods) > balanceHistoryMaxPoints {
+		return utils.ValidationError(c, map[string][]string{
+			"interval": {"The range is too long for this interval, use a wider interval or a shorter range"},
+		})
+	}
+
+	opening := s.balanceBefore(paymentAccount, from)
+
+	entries, err := s.balance.FindBetween(paymentAccount.ID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve balance history")
+	}
+
+	points := make([]dto.BalanceHistoryPoint, 0, len(periods))
+	balance := opening
+	next := 0
+
+	for _, period := range periods {
+		change := int64(0)
+
+		for next < len(entries) && entries[next].RecordedAt.Before(period.end) {
+			change += entries[next].Amount
+			balance = entries[next].Balance
+			next++
+		}
+
+		points = append(points, dto.BalanceHistoryPoint{
+			Date:    period.start.Format("2006-01-02"),
+			Balance: balance,
+			Change:  change,
+		})
+	}
+
+	return utils.SuccessResponse(c, "Balance history retrieved successfully", dto.BalanceHistoryResponse{
+		PaymentAccountID: paymentAccount.ID,
+		Currency:         currencyOf(paymentAccount),
+		From:             from.Format("2006-01-02"),
+		To:               to.Format("2006-01-02"),
+		Interval:         interval,
+		OpeningBalance:   opening,
+		ClosingBalance:   balance,
+		Points:           points,
+	})
+}
+
+// BalanceAt returns the balance at the end of the given day.
+func (s *balanceHistoryService) BalanceAt(c *fiber.Ctx) error {
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", c.Query("date", time.Now().Format("2006-01-02")), time.Local)
+	if err != nil {
+		return utils.ValidationError(c, map[string][]string{
+			"date": {"The date field must be a date in the yyyy-mm-dd format"},
+		})
+	}
+
+	balance := s.balanceBefore(paymentAccount, date.AddDate(0, 0, 1))
+
+	return utils.SuccessResponse(c, "Balance retrieved successfully", dto.BalanceAtResponse{
+		PaymentAccountID: paymentAccount.ID,
+		Currency:         currencyOf(paymentAccount),
+		Date:             date.Format("2006-01-02"),
+		Balance:          balance,
+	})
+}
+
+// Backfill reconstructs the balance ledger from the booked payments, working back from the current deposit.
+// Accounts that already have a ledger are skipped unless force is set, a zero paymentAccountID backfills every account.
+// Balance changes made outside payments cannot be told apart and end up in the opening balance.
+func (s *balanceHistoryService) Backfill(paymentAccountID uint, force bool) (map[string]int, error) {
+	results := map[string]int{"total": 0, "backfilled": 0, "skipped": 0, "failed": 0}
+
+	backfill := func(paymentAccount *models.PaymentAccount) {
+		results["total"]++
+
+		exists, err := s.balance.Exists(paymentAccount.ID)
+		if err != nil {
+			log.Printf("Failed to check balance history of payment account %d: %v\n", paymentAccount.ID, err)
+			results["failed"]++
+			return
+		}
+
+		if exists && !force {
+			results["skipped"]++
+			return
+		}
+
+		if err := s.backfillAccount(paymentAccount); err != nil {
+			log.Printf("Failed to backfill balance history of payment account %d: %v\n", paymentAccount.ID, err)
+			results["failed"]++
+			return
+		}
+
+		results["backfilled"]++
+	}
+
+	if paymentAccountID != 0 {
+		paymentAccount, err := s.paymentAccount.FindByID(paymentAccountID)
+		if err != nil {
+			return results, err
+		}
+
+		backfill(paymentAccount)
+		return results, nil
+	}
+
+	err := s.paymentAccount.ProcessAll(100, func(paymentAccounts []models.PaymentAccount) error {
+		for i := range paymentAccounts {
+			backfill(&paymentAccounts[i])
+		}
+		return nil
+	})
+
+	return results, err
+}
+
+func (s *balanceHistoryService) backfillAccount(paymentAccount *models.PaymentAccount) error {
+	payments, err := s.payment.FindBookedByAccount(paymentAccount.ID)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(payments, func(i, j int) bool {
+		return bookedAt(&payments[i]).Before(bookedAt(&payments[j]))
+	})
+
+	total := int64(0)
+	for i := range payments {
+		total += balanceEffect(&payments[i], paymentAccount.ID)
+	}
+
+	opening := paymentAccount.Deposit - total
+	openingAt := time.Now()
+	if len(payments) > 0 {
+		openingAt = bookedAt(&payments[0]).Add(-time.Second)
+	}
+
+	entries := []models.PaymentAccountBalance{{
+		UserID:           paymentAccount.UserID,
+		PaymentAccountID: paymentAccount.ID,
+		Source:           models.PaymentAccountBalanceSourceBackfill,
+		Amount:           opening,
+		Balance:          opening,
+		RecordedAt:       openingAt,
+	}}
+
+	balance := opening
+	for i := range payments {
+		effect := balanceEffect(&payments[i], paymentAccount.ID)
+		if effect == 0 {
+			continue
+		}
+
+		balance += effect
+		entries = append(entries, models.PaymentAccountBalance{
+			UserID:           paymentAccount.UserID,
+			PaymentAccountID: paymentAccount.ID,
+			PaymentID:        &payments[i].ID,
+			Source:           models.PaymentAccountBalanceSourceBackfill,
+			Amount:           effect,
+			Balance:          balance,
+			RecordedAt:       bookedAt(&payments[i]),
+		})
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.balance.DeleteByPaymentAccountID(tx, paymentAccount.ID); err != nil {
+			return err
+		}
+		return s.balance.Create(tx, entries...)
+	})
+}
+
+// balanceBefore returns the balance of the account right before the given time. Before the first ledger entry it is
+// the balance the ledger started from, and without any ledger entry the current deposit.
+func (s *balanceHistoryService) balanceBefore(paymentAccount *models.PaymentAccount, before time.Time) int64 {
+	if entry, err := s.balance.LatestBefore(paymentAccount.ID, before); err == nil {
+		return entry.Balance
+	}
+
+	if first, err := s.balance.First(paymentAccount.ID); err == nil {
+		return first.Balance - first.Amount
+	}
+
+	return paymentAccount.Deposit
+}
+
+func (s *balanceHistoryService) findPaymentAccount(c *fiber.Ctx) (*models.PaymentAccount, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentAccount, err := s.paymentAccount.FindByID(uint(id))
+	if err != nil || !policies.PaymentAccount.View(c.Locals("user_id").(uint), paymentAccount) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return paymentAccount, nil
+}
+
+type balancePeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// balancePeriods splits [from, to) into days, weeks starting on Monday or calendar months. The first period starts at from.
+func balancePeriods(from, to time.Time, interval string) []balancePeriod {
+	var periods []balancePeriod
+
+	for start := from; start.Before(to) && len(periods) <= balanceHistoryMaxPoints; {
+		var end time.Time
+
+		switch interval {
+		case "week":
+			offset := (int(start.Weekday()) + 6) % 7
+			end = time.Date(start.Year(), start.Month(), start.Day()-offset+7, 0, 0, 0, 0, start.Location())
+		case "month":
+			end = time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, start.Location())
+		default:
+			end = start.AddDate(0, 0, 1)
+		}
+
+		if end.After(to) {
+			end = to
+		}
+
+		periods = append(periods, balancePeriod{start: start, end: end})
+		start = end
+	}
+
+	return periods
+}
+
+// balanceEffect returns how much a booked payment changed the balance of the account.
+func balanceEffect(payment *models.Payment, paymentAccountID uint) int64 {
+	amount := paymentAmount(payment)
+	effect := int64(0)
+
+	switch payment.TypeID {
+	case models.PaymentTypeExpense:
+		if payment.PaymentAccountID == paymentAccountID {
+			effect -= amount
+		}
+	case models.PaymentTypeIncome:
+		if payment.PaymentAccountID == paymentAccountID {
+			effect += amount
+		}
+	case models.PaymentTypeTransfer, models.PaymentTypeWithdrawal:
+		if payment.PaymentAccountID == paymentAccountID {
+			effect -= amount
+		}
+		if payment.PaymentAccountToID != nil && *payment.PaymentAccountToID == paymentAccountID {
+			effect += payment.DestinationAmount()
+		}
+	}
+
+	return effect
+}
+
+// bookedAt estimates when a payment changed the balance: when it was created, or on its date for payments
+// that were scheduled ahead and published later.
+func bookedAt(payment *models.Payment) time.Time {
+	date := time.Date(payment.Date.Year(), payment.Date.Month(), payment.Date.Day(), 0, 0, 0, 0, time.Local)
+	if date.After(payment.CreatedAt) {
+		return date
+	}
+	return payment.CreatedAt
+}
@@ -0,0 +1,197 @@
+package payment_service
+
+import (
+	"errors"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// balanceFields are the payment account columns needed to check and update a balance.
+var balanceFields = []string{"id", "user_id", "name", "type", "deposit", "credit_limit"}
+
+type balanceService struct {
+	paymentAccount *repositories.PaymentAccountRepository
+	ledger         *repositories.PaymentAccountBalanceRepository
+}
+
+func newBalanceService(db *gorm.DB) *balanceService {
+	return &balanceService{
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		ledger:         repositories.NewPaymentAccountBalanceRepository(db),
+	}
+}
+
+// Apply books the payment amount into its payment account(s).
+func (s *balanceService) Apply(tx *gorm.DB, userId uint, userName string, payment *models.Payment) error {
+	return s.updateBalances(tx, userId, userName, payment, false)
+}
+
+// Revert undoes a previously applied payment, restoring the account balance(s).
+func (s *balanceService) Revert(tx *gorm.DB, userId uint, userName string, payment *models.Payment) error {
+	return s.updateBalances(tx, userId, userName, payment, true)
+}
+
+// Release gives back whatever a draft or scheduled payment still moves on the balance ledger, which attaching items
+// to it did before unbooked payments were kept off the balance. It does nothing for booked payments, use Revert.
+func (s *balanceService) Release(tx *gorm.DB, userId uint, userName string, payment *models.Payment) error {
+	if isBooked(payment) {
+		return nil
+	}
+
+	nets, err := s.ledger.NetByPayment(tx, payment.ID)
+	if err != nil {
+		return errors.New("Failed to update payment account, please try again")
+	}
+
+	for _, net := range nets {
+		if net.Amount == 0 {
+			continue
+		}
+
+		paymentAccount, err := s.paymentAccount.SelectForUpdate(tx, net.PaymentAccountID, balanceFields)
+		if err != nil {
+			return errors.New("Payment account not found")
+		}
+
+		_, err = s.paymentAccount.UpdateForPayment(tx, userId, userName, &models.PaymentAccount{
+			ID:      net.PaymentAccountID,
+			Deposit: paymentAccount.Deposit - net.Amount,
+		}, paymentAccount, &payment.ID)
+
+		if err != nil {
+			return errors.New("Failed to update payment account, please try again")
+		}
+	}
+
+	return nil
+}
+
+func (s *balanceService) updateBalances(tx *gorm.DB, userId uint, userName string, payment *models.Payment, reverse bool) error {
+	switch payment.TypeID {
+	case models.PaymentTypeExpense, models.PaymentTypeIncome:
+		return s.handleIncomeOrExpense(tx, userId, userName, payment, reverse)
+	case models.PaymentTypeTransfer, models.PaymentTypeWithdrawal:
+		return s.handleTransferOrWithdrawal(tx, userId, userName, payment, reverse)
+	}
+	return nil
+}
+
+func (s *balanceService) handleIncomeOrExpense(tx *gorm.DB, userId uint, userName string, payment *models.Payment, reverse bool) error {
+	paymentAccount, err := s.paymentAccount.SelectForUpdate(tx, payment.PaymentAccountID, balanceFields)
+
+	if err != nil {
+		return errors.New("Payment account not found")
+	}
+
+	amount := paymentAmount(payment)
+	depositChange := paymentAccount.Deposit
+
+	if payment.TypeID == models.PaymentTypeExpense {
+		if reverse {
+			depositChange += amount
+		} else {
+			if !paymentAccount.CanSpend(amount) {
+				return insufficientBalance(paymentAccount, "e01")
+			}
+			depositChange -= amount
+		}
+	} else {
+		if reverse {
+			depositChange -= amount
+		} else {
+			depositChange += amount
+		}
+	}
+
+	_, err = s.paymentAccount.UpdateForPayment(tx, userId, userName, &models.PaymentAccount{
+		ID:      payment.PaymentAccountID,
+		Deposit: depositChange,
+	}, paymentAccount, &payment.ID)
+
+	if err != nil {
+		return errors.New("Failed to update payment account, please try again")
+	}
+
+	return nil
+}
+
+func (s *balanceService) handleTransferOrWithdrawal(tx *gorm.DB, userId uint, userName string, payment *models.Payment, reverse bool) error {
+	if payment.PaymentAccountToID == nil {
+		return errors.New("Payment account destination not found")
+	}
+
+	// Rows are locked in id order, so two transfers between the same accounts in opposite directions cannot deadlock.
+	if *payment.PaymentAccountToID < payment.PaymentAccountID {
+		if _, err := s.paymentAccount.SelectForUpdate(tx, *payment.PaymentAccountToID, []string{"id"}); err != nil {
+			return errors.New("Payment account destination not found")
+		}
+	}
+
+	paymentAccount, err := s.paymentAccount.SelectForUpdate(tx, payment.PaymentAccountID, balanceFields)
+	if err != nil {
+		return errors.New("Payment account not found")
+	}
+
+	paymentAccountTo, err := s.paymentAccount.SelectForUpdate(tx, *payment.PaymentAccountToID, balanceFields)
+	if err != nil {
+		return errors.New("Payment account destination not found")
+	}
+
+	amount := paymentAmount(payment)
+	amountTo := payment.DestinationAmount()
+	balanceOrigin := paymentAccount.Deposit
+	balanceTo := paymentAccountTo.Deposit
+
+	if reverse {
+		balanceOrigin += amount
+		balanceTo -= amountTo
+	} else {
+		if !paymentAccount.CanSpend(amount) {
+			return insufficientBalance(paymentAccount, "e02")
+		}
+
+		balanceOrigin -= amount
+		balanceTo += amountTo
+	}
+
+	_, err = s.paymentAccount.UpdateForPayment(tx, userId, userName, &models.PaymentAccount{
+		ID:      payment.PaymentAccountID,
+		Deposit: balanceOrigin,
+	}, paymentAccount, &payment.ID)
+
+	if err != nil {
+		return errors.New("Failed to update payment account, please try again")
+	}
+
+	_, err = s.paymentAccount.UpdateForPayment(tx, userId, userName, &models.PaymentAccount{
+		ID:      *payment.PaymentAccountToID,
+		Deposit: balanceTo,
+	}, paymentAccountTo, &payment.ID)
+
+	if err != nil {
+		return errors.New("Failed to update payment account destination, please try again")
+	}
+
+	return nil
+}
+
+func insufficientBalance(paymentAccount *models.PaymentAccount, code string) error {
+	if paymentAccount.IsCredit() {
+		return errors.New("Credit limit exceeded for this payment account (" + code + ")")
+	}
+	return errors.New("Insufficient balance for this payment account (" + code + ")")
+}
+
+// isBooked reports whether the payment has already affected account balances.
+func isBooked(payment *models.Payment) bool {
+	return !payment.IsDraft && !payment.IsScheduled
+}
+
+func paymentAmount(payment *models.Payment) int64 {
+	if payment.Amount == nil {
+		return 0
+	}
+	return *payment.Amount
+}
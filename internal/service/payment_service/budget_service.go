@@ -0,0 +1,300 @@
+package payment_service
+
+import (
+	"fmt"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"math"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type BudgetService interface {
+	Status(c *fiber.Ctx) error
+}
+
+type budgetService struct {
+	budget       *repositories.BudgetRepository
+	category     *repositories.PaymentCategoryRepository
+	exchangeRate *repositories.ExchangeRateRepository
+	user         *repositories.UserRepository
+}
+
+func NewBudgetService(db *gorm.DB) BudgetService {
+	return newBudgetService(db)
+}
+
+func newBudgetService(db *gorm.DB) *budgetService {
+	return &budgetService{
+		budget:       repositories.NewBudgetRepository(db),
+		category:     repositories.NewPaymentCategoryRepository(db),
+		exchangeRate: repositories.NewExchangeRateRepository(db),
+		user:         repositories.NewUserRepository(db),
+	}
+}
+
+// Status returns the spending against every budget of the user in a month, the current one in the user's timezone
+// unless month (YYYY-MM) is given.
+func (s *budgetService) Status(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	location := utils.FormatterFromContext(c).Location
+
+	month := monthStart(time.Now().In(location))
+	if value := c.Query("month"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01", value, location)
+		if err != nil {
+			return utils.ValidationError(c, map[string][]string{
+				"month": {"The month must be in the format YYYY-MM"},
+			})
+		}
+		month = parsed
+	}
+
+	budgets, err := s.budget.FindAllByUser(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve budgets")
+	}
+
+	categories, err := s.category.FindAllByUser(userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve budgets")
+	}
+
+	statuses := make([]dto.BudgetStatusResponse, 0, len(budgets))
+	for i := range budgets {
+		status, err := s.status(&budgets[i], month, categories)
+		if err != nil {
+			return utils.ValidationError(c, map[string][]string{"currency": {err.Error()}})
+		}
+		statuses = append(statuses, status)
+	}
+
+	return utils.SuccessResponse(c, "Budget status retrieved successfully", statuses)
+}
+
+// Alert emails the owner of the budgets covering an expense once the spending of the current month crosses 80% or
+// 100% of a budget. Each threshold is sent at most once per budget and month, and crossing both at once only sends
+// the 100% alert. It runs after the payment is committed, so failures are only logged.
+func (s *budgetService) Alert(payment *models.Payment) {
+	if payment.TypeID != models.PaymentTypeExpense || !isBooked(payment) {
+		return
+	}
+
+	user, err := s.user.FindByID(payment.UserID)
+	if err != nil {
+		log.Printf("Failed to check budgets of user %d: %v", payment.UserID, err)
+		return
+	}
+
+	formatter := userFormatter(user)
+	month := monthStart(time.Now().In(formatter.Location))
+	if payment.Date.Year() != month.Year() || payment.Date.Month() != month.Month() {
+		return
+	}
+
+	categories, err := s.category.FindAllByUser(user.ID)
+	if err != nil {
+		log.Printf("Failed to check budgets of user %d: %v", user.ID, err)
+		return
+	}
+
+	var categoryIDs []uint
+	if payment.CategoryID != nil {
+		categoryIDs = models.CategoryAncestors(categories, *payment.CategoryID)
+	}
+
+	budgets, err := s.budget.FindAffected(user.ID, payment.PaymentAccountID, categoryIDs)
+	if err != nil {
+		log.Printf("Failed to check budgets of user %d: %v", user.ID, err)
+		return
+	}
+
+	for i := range budgets {
+		status, err := s.status(&budgets[i], month, categories)
+		if err != nil {
+			log.Printf("Failed to check budget %d: %v", budgets[i].ID, err)
+			continue
+		}
+
+		threshold, isNew := s.recordAlerts(&budgets[i], status)
+		if isNew {
+			s.sendAlertEmail(user, formatter, &budgets[i], status, threshold)
+		}
+	}
+}
+
+// recordAlerts records every threshold the budget has reached and returns the highest one, with whether it was
+// not recorded before.
+func (s *budgetService) recordAlerts(budget *models.Budget, status dto.BudgetStatusResponse) (int, bool) {
+	reached := 0
+	isNew := false
+
+	for _, threshold := range models.BudgetAlertThresholds {
+		if status.Percent < float64(threshold) {
+			break
+		}
+
+		recorded, err := s.budget.RecordAlert(&models.BudgetAlert{
+			BudgetID:  budget.ID,
+			Period:    status.Period,
+			Threshold: threshold,
+			Spent:     status.Spent,
+		})
+		if err != nil {
+			log.Printf("Failed to record alert of budget %d: %v", budget.ID, err)
+			return 0, false
+		}
+
+		reached = threshold
+		isNew = recorded
+	}
+
+	return reached, isNew
+}
+
+func (s *budgetService) sendAlertEmail(user *models.User, formatter *utils.Formatter, budget *models.Budget, status dto.BudgetStatusResponse, threshold int) {
+	subject := fmt.Sprintf("Budget %s reached %d%%", budget.Name, threshold)
+	if threshold >= 100 {
+		subject = fmt.Sprintf("Budget %s exceeded", budget.Name)
+	}
+
+	month, _ := time.Parse("2006-01", status.Period)
+
+	err := utils.SendEmail(user.Email, subject, map[string]any{
+		"Name":       user.Name,
+		"BudgetName": budget.Name,
+		"Threshold":  threshold,
+		"Exceeded":   threshold >= 100,
+		"Period":     month.Format("January 2006"),
+		"Spent":      formatter.Money(status.Spent, status.Currency),
+		"Available":  formatter.Money(status.Available, status.Currency),
+		"Remaining":  formatter.Money(status.Remaining, status.Currency),
+		"Percent":    fmt.Sprintf("%.0f%%", status.Percent),
+	}, "resources/views/emails/main.html", "resources/views/emails/budget_alert.html")
+
+	if err != nil {
+		log.Printf("Failed to send budget alert email to user %d: %v", user.ID, err)
+	}
+}
+
+// status computes the spending against the budget in the month starting at month.
+func (s *budgetService) status(budget *models.Budget, month time.Time, categories []models.PaymentCategory) (dto.BudgetStatusResponse, error) {
+	from, to := month, month.AddDate(0, 1, -1)
+
+	spent, err := s.spent(budget, from, to, categories)
+	if err != nil {
+		return dto.BudgetStatusResponse{}, err
+	}
+
+	// The carryover only looks one month back, the previous month's own carryover is not chained into it.
+	var carryover int64
+	if budget.Rollover && budget.CreatedAt.Before(month) {
+		previous, err := s.spent(budget, month.AddDate(0, -1, 0), month.AddDate(0, 0, -1), categories)
+		if err != nil {
+			return dto.BudgetStatusResponse{}, err
+		}
+		carryover = budget.Amount - previous
+	}
+
+	available := budget.Amount + carryover
+
+	var percent float64
+	if available > 0 {
+		percent = math.Round(float64(spent)/float64(available)*100*100) / 100
+	} else if spent > 0 {
+		percent = 100
+	}
+
+	state := dto.BudgetStatusOnTrack
+	if percent >= float64(models.BudgetAlertThresholds[len(models.BudgetAlertThresholds)-1]) {
+		state = dto.BudgetStatusExceeded
+	} else if percent >= float64(models.BudgetAlertThresholds[0]) {
+		state = dto.BudgetStatusWarning
+	}
+
+	return dto.BudgetStatusResponse{
+		BudgetID:         budget.ID,
+		Name:             budget.Name,
+		Scope:            budget.Scope,
+		CategoryID:       budget.CategoryID,
+		PaymentAccountID: budget.PaymentAccountID,
+		Currency:         budget.Currency,
+		Period:           month.Format("2006-01"),
+		StartDate:        from.Format("2006-01-02"),
+		EndDate:          to.Format("2006-01-02"),
+		Amount:           budget.Amount,
+		Carryover:        carryover,
+		Available:        available,
+		Spent:            spent,
+		Remaining:        available - spent,
+		Percent:          percent,
+		Status:           state,
+	}, nil
+}
+
+// spent sums the expenses covered by the budget between from and to in the currency of the budget, converted at
+// the latest exchange rate on the last day of the range.
+func (s *budgetService) spent(budget *models.Budget, from, to time.Time, categories []models.PaymentCategory) (int64, error) {
+	var categoryIDs []uint
+	if budget.Scope == models.BudgetScopeCategory && budget.CategoryID != nil {
+		categoryIDs = models.CategoryDescendants(categories, *budget.CategoryID)
+	}
+
+	var paymentAccountID *uint
+	if budget.Scope == models.BudgetScopeAccount {
+		paymentAccountID = budget.PaymentAccountID
+	}
+
+	amounts, err := s.budget.Spent(budget.UserID, paymentAccountID, categoryIDs, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	rateDate := to
+	if rateDate.After(time.Now()) {
+		rateDate = time.Now()
+	}
+
+	var total int64
+	for _, amount := range amounts {
+		currency := amount.Currency
+		if currency == "" {
+			currency = utils.DefaultCurrency
+		}
+
+		if currency == budget.Currency {
+			total += amount.Amount
+			continue
+		}
+
+		rate, err := s.exchangeRate.Rate(currency, budget.Currency, rateDate)
+		if err != nil {
+			return 0, fmt.Errorf("No exchange rate from %s to %s on or before %s", currency, budget.Currency, rateDate.Format("2006-01-02"))
+		}
+
+		total += utils.ConvertMoney(amount.Amount, currency, budget.Currency, rate)
+	}
+
+	return total, nil
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// userFormatter formats for the user's own locale and timezone, for messages sent outside of a request.
+func userFormatter(user *models.User) *utils.Formatter {
+	locale, timezone := utils.DefaultLocale, utils.DefaultTimezone
+	if user.Locale != nil && *user.Locale != "" {
+		locale = *user.Locale
+	}
+	if user.Timezone != nil && *user.Timezone != "" {
+		timezone = *user.Timezone
+	}
+	return utils.NewFormatter(locale, timezone)
+}
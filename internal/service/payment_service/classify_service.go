@@ -0,0 +1,80 @@
+package payment_service
+
+import (
+	"errors"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"log"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxPaymentTags      = 10
+	maxPaymentTagLength = 50
+)
+
+// classifyService files payments under a category and tags them, for the store and update services.
+type classifyService struct {
+	category *repositories.PaymentCategoryRepository
+	tag      *repositories.PaymentTagRepository
+}
+
+func newClassifyService(db *gorm.DB) *classifyService {
+	return &classifyService{
+		category: repositories.NewPaymentCategoryRepository(db),
+		tag:      repositories.NewPaymentTagRepository(db),
+	}
+}
+
+// validate adds the errors of the category and tags fields to validationErrs. A zero category_id removes the
+// category, so it is always valid.
+func (s *classifyService) validate(userID uint, categoryID *uint, tags []string, validationErrs map[string][]string) {
+	if categoryID != nil && *categoryID != 0 && !policies.PaymentCategory.UseByID(s.category, userID, categoryID) {
+		validationErrs["category_id"] = []string{"The selected category is invalid"}
+	}
+
+	names := models.NormalizeTagNames(tags)
+	if len(names) > maxPaymentTags {
+		validationErrs["tags"] = []string{"A payment may have at most " + strconv.Itoa(maxPaymentTags) + " tags"}
+		return
+	}
+
+	for _, name := range names {
+		if len([]rune(name)) > maxPaymentTagLength {
+			validationErrs["tags"] = []string{"Each tag may not be greater than " + strconv.Itoa(maxPaymentTagLength) + " characters"}
+			return
+		}
+	}
+}
+
+// Tag replaces the tags of the payment with the named ones, creating the tags the user does not have yet.
+func (s *classifyService) Tag(tx *gorm.DB, userID uint, payment *models.Payment, names []string) error {
+	tags, err := s.tag.FirstOrCreate(tx, userID, models.NormalizeTagNames(names))
+	if err != nil {
+		log.Println("Failed to create payment tags: ", err)
+		return errors.New("Failed to tag payment, please try again")
+	}
+
+	if err := s.tag.Sync(tx, payment.ID, tags); err != nil {
+		log.Println("Failed to tag payment: ", err)
+		return errors.New("Failed to tag payment, please try again")
+	}
+
+	payment.Tags = tags
+	if payment.Tags == nil {
+		payment.Tags = []models.PaymentTag{}
+	}
+
+	return nil
+}
+
+// categoryID returns the category to store, a zero ID removes it.
+func categoryID(id *uint) *uint {
+	if id == nil || *id == 0 {
+		return nil
+	}
+	return id
+}
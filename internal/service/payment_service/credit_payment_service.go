@@ -0,0 +1,141 @@
+package payment_service
+
+import (
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type CreditPaymentService interface {
+	Pay(c *fiber.Ctx) error
+}
+
+type creditPaymentService struct {
+	payment        *repositories.PaymentRepository
+	paymentAccount *repositories.PaymentAccountRepository
+	store          *storeService
+	balance        *balanceService
+	db             *gorm.DB
+}
+
+func NewCreditPaymentService(db *gorm.DB) CreditPaymentService {
+	return &creditPaymentService{
+		payment:        repositories.NewPaymentRepository(db),
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		store:          newStoreService(db),
+		balance:        newBalanceService(db),
+		db:             db,
+	}
+}
+
+// Pay books a transfer from a funding account into a credit card or loan account. Money moving into a credit
+// account lowers its debt, so the amount may not exceed the outstanding balance and defaults to all of it.
+func (s *creditPaymentService) Pay(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	creditAccount, err := s.paymentAccount.FindByID(uint(id))
+	if err != nil || !policies.PaymentAccount.Use(userId, creditAccount) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	if !creditAccount.IsCredit() {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Only credit card and loan accounts can be paid off")
+	}
+
+	outstanding := creditAccount.Outstanding()
+	if outstanding == 0 {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "This payment account has no outstanding balance")
+	}
+
+	var payload dto.PayCreditRequest
+
+	rules := govalidator.MapData{
+		"payment_account_id": []string{"required", "numeric"},
+		"amount":             []string{"numeric"},
+		"date":               []string{"date:yyyy-mm-dd"},
+	}
+
+	errs := utils.ValidateJSON(c, &payload, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if payload.Amount == nil {
+		payload.Amount = &outstanding
+	}
+
+	if payload.Date == "" {
+		payload.Date = time.Now().Format("2006-01-02")
+	}
+
+	validationErrs := make(map[string][]string)
+
+	if *payload.Amount < 1 {
+		validationErrs["amount"] = []string{"This field must be greater than 0"}
+	} else if *payload.Amount > outstanding {
+		validationErrs["amount"] = []string{"The amount may not exceed the outstanding balance of " + utils.FormatMoney(outstanding, creditAccount.Currency)}
+	}
+
+	if payload.PaymentAccountID == creditAccount.ID {
+		validationErrs["payment_account_id"] = []string{"The funding account must be different from the paid account"}
+	} else if fundingAccount, err := s.paymentAccount.SelectByID(nil, payload.PaymentAccountID, []string{"id", "user_id", "currency"}); err != nil || !policies.PaymentAccount.Use(userId, fundingAccount) {
+		validationErrs["payment_account_id"] = []string{"The selected payment account is invalid"}
+	} else if currencyOf(fundingAccount) != currencyOf(creditAccount) {
+		validationErrs["payment_account_id"] = []string{"The funding account must use the same currency as the paid account"}
+	}
+
+	if len(validationErrs) > 0 {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	name := "Credit card payment"
+	if creditAccount.Type == models.PaymentAccountTypeLoan {
+		name = "Loan payment"
+	}
+	name += " " + creditAccount.Name
+
+	var payment *models.Payment
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		payment, err = s.store.createPayment(tx, userId, userName, &dto.StorePaymentRequest{
+			Name:               &name,
+			Amount:             payload.Amount,
+			TypeID:             models.PaymentTypeTransfer,
+			Date:               payload.Date,
+			PaymentAccountID:   payload.PaymentAccountID,
+			PaymentAccountToID: &creditAccount.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.balance.Apply(tx, userId, userName, payment)
+	})
+
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	result, err := s.payment.FindByID(int(payment.ID))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+	}
+
+	return utils.CreatedResponse(c, "Payment account paid successfully", result)
+}
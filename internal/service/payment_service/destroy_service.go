@@ -0,0 +1,102 @@
+package payment_service
+
+import (
+	"errors"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type DestroyService interface {
+	Destroy(c *fiber.Ctx) error
+}
+
+type destroyService struct {
+	payment        *repositories.PaymentRepository
+	paymentItem    *repositories.PaymentItemRepository
+	reconciliation *repositories.PaymentReconciliationRepository
+	tag            *repositories.PaymentTagRepository
+	balance        *balanceService
+	goal           *goalContributionService
+	db             *gorm.DB
+}
+
+func NewDestroyService(db *gorm.DB) DestroyService {
+	return &destroyService{
+		payment:        repositories.NewPaymentRepository(db),
+		paymentItem:    repositories.NewPaymentItemRepository(db),
+		reconciliation: repositories.NewPaymentReconciliationRepository(db),
+		tag:            repositories.NewPaymentTagRepository(db),
+		balance:        newBalanceService(db),
+		goal:           newGoalContributionService(db),
+		db:             db,
+	}
+}
+
+func (s *destroyService) Destroy(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	paymentID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	payment, err := s.payment.FindByID(paymentID)
+	if err != nil || !policies.Payment.Delete(userId, payment) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if isBooked(payment) {
+			if err := s.balance.Revert(tx, userId, userName, payment); err != nil {
+				return err
+			}
+		} else if err := s.balance.Release(tx, userId, userName, payment); err != nil {
+			return err
+		}
+
+		if err := s.paymentItem.DeleteByPaymentID(tx, payment.ID); err != nil {
+			log.Println("Failed to delete payment items: ", err)
+			return errors.New("Failed to delete payment items, please try again")
+		}
+
+		if err := s.tag.DetachPayment(tx, payment.ID); err != nil {
+			log.Println("Failed to detach payment tags: ", err)
+			return errors.New("Failed to delete payment, please try again")
+		}
+
+		if err := s.goal.Revise(tx, userId, userName, payment.ID, 0, true); err != nil {
+			return err
+		}
+
+		if err := s.payment.Delete(tx, payment, userId, userName); err != nil {
+			log.Println("Failed to delete payment: ", err)
+			return errors.New("Failed to delete payment, please try again")
+		}
+
+		if payment.IsReconciled() {
+			if err := s.reconciliation.Flag(tx, *payment.ReconciliationID); err != nil {
+				log.Println("Failed to flag reconciliation: ", err)
+				return errors.New("Failed to delete payment, please try again")
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if payment.IsReconciled() {
+		return utils.SimpleSuccessResponse(c, "Payment deleted successfully, the reconciliation it was cleared in has been flagged")
+	}
+
+	return utils.SimpleSuccessResponse(c, "Payment deleted successfully")
+}
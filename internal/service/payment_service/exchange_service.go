@@ -0,0 +1,90 @@
+package payment_service
+
+import (
+	"errors"
+	"fmt"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+type exchangeService struct {
+	paymentAccount *repositories.PaymentAccountRepository
+	exchangeRate   *repositories.ExchangeRateRepository
+}
+
+func newExchangeService(db *gorm.DB) *exchangeService {
+	return &exchangeService{
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		exchangeRate:   repositories.NewExchangeRateRepository(db),
+	}
+}
+
+// Resolve sets the currency of the payment to the one of its account. Transfers and withdrawals between accounts
+// of different currencies also get the amount credited to the destination and the rate between both amounts:
+// amountTo when it is given, otherwise the amount converted at the latest exchange rate on the payment date.
+func (s *exchangeService) Resolve(tx *gorm.DB, payment *models.Payment, amountTo *int64) error {
+	paymentAccount, err := s.paymentAccount.SelectByID(tx, payment.PaymentAccountID, []string{"id", "currency"})
+	if err != nil {
+		return errors.New("Payment account not found")
+	}
+
+	payment.Currency = currencyOf(paymentAccount)
+	payment.AmountTo = nil
+	payment.ExchangeRate = nil
+
+	if payment.PaymentAccountToID == nil || (payment.TypeID != models.PaymentTypeTransfer && payment.TypeID != models.PaymentTypeWithdrawal) {
+		return nil
+	}
+
+	paymentAccountTo, err := s.paymentAccount.SelectByID(tx, *payment.PaymentAccountToID, []string{"id", "currency"})
+	if err != nil {
+		return errors.New("Payment account destination not found")
+	}
+
+	currencyTo := currencyOf(paymentAccountTo)
+	if currencyTo == payment.Currency {
+		return nil
+	}
+
+	amount := paymentAmount(payment)
+
+	if amountTo != nil && *amountTo > 0 {
+		rate := 0.0
+		if amount > 0 {
+			rate = exchangeRateBetween(amount, payment.Currency, *amountTo, currencyTo)
+		}
+
+		payment.AmountTo = amountTo
+		payment.ExchangeRate = &rate
+		return nil
+	}
+
+	rate, err := s.exchangeRate.Rate(payment.Currency, currencyTo, payment.Date)
+	if err != nil {
+		return fmt.Errorf("No exchange rate from %s to %s on or before %s, enter the amount received instead", payment.Currency, currencyTo, payment.Date.Format("2006-01-02"))
+	}
+
+	converted := utils.ConvertMoney(amount, payment.Currency, currencyTo, rate)
+	payment.AmountTo = &converted
+	payment.ExchangeRate = &rate
+
+	return nil
+}
+
+func currencyOf(paymentAccount *models.PaymentAccount) string {
+	if paymentAccount.Currency == "" {
+		return utils.DefaultCurrency
+	}
+	return paymentAccount.Currency
+}
+
+// exchangeRateBetween returns the rate that turns amount in from into amountTo in to, both in minor units.
+func exchangeRateBetween(amount int64, from string, amountTo int64, to string) float64 {
+	major := float64(amount) / math.Pow10(utils.GetCurrency(from).Decimals)
+	majorTo := float64(amountTo) / math.Pow10(utils.GetCurrency(to).Decimals)
+	return math.Round(majorTo/major*1e10) / 1e10
+}
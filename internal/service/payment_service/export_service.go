@@ -0,0 +1,187 @@
+package payment_service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+var exportColumns = []string{"Code", "Date", "Name", "Type", "Status", "Account", "Account To", "Amount", "Formatted Amount", "Items Count"}
+
+type ExportService interface {
+	Export(c *fiber.Ctx) error
+}
+
+type exportService struct {
+	payment  *repositories.PaymentRepository
+	category *repositories.PaymentCategoryRepository
+}
+
+func NewExportService(db *gorm.DB) ExportService {
+	return &exportService{
+		payment:  repositories.NewPaymentRepository(db),
+		category: repositories.NewPaymentCategoryRepository(db),
+	}
+}
+
+// Export streams the filtered payments as a CSV or XLSX download.
+// Rows are written while they are read from the database, so errors after the first row can only be logged.
+func (s *exportService) Export(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	format := c.Query("format", "csv")
+	typeID, _ := strconv.Atoi(c.Query("type", "0"))
+	accountID, _ := strconv.Atoi(c.Query("account_id", "0"))
+	categoryID, _ := strconv.Atoi(c.Query("category_id", "0"))
+
+	if format != "csv" && format != "xlsx" {
+		return utils.ValidationError(c, map[string][]string{
+			"format": {"The format must be one of csv, xlsx"},
+		})
+	}
+
+	filter := repositories.PaymentFilter{
+		DateFrom:    c.Query("date_from"),
+		DateTo:      c.Query("date_to"),
+		Type:        typeID,
+		AccountID:   accountID,
+		Search:      c.Query("search"),
+		UserID:      userId,
+		CategoryIDs: s.category.FilterIDs(userId, categoryID),
+		Tags:        models.NormalizeTagNames(strings.Split(c.Query("tags"), ",")),
+	}
+
+	fileName := fmt.Sprintf("payments-%s.%s", time.Now().Format("20060102-150405"), format)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+
+	if format == "xlsx" {
+		c.Set(fiber.HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			if err := s.writeXLSX(w, filter); err != nil {
+				log.Println("Failed to export payments as xlsx: ", err)
+			}
+		})
+		return nil
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := s.writeCSV(w, filter); err != nil {
+			log.Println("Failed to export payments as csv: ", err)
+		}
+	})
+	return nil
+}
+
+func (s *exportService) writeCSV(w *bufio.Writer, filter repositories.PaymentFilter) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(exportColumns); err != nil {
+		return err
+	}
+
+	err := s.payment.StreamFiltered(filter, func(row *dto.PaymentExportRow) error {
+		record := exportRecord(row)
+		values := make([]string, len(record))
+		for i, value := range record {
+			values[i] = fmt.Sprint(value)
+		}
+		return writer.Write(values)
+	})
+
+	writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+// writeXLSX uses the excelize stream writer, which spills to a temporary file for large sheets.
+func (s *exportService) writeXLSX(w *bufio.Writer, filter repositories.PaymentFilter) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	const sheet = "Payments"
+	file.SetSheetName("Sheet1", sheet)
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+
+	if err := stream.SetRow("A1", header); err != nil {
+		return err
+	}
+
+	rowNumber := 1
+
+	err = s.payment.StreamFiltered(filter, func(row *dto.PaymentExportRow) error {
+		rowNumber++
+		cell, _ := excelize.CoordinatesToCellName(1, rowNumber)
+		return stream.SetRow(cell, exportRecord(row))
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := file.WriteTo(w); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func exportRecord(row *dto.PaymentExportRow) []interface{} {
+	amount := int64(0)
+	if row.Amount != nil {
+		amount = *row.Amount
+	}
+
+	status := "Booked"
+	if row.IsDraft {
+		status = "Draft"
+	} else if row.IsScheduled {
+		status = "Scheduled"
+	}
+
+	return []interface{}{
+		row.Code,
+		row.Date.Format("2006-01-02"),
+		exportString(row.Name),
+		exportString(row.TypeName),
+		status,
+		exportString(row.AccountName),
+		exportString(row.AccountToName),
+		amount,
+		utils.FormatMoney(amount, row.Currency),
+		row.ItemsCount,
+	}
+}
+
+func exportString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -1,32 +1,92 @@
 package payment_service
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"golang-api/internal/config"
 	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/repositories"
 	"golang-api/pkg/utils"
-	"io"
-	"net/http"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+const (
+	reportDirectory = "storage/files/reports"
+	reportLifetime  = 24 * time.Hour
 )
 
 type GenerateReportService interface {
 	GenerateReport(c *fiber.Ctx) error
 }
 
-type generateReportService struct{}
+type generateReportService struct {
+	payment        *repositories.PaymentRepository
+	paymentItem    *repositories.PaymentItemRepository
+	paymentAccount *repositories.PaymentAccountRepository
+	fileDownload   *repositories.FileDownloadRepository
+	generate       *repositories.GenerateRepository
+}
+
+func NewGenerateReportService(db *gorm.DB) GenerateReportService {
+	return &generateReportService{
+		payment:        repositories.NewPaymentRepository(db),
+		paymentItem:    repositories.NewPaymentItemRepository(db),
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		fileDownload:   repositories.NewFileDownloadRepository(db),
+		generate:       repositories.NewGenerateRepository(db),
+	}
+}
+
+// paymentReport holds everything rendered into the PDF and CSV files of a report.
+type paymentReport struct {
+	UserName    string
+	StartDate   time.Time
+	EndDate     time.Time
+	GeneratedAt time.Time
+	Payments    []models.Payment
+	Items       map[uint][]models.PaymentItem
+	Accounts    []models.PaymentAccount
+	Totals      []reportTotals
+}
+
+// reportTotals sums the payments of one currency, amounts in different currencies are never added up.
+type reportTotals struct {
+	Currency   string
+	Income     int64
+	Expense    int64
+	Transfer   int64
+	Withdrawal int64
+}
+
+// totalsFor returns the totals of the currency, adding them in order of the first payment in that currency.
+func (r *paymentReport) totalsFor(currency string) *reportTotals {
+	currency = utils.GetCurrency(currency).Code
+
+	for i := range r.Totals {
+		if r.Totals[i].Currency == currency {
+			return &r.Totals[i]
+		}
+	}
 
-func NewGenerateReportService() GenerateReportService {
-	return &generateReportService{}
+	r.Totals = append(r.Totals, reportTotals{Currency: currency})
+	return &r.Totals[len(r.Totals)-1]
 }
 
 func (s *generateReportService) GenerateReport(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
 	var payload dto.GenerateReportRequest
 
 	validateErrors := s.validate(c, &payload)
@@ -34,7 +94,22 @@ func (s *generateReportService) GenerateReport(c *fiber.Ctx) error {
 		return utils.ValidationError(c, validateErrors)
 	}
 
-	return s.forwardRequest(c, &payload)
+	startDate, endDate := s.resolvePeriod(&payload)
+
+	report, err := s.collect(userId, userName, startDate, endDate)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	fileDownload, err := s.store(userId, report)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Report generated successfully", dto.GenerateReportResponse{
+		UID:       fileDownload.UID,
+		ExpiresAt: report.GeneratedAt.Add(reportLifetime),
+	})
 }
 
 func (s *generateReportService) validate(c *fiber.Ctx, payload *dto.GenerateReportRequest) map[string][]string {
@@ -98,37 +173,150 @@ func (s *generateReportService) validateMonthly(payload *dto.GenerateReportReque
 	}
 }
 
-func (s *generateReportService) forwardRequest(c *fiber.Ctx, payload *dto.GenerateReportRequest) error {
-	mainURL := strings.TrimRight(config.MainUrl, "/")
-	targetURL := fmt.Sprintf("%s/api/payments/generate-report", mainURL)
+func (s *generateReportService) resolvePeriod(payload *dto.GenerateReportRequest) (time.Time, time.Time) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
 
-	bodyBytes, _ := json.Marshal(payload)
+	switch payload.ReportType {
+	case "monthly":
+		month, _ := time.ParseInLocation("2006-01", payload.Periode, time.Local)
+		return month, month.AddDate(0, 1, -1)
+	case "date_range":
+		start, _ := time.ParseInLocation("2006-01-02", payload.StartDate, time.Local)
+		end, _ := time.ParseInLocation("2006-01-02", payload.EndDate, time.Local)
+		return start, end
+	}
+
+	return today, today
+}
 
-	req, err := http.NewRequest("POST", targetURL, strings.NewReader(string(bodyBytes)))
+func (s *generateReportService) collect(userId uint, userName string, startDate, endDate time.Time) (*paymentReport, error) {
+	payments, err := s.payment.FindBookedBetween(userId, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create request")
+		log.Println("Failed to retrieve payments for report: ", err)
+		return nil, errors.New("Failed to retrieve payments")
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	accounts, err := s.paymentAccount.FindAllByUserID(userId)
+	if err != nil {
+		log.Println("Failed to retrieve payment accounts for report: ", err)
+		return nil, errors.New("Failed to retrieve payment accounts")
+	}
 
-	authHeader := c.Get("Authorization")
-	if authHeader != "" {
-		req.Header.Set("Authorization", authHeader)
+	report := &paymentReport{
+		UserName:    userName,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		GeneratedAt: time.Now(),
+		Payments:    payments,
+		Items:       make(map[uint][]models.PaymentItem),
+		Accounts:    accounts,
 	}
 
-	client := &http.Client{Timeout: 120 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadGateway, "Failed to connect to main server")
+	var paymentIDs []uint
+
+	for _, payment := range payments {
+		amount := paymentAmount(&payment)
+		totals := report.totalsFor(payment.Currency)
+
+		switch payment.TypeID {
+		case models.PaymentTypeIncome:
+			totals.Income += amount
+		case models.PaymentTypeExpense:
+			totals.Expense += amount
+		case models.PaymentTypeTransfer:
+			totals.Transfer += amount
+		case models.PaymentTypeWithdrawal:
+			totals.Withdrawal += amount
+		}
+
+		if payment.HasItems {
+			paymentIDs = append(paymentIDs, payment.ID)
+		}
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to read response from main server")
+	if len(paymentIDs) > 0 {
+		paymentItems, err := s.paymentItem.FindByPaymentIDs(paymentIDs)
+		if err != nil {
+			log.Println("Failed to retrieve payment items for report: ", err)
+			return nil, errors.New("Failed to retrieve payment items")
+		}
+
+		for _, paymentItem := range paymentItems {
+			report.Items[paymentItem.PaymentID] = append(report.Items[paymentItem.PaymentID], paymentItem)
+		}
+	}
+
+	return report, nil
+}
+
+// store renders the report files into the private storage directory and registers them under a new file download.
+// The files are only served through the authenticated download route, to the user they were generated for.
+func (s *generateReportService) store(userId uint, report *paymentReport) (*models.FileDownload, error) {
+	if err := os.MkdirAll(reportDirectory, 0755); err != nil {
+		log.Println("Failed to create report directory: ", err)
+		return nil, errors.New("Failed to generate report")
+	}
+
+	baseName := fmt.Sprintf("payment-report-%s-%s-%s",
+		report.StartDate.Format("20060102"),
+		report.EndDate.Format("20060102"),
+		strings.ReplaceAll(uuid.NewString(), "-", "")[:12],
+	)
+
+	renderers := []struct {
+		extension string
+		render    func(report *paymentReport, path string) error
+	}{
+		{"pdf", renderReportPDF},
+		{"csv", renderReportCSV},
+	}
+
+	deletionTime := report.GeneratedAt.Add(reportLifetime)
+	subjectID := userId
+	var files []models.File
+
+	for _, renderer := range renderers {
+		fileName := baseName + "." + renderer.extension
+		filePath := filepath.Join(reportDirectory, fileName)
+		code := s.generate.GetCode("file", true)
+
+		if err := renderer.render(report, filePath); err != nil {
+			log.Printf("Failed to render %s report: %v\n", renderer.extension, err)
+			return nil, errors.New("Failed to generate report")
+		}
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			log.Println("Failed to read report file: ", err)
+			return nil, errors.New("Failed to generate report")
+		}
+
+		files = append(files, models.File{
+			Code:                  code,
+			UserID:                &subjectID,
+			FileName:              fileName,
+			FilePath:              filePath,
+			FileSize:              info.Size(),
+			DownloadURL:           strings.TrimRight(config.AppURL, "/") + "/api/files/" + url.PathEscape(code) + "/download",
+			FileAlias:             "Payment Report (" + strings.ToUpper(renderer.extension) + ")",
+			ScheduledDeletionTime: &deletionTime,
+			SubjectType:           "App\\Models\\User",
+			SubjectID:             subjectID,
+		})
+	}
+
+	fileDownload := &models.FileDownload{
+		UID:    uuid.NewString(),
+		Code:   s.generate.GetCode("file_download", true),
+		Status: models.FileDownloadStatusActive,
+		Files:  files,
+	}
+
+	if err := s.fileDownload.Create(fileDownload); err != nil {
+		log.Println("Failed to store report files: ", err)
+		return nil, errors.New("Failed to generate report")
 	}
 
-	c.Set("Content-Type", resp.Header.Get("Content-Type"))
-	return c.Status(resp.StatusCode).Send(respBody)
+	return fileDownload, nil
 }
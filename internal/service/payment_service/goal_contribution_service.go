@@ -0,0 +1,251 @@
+package payment_service
+
+import (
+	"errors"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type GoalContributionService interface {
+	Contribute(c *fiber.Ctx) error
+}
+
+type goalContributionService struct {
+	payment        *repositories.PaymentRepository
+	paymentAccount *repositories.PaymentAccountRepository
+	paymentGoal    *repositories.PaymentGoalRepository
+	store          *storeService
+	balance        *balanceService
+	db             *gorm.DB
+}
+
+func NewGoalContributionService(db *gorm.DB) GoalContributionService {
+	return newGoalContributionService(db)
+}
+
+func newGoalContributionService(db *gorm.DB) *goalContributionService {
+	return &goalContributionService{
+		payment:        repositories.NewPaymentRepository(db),
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		paymentGoal:    repositories.NewPaymentGoalRepository(db),
+		store:          newStoreService(db),
+		balance:        newBalanceService(db),
+		db:             db,
+	}
+}
+
+// Contribute moves money from a payment account toward a goal. The money is booked as an expense, or as a transfer
+// when it is put aside on a savings account given as payment_account_to_id, and the goal amount, progress and status
+// are updated with it in the same transaction.
+func (s *goalContributionService) Contribute(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	goalID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment goal ID")
+	}
+
+	goal, err := s.paymentGoal.FindByID(goalID, userId)
+	if err != nil || !policies.PaymentGoal.Update(userId, goal) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment goal not found")
+	}
+
+	if goal.IsCompleted() {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "This payment goal is already completed")
+	}
+
+	var payload dto.ContributePaymentGoalRequest
+
+	validateErrors := s.validate(c, userId, &payload)
+	if validateErrors != nil {
+		return utils.ValidationError(c, validateErrors)
+	}
+
+	if payload.Date == "" {
+		payload.Date = time.Now().Format("2006-01-02")
+	}
+
+	name := "Contribution to goal " + goal.Name
+	typeID := models.PaymentTypeExpense
+	if payload.PaymentAccountToID != nil {
+		typeID = models.PaymentTypeTransfer
+	}
+
+	var payment *models.Payment
+	var contribution *models.PaymentGoalContribution
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		payment, err = s.store.createPayment(tx, userId, userName, &dto.StorePaymentRequest{
+			Name:               &name,
+			Amount:             &payload.Amount,
+			TypeID:             typeID,
+			Date:               payload.Date,
+			PaymentAccountID:   payload.PaymentAccountID,
+			PaymentAccountToID: payload.PaymentAccountToID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.balance.Apply(tx, userId, userName, payment); err != nil {
+			return err
+		}
+
+		if err := s.adjustGoal(tx, userId, userName, goal.ID, payload.Amount); err != nil {
+			return err
+		}
+
+		contribution = &models.PaymentGoalContribution{
+			UserID:        userId,
+			PaymentGoalID: goal.ID,
+			PaymentID:     payment.ID,
+			Amount:        payload.Amount,
+		}
+
+		if err := s.paymentGoal.CreateContribution(tx, contribution); err != nil {
+			log.Println("Failed to create payment goal contribution: ", err)
+			return errors.New("Failed to contribute to payment goal, please try again")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	goal, err = s.paymentGoal.FindByID(goalID, userId)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment goal not found")
+	}
+
+	payment, err = s.payment.FindByID(int(payment.ID))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+	}
+
+	message := "Contribution added successfully"
+	if goal.IsCompleted() {
+		message = "Contribution added successfully, the payment goal has been completed"
+	}
+
+	return utils.CreatedResponse(c, message, dto.ContributePaymentGoalResponse{
+		Goal:         goal,
+		Contribution: contribution,
+		Payment:      payment,
+	})
+}
+
+// Revise follows a change of a payment that was contributed to a goal: the goal amount moves by the difference to
+// the new amount, or loses the contribution when removed. Payments that are not contributions are left alone.
+func (s *goalContributionService) Revise(tx *gorm.DB, userId uint, userName string, paymentID uint, amount int64, removed bool) error {
+	contribution, err := s.paymentGoal.FindContributionByPayment(tx, paymentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		log.Println("Failed to find payment goal contribution: ", err)
+		return errors.New("Failed to update payment goal, please try again")
+	}
+
+	if removed {
+		amount = 0
+	}
+
+	if amount == contribution.Amount && !removed {
+		return nil
+	}
+
+	if err := s.adjustGoal(tx, userId, userName, contribution.PaymentGoalID, amount-contribution.Amount); err != nil {
+		return err
+	}
+
+	if removed {
+		err = s.paymentGoal.DeleteContribution(tx, contribution.ID)
+	} else {
+		err = s.paymentGoal.UpdateContribution(tx, contribution.ID, amount)
+	}
+
+	if err != nil {
+		log.Println("Failed to update payment goal contribution: ", err)
+		return errors.New("Failed to update payment goal, please try again")
+	}
+
+	return nil
+}
+
+// adjustGoal adds the difference to the saved amount of the goal and recalculates its progress and status.
+func (s *goalContributionService) adjustGoal(tx *gorm.DB, userId uint, userName string, goalID uint, difference int64) error {
+	goal, err := s.paymentGoal.LockByID(tx, goalID)
+	if err != nil {
+		return errors.New("Payment goal not found")
+	}
+
+	prev := *goal
+	goal.Amount += difference
+	goal.Recalculate(time.Now())
+
+	if err := s.paymentGoal.Update(tx, userId, userName, goal, &prev); err != nil {
+		log.Println("Failed to update payment goal: ", err)
+		return errors.New("Failed to update payment goal, please try again")
+	}
+
+	return nil
+}
+
+func (s *goalContributionService) validate(c *fiber.Ctx, userId uint, payload *dto.ContributePaymentGoalRequest) map[string][]string {
+	rules := govalidator.MapData{
+		"payment_account_id":    []string{"required", "numeric"},
+		"payment_account_to_id": []string{"numeric"},
+		"amount":                []string{"required", "numeric"},
+		"date":                  []string{"date:yyyy-mm-dd"},
+	}
+
+	errs := utils.ValidateJSON(c, payload, rules)
+	if errs != nil {
+		return errs
+	}
+
+	validationErrs := make(map[string][]string)
+
+	if payload.Amount < 1 {
+		validationErrs["amount"] = []string{"This field must be greater than 0"}
+	}
+
+	paymentAccount, err := s.paymentAccount.SelectByID(nil, payload.PaymentAccountID, []string{"id", "user_id", "currency"})
+	if err != nil || !policies.PaymentAccount.Use(userId, paymentAccount) {
+		validationErrs["payment_account_id"] = []string{"The selected payment account is invalid"}
+	} else if currencyOf(paymentAccount) != utils.DefaultCurrency {
+		validationErrs["payment_account_id"] = []string{"The payment account must use " + utils.DefaultCurrency + ", the currency of payment goals"}
+	}
+
+	if payload.PaymentAccountToID != nil {
+		paymentAccountTo, err := s.paymentAccount.SelectByID(nil, *payload.PaymentAccountToID, []string{"id", "user_id", "currency"})
+		if err != nil || !policies.PaymentAccount.Use(userId, paymentAccountTo) {
+			validationErrs["payment_account_to_id"] = []string{"The selected payment account is invalid"}
+		} else if *payload.PaymentAccountToID == payload.PaymentAccountID {
+			validationErrs["payment_account_to_id"] = []string{"The destination account must be different from the origin account."}
+		} else if currencyOf(paymentAccountTo) != utils.DefaultCurrency {
+			validationErrs["payment_account_to_id"] = []string{"The payment account must use " + utils.DefaultCurrency + ", the currency of payment goals"}
+		}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
@@ -0,0 +1,617 @@
+package payment_service
+
+import (
+	"errors"
+	"fmt"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"io"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+const (
+	importMaxFileSize     = 5 * 1024 * 1024
+	importDuplicateDays   = 3
+	importDefaultName     = "Imported transaction"
+	importQIFDefaultDates = "MM/DD/YYYY"
+)
+
+var errImportNotFound = errors.New("Import not found")
+
+type ImportService interface {
+	Preview(c *fiber.Ctx) error
+	Confirm(c *fiber.Ctx) error
+	ShowMapping(c *fiber.Ctx) error
+	SaveMapping(c *fiber.Ctx) error
+}
+
+type importService struct {
+	paymentImport  *repositories.PaymentImportRepository
+	payment        *repositories.PaymentRepository
+	paymentAccount *repositories.PaymentAccountRepository
+	store          *storeService
+	balance        *balanceService
+	db             *gorm.DB
+}
+
+func NewImportService(db *gorm.DB) ImportService {
+	return &importService{
+		paymentImport:  repositories.NewPaymentImportRepository(db),
+		payment:        repositories.NewPaymentRepository(db),
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		store:          newStoreService(db),
+		balance:        newBalanceService(db),
+		db:             db,
+	}
+}
+
+// Preview parses an uploaded bank statement into candidate payments and flags likely duplicates.
+// Nothing is booked until the import is confirmed.
+func (s *importService) Preview(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return utils.ValidationError(c, map[string][]string{"file": {"The file field is required"}})
+	}
+
+	if fileHeader.Size > importMaxFileSize {
+		return utils.ValidationError(c, map[string][]string{"file": {"The file may not be greater than 5 MB"}})
+	}
+
+	format := strings.ToLower(c.FormValue("format"))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+
+	if format != models.PaymentImportFormatCSV && format != models.PaymentImportFormatOFX && format != models.PaymentImportFormatQIF {
+		return utils.ValidationError(c, map[string][]string{"format": {"The format must be one of csv, ofx, qif"}})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to read the uploaded file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to read the uploaded file")
+	}
+
+	var lines []statementLine
+	decimals := utils.GetCurrency(paymentAccount.Currency).Decimals
+
+	switch format {
+	case models.PaymentImportFormatCSV:
+		mapping, validationErrs := s.resolveMapping(c, userId, paymentAccount.ID)
+		if validationErrs != nil {
+			return utils.ValidationError(c, validationErrs)
+		}
+		lines, err = parseCSVStatement(data, mapping, decimals)
+	case models.PaymentImportFormatOFX:
+		lines, err = parseOFXStatement(data, decimals)
+	case models.PaymentImportFormatQIF:
+		dateFormat := c.FormValue("date_format", importQIFDefaultDates)
+		lines, err = parseQIFStatement(data, dateFormat, decimals)
+	}
+
+	if err != nil {
+		return utils.ValidationError(c, map[string][]string{"file": {err.Error()}})
+	}
+
+	rows := s.buildRows(lines)
+	if len(rows) == 0 {
+		return utils.ValidationError(c, map[string][]string{"file": {"The file does not contain any transactions"}})
+	}
+
+	if err := s.detectDuplicates(userId, paymentAccount.ID, rows); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	paymentImport := &models.PaymentImport{
+		UserID:           userId,
+		PaymentAccountID: paymentAccount.ID,
+		Format:           format,
+		FileName:         fileHeader.Filename,
+		Status:           models.PaymentImportStatusPending,
+		Rows:             rows,
+	}
+
+	if err := s.paymentImport.Create(paymentImport); err != nil {
+		log.Println("Failed to store payment import: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to store the import, please try again")
+	}
+
+	return utils.SuccessResponse(c, "Import preview created successfully", importResponse(paymentImport))
+}
+
+// Confirm books the selected rows of a pending import in one transaction. Without a selection,
+// every row that was not flagged as a duplicate is booked.
+func (s *importService) Confirm(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	importID, err := strconv.Atoi(c.Params("importId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid import ID")
+	}
+
+	var payload dto.ConfirmPaymentImportRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	var result *models.PaymentImport
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		paymentImport, err := s.paymentImport.FindForUpdate(tx, importID, paymentAccount.ID)
+		if err != nil || !policies.PaymentImport.Confirm(userId, paymentImport) {
+			return errImportNotFound
+		}
+
+		if paymentImport.Status != models.PaymentImportStatusPending {
+			return errors.New("This import has already been confirmed")
+		}
+
+		selected, err := selectImportRows(paymentImport.Rows, payload.Rows)
+		if err != nil {
+			return err
+		}
+
+		for _, index := range selected {
+			row := &paymentImport.Rows[index]
+			name := row.Name
+			amount := row.Amount
+
+			payment, err := s.store.createPayment(tx, userId, userName, &dto.StorePaymentRequest{
+				Name:             &name,
+				Amount:           &amount,
+				TypeID:           row.TypeID,
+				Date:             row.Date,
+				PaymentAccountID: paymentAccount.ID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := s.balance.Apply(tx, userId, userName, payment); err != nil {
+				return fmt.Errorf("Row %d: %s", row.Index+1, err.Error())
+			}
+
+			row.PaymentID = &payment.ID
+		}
+
+		now := time.Now()
+		paymentImport.Status = models.PaymentImportStatusConfirmed
+		paymentImport.ConfirmedAt = &now
+
+		if err := s.paymentImport.Update(tx, paymentImport); err != nil {
+			log.Println("Failed to update payment import: ", err)
+			return errors.New("Failed to confirm the import, please try again")
+		}
+
+		result = paymentImport
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, errImportNotFound) {
+			return utils.ErrorResponse(c, fiber.StatusNotFound, err.Error())
+		}
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Import confirmed successfully", importResponse(result))
+}
+
+func (s *importService) ShowMapping(c *fiber.Ctx) error {
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	mapping, err := s.paymentImport.FindMapping(paymentAccount.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "No import mapping has been saved for this payment account")
+	}
+
+	return utils.SuccessResponse(c, "Import mapping retrieved successfully", mapping)
+}
+
+func (s *importService) SaveMapping(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	var payload dto.PaymentImportMappingRequest
+
+	rules := govalidator.MapData{
+		"delimiter":     []string{"max:1"},
+		"has_header":    []string{"bool"},
+		"date_column":   []string{"required", "max:100"},
+		"date_format":   []string{"required", "max:20"},
+		"name_column":   []string{"required", "max:100"},
+		"amount_column": []string{"max:100"},
+		"debit_column":  []string{"max:100"},
+		"credit_column": []string{"max:100"},
+	}
+
+	errs := utils.ValidateJSON(c, &payload, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if validationErrs := validateImportMapping(&payload); validationErrs != nil {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	mapping, err := s.saveMapping(userId, paymentAccount.ID, &payload)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to save the import mapping")
+	}
+
+	return utils.SuccessResponse(c, "Import mapping saved successfully", mapping)
+}
+
+func (s *importService) findPaymentAccount(c *fiber.Ctx) (*models.PaymentAccount, error) {
+	userId := c.Locals("user_id").(uint)
+
+	paymentAccountID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	paymentAccount, err := s.paymentAccount.SelectByID(nil, uint(paymentAccountID), []string{"id", "user_id", "name"})
+	if err != nil || !policies.PaymentAccount.View(userId, paymentAccount) {
+		return nil, errors.New("Payment account not found")
+	}
+
+	return paymentAccount, nil
+}
+
+// resolveMapping uses the mapping sent with the upload when present, otherwise the saved mapping of the account.
+// An uploaded mapping replaces the saved one when save_mapping is true.
+func (s *importService) resolveMapping(c *fiber.Ctx, userId uint, paymentAccountID uint) (*models.PaymentImportMapping, map[string][]string) {
+	if c.FormValue("date_column") == "" {
+		mapping, err := s.paymentImport.FindMapping(paymentAccountID)
+		if err != nil {
+			return nil, map[string][]string{"date_column": {"A column mapping is required for CSV files when none has been saved"}}
+		}
+		return mapping, nil
+	}
+
+	var payload dto.PaymentImportMappingRequest
+	if err := c.BodyParser(&payload); err != nil {
+		return nil, map[string][]string{"date_column": {"The column mapping is invalid"}}
+	}
+
+	if validationErrs := validateImportMapping(&payload); validationErrs != nil {
+		return nil, validationErrs
+	}
+
+	if c.FormValue("save_mapping") == "true" || c.FormValue("save_mapping") == "1" {
+		mapping, err := s.saveMapping(userId, paymentAccountID, &payload)
+		if err != nil {
+			log.Println("Failed to save payment import mapping: ", err)
+		} else {
+			return mapping, nil
+		}
+	}
+
+	return importMapping(userId, paymentAccountID, &payload), nil
+}
+
+func (s *importService) saveMapping(userId uint, paymentAccountID uint, payload *dto.PaymentImportMappingRequest) (*models.PaymentImportMapping, error) {
+	mapping := importMapping(userId, paymentAccountID, payload)
+
+	if existing, err := s.paymentImport.FindMapping(paymentAccountID); err == nil {
+		mapping.ID = existing.ID
+		mapping.CreatedAt = existing.CreatedAt
+	}
+
+	if err := s.paymentImport.SaveMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+func (s *importService) buildRows(lines []statementLine) []models.PaymentImportRow {
+	rows := make([]models.PaymentImportRow, 0, len(lines))
+
+	for _, line := range lines {
+		if line.Amount == 0 {
+			continue
+		}
+
+		typeID := models.PaymentTypeIncome
+		if line.Amount < 0 {
+			typeID = models.PaymentTypeExpense
+		}
+
+		name := strings.Join(strings.Fields(line.Name), " ")
+		if name == "" {
+			name = importDefaultName
+		}
+		if runes := []rune(name); len(runes) > 255 {
+			name = string(runes[:255])
+		}
+
+		rows = append(rows, models.PaymentImportRow{
+			Index:     len(rows),
+			Date:      line.Date.Format("2006-01-02"),
+			Name:      name,
+			Amount:    absAmount(line.Amount),
+			TypeID:    typeID,
+			Reference: line.Reference,
+		})
+	}
+
+	return rows
+}
+
+// detectDuplicates flags rows that match an existing payment of the account on amount and direction,
+// dated within a few days and with a similar name (or on the very same day), and rows repeated within the file.
+func (s *importService) detectDuplicates(userId uint, paymentAccountID uint, rows []models.PaymentImportRow) error {
+	first, last := rows[0].Date, rows[0].Date
+	for _, row := range rows {
+		if row.Date < first {
+			first = row.Date
+		}
+		if row.Date > last {
+			last = row.Date
+		}
+	}
+
+	startDate, _ := time.ParseInLocation("2006-01-02", first, time.Local)
+	endDate, _ := time.ParseInLocation("2006-01-02", last, time.Local)
+
+	existing, err := s.payment.FindByAccountBetween(
+		userId,
+		paymentAccountID,
+		startDate.AddDate(0, 0, -importDuplicateDays).Format("2006-01-02"),
+		endDate.AddDate(0, 0, importDuplicateDays).Format("2006-01-02"),
+	)
+	if err != nil {
+		log.Println("Failed to retrieve payments for duplicate detection: ", err)
+		return errors.New("Failed to check for duplicate payments")
+	}
+
+	matched := make(map[uint]bool)
+	seen := make(map[string]bool)
+
+	for i := range rows {
+		row := &rows[i]
+
+		key := row.Reference
+		if key == "" {
+			key = fmt.Sprintf("%s|%d|%d|%s", row.Date, row.TypeID, row.Amount, strings.ToLower(row.Name))
+		}
+		if seen[key] {
+			row.IsDuplicate = true
+			continue
+		}
+		seen[key] = true
+
+		rowDate, _ := time.ParseInLocation("2006-01-02", row.Date, time.Local)
+
+		for j := range existing {
+			payment := &existing[j]
+			if matched[payment.ID] || paymentAmount(payment) != row.Amount {
+				continue
+			}
+
+			if !paymentMovesAccount(payment, paymentAccountID, row.TypeID == models.PaymentTypeIncome) {
+				continue
+			}
+
+			days := rowDate.Sub(payment.Date).Hours() / 24
+			if days < -importDuplicateDays || days > importDuplicateDays {
+				continue
+			}
+
+			sameDay := rowDate.Format("2006-01-02") == payment.Date.Format("2006-01-02")
+			if !sameDay && (payment.Name == nil || !namesSimilar(row.Name, *payment.Name)) {
+				continue
+			}
+
+			matched[payment.ID] = true
+			row.IsDuplicate = true
+			row.DuplicateOfID = &payment.ID
+			break
+		}
+	}
+
+	return nil
+}
+
+// paymentMovesAccount reports whether a payment moves money into (incoming) or out of the account.
+func paymentMovesAccount(payment *models.Payment, paymentAccountID uint, incoming bool) bool {
+	isSource := payment.PaymentAccountID == paymentAccountID
+	isTarget := payment.PaymentAccountToID != nil && *payment.PaymentAccountToID == paymentAccountID
+
+	if incoming {
+		return (isSource && payment.TypeID == models.PaymentTypeIncome) || isTarget
+	}
+
+	return isSource && payment.TypeID != models.PaymentTypeIncome
+}
+
+func namesSimilar(a, b string) bool {
+	tokensA := nameTokens(a)
+	tokensB := nameTokens(b)
+
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return false
+	}
+
+	joinedA := strings.Join(tokensA, " ")
+	joinedB := strings.Join(tokensB, " ")
+	if strings.Contains(joinedA, joinedB) || strings.Contains(joinedB, joinedA) {
+		return true
+	}
+
+	set := make(map[string]bool, len(tokensA))
+	for _, token := range tokensA {
+		set[token] = true
+	}
+
+	shared := 0
+	union := len(set)
+	for _, token := range tokensB {
+		if set[token] {
+			shared++
+			set[token] = false
+		} else if _, ok := set[token]; !ok {
+			union++
+		}
+	}
+
+	return float64(shared)/float64(union) >= 0.5
+}
+
+func nameTokens(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func selectImportRows(rows []models.PaymentImportRow, requested []int) ([]int, error) {
+	if requested == nil {
+		var selected []int
+		for _, row := range rows {
+			if !row.IsDuplicate {
+				selected = append(selected, row.Index)
+			}
+		}
+
+		if len(selected) == 0 {
+			return nil, errors.New("There are no rows to import, select the rows to import explicitly")
+		}
+
+		return selected, nil
+	}
+
+	if len(requested) == 0 {
+		return nil, errors.New("Select at least one row to import")
+	}
+
+	picked := make(map[int]bool, len(requested))
+	var selected []int
+
+	for _, index := range requested {
+		if index < 0 || index >= len(rows) {
+			return nil, fmt.Errorf("Row %d does not exist in this import", index)
+		}
+		if !picked[index] {
+			picked[index] = true
+			selected = append(selected, index)
+		}
+	}
+
+	return selected, nil
+}
+
+func validateImportMapping(payload *dto.PaymentImportMappingRequest) map[string][]string {
+	validationErrs := make(map[string][]string)
+
+	if strings.TrimSpace(payload.DateColumn) == "" {
+		validationErrs["date_column"] = []string{"The date column field is required"}
+	}
+
+	if strings.TrimSpace(payload.DateFormat) == "" {
+		validationErrs["date_format"] = []string{"The date format field is required, e.g. DD/MM/YYYY"}
+	} else if layout := statementDateLayout(payload.DateFormat); layout == strings.ToUpper(payload.DateFormat) {
+		validationErrs["date_format"] = []string{"The date format must use YYYY, MM and DD, e.g. DD/MM/YYYY"}
+	}
+
+	if strings.TrimSpace(payload.NameColumn) == "" {
+		validationErrs["name_column"] = []string{"The name column field is required"}
+	}
+
+	hasAmount := payload.AmountColumn != nil && *payload.AmountColumn != ""
+	hasDebitCredit := (payload.DebitColumn != nil && *payload.DebitColumn != "") || (payload.CreditColumn != nil && *payload.CreditColumn != "")
+	if !hasAmount && !hasDebitCredit {
+		validationErrs["amount_column"] = []string{"Either the amount column or the debit and credit columns are required"}
+	}
+
+	if len([]rune(payload.Delimiter)) > 1 {
+		validationErrs["delimiter"] = []string{"The delimiter must be a single character"}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
+
+func importMapping(userId uint, paymentAccountID uint, payload *dto.PaymentImportMappingRequest) *models.PaymentImportMapping {
+	delimiter := payload.Delimiter
+	if delimiter == "" {
+		delimiter = ","
+	}
+
+	return &models.PaymentImportMapping{
+		UserID:           userId,
+		PaymentAccountID: paymentAccountID,
+		Delimiter:        delimiter,
+		HasHeader:        payload.HasHeader == nil || *payload.HasHeader,
+		DateColumn:       strings.TrimSpace(payload.DateColumn),
+		DateFormat:       strings.ToUpper(strings.TrimSpace(payload.DateFormat)),
+		NameColumn:       strings.TrimSpace(payload.NameColumn),
+		AmountColumn:     payload.AmountColumn,
+		DebitColumn:      payload.DebitColumn,
+		CreditColumn:     payload.CreditColumn,
+	}
+}
+
+func importResponse(paymentImport *models.PaymentImport) dto.PaymentImportPreviewResponse {
+	response := dto.PaymentImportPreviewResponse{
+		ID:        paymentImport.ID,
+		Format:    paymentImport.Format,
+		FileName:  paymentImport.FileName,
+		Status:    paymentImport.Status,
+		TotalRows: len(paymentImport.Rows),
+		Rows:      paymentImport.Rows,
+	}
+
+	for _, row := range paymentImport.Rows {
+		if row.IsDuplicate {
+			response.DuplicateRows++
+		}
+		if row.PaymentID != nil {
+			response.ImportedRows++
+		}
+	}
+
+	return response
+}
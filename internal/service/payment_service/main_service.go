@@ -7,20 +7,32 @@ import (
 
 type MainService interface {
 	Store(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Destroy(c *fiber.Ctx) error
+	Publish(c *fiber.Ctx) error
 	GenerateReport(c *fiber.Ctx) error
+	Export(c *fiber.Ctx) error
 	AttachMultipleItems(c *fiber.Ctx) error
 }
 
 type mainService struct {
 	storeService          StoreService
+	updateService         UpdateService
+	destroyService        DestroyService
+	publishService        PublishService
 	generateReportService GenerateReportService
+	exportService         ExportService
 	attachItemsService    AttachItemsService
 }
 
 func NewMainService(db *gorm.DB) MainService {
 	return &mainService{
 		storeService:          NewStoreService(db),
-		generateReportService: NewGenerateReportService(),
+		updateService:         NewUpdateService(db),
+		destroyService:        NewDestroyService(db),
+		publishService:        NewPublishService(db),
+		generateReportService: NewGenerateReportService(db),
+		exportService:         NewExportService(db),
 		attachItemsService:    NewAttachItemsService(db),
 	}
 }
@@ -29,10 +41,26 @@ func (s *mainService) Store(c *fiber.Ctx) error {
 	return s.storeService.Store(c)
 }
 
+func (s *mainService) Update(c *fiber.Ctx) error {
+	return s.updateService.Update(c)
+}
+
+func (s *mainService) Destroy(c *fiber.Ctx) error {
+	return s.destroyService.Destroy(c)
+}
+
+func (s *mainService) Publish(c *fiber.Ctx) error {
+	return s.publishService.Publish(c)
+}
+
 func (s *mainService) GenerateReport(c *fiber.Ctx) error {
 	return s.generateReportService.GenerateReport(c)
 }
 
+func (s *mainService) Export(c *fiber.Ctx) error {
+	return s.exportService.Export(c)
+}
+
 func (s *mainService) AttachMultipleItems(c *fiber.Ctx) error {
 	return s.attachItemsService.AttachMultipleItems(c)
 }
@@ -0,0 +1,189 @@
+package payment_service
+
+import (
+	"errors"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const schedulerCauserName = "Scheduler"
+
+type PublishService interface {
+	Publish(c *fiber.Ctx) error
+	PublishDue() map[string]int
+}
+
+type publishService struct {
+	payment *repositories.PaymentRepository
+	user    *repositories.UserRepository
+	balance *balanceService
+	db      *gorm.DB
+}
+
+func NewPublishService(db *gorm.DB) PublishService {
+	return &publishService{
+		payment: repositories.NewPaymentRepository(db),
+		user:    repositories.NewUserRepository(db),
+		balance: newBalanceService(db),
+		db:      db,
+	}
+}
+
+// Publish turns a draft or scheduled payment into a real transaction on request.
+func (s *publishService) Publish(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	paymentID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	payment, err := s.payment.FindByID(paymentID)
+	if err != nil || !policies.Payment.Publish(userId, payment) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+	}
+
+	if err := s.publish(payment.ID, userId, userName); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	result, err := s.payment.FindByID(paymentID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+	}
+
+	return utils.SuccessResponse(c, "Payment published successfully", result)
+}
+
+// PublishDue books every scheduled (non draft) payment whose date has arrived.
+// Payments that cannot be booked, e.g. because of an insufficient balance, stay scheduled and record the failure.
+// They are retried with a growing delay, see models.Payment.NextPublishAttempt, until models.MaxPublishAttempts is
+// reached, and the user is emailed on the first failure.
+func (s *publishService) PublishDue() map[string]int {
+	results := map[string]int{
+		"total":     0,
+		"published": 0,
+		"skipped":   0,
+		"failed":    0,
+	}
+
+	now := time.Now()
+	today := now.Format("2006-01-02")
+
+	err := s.payment.ProcessDueScheduled(today, 50, func(payments []models.Payment) error {
+		for i := range payments {
+			payment := &payments[i]
+			results["total"]++
+
+			if next := payment.NextPublishAttempt(); next != nil && now.Before(*next) {
+				results["skipped"]++
+				continue
+			}
+
+			if err := s.publish(payment.ID, payment.UserID, schedulerCauserName); err != nil {
+				log.Printf("Failed to publish scheduled payment %s: %v\n", payment.Code, err)
+				results["failed"]++
+				s.recordFailure(payment, err)
+				continue
+			}
+
+			results["published"]++
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Println("Failed to process scheduled payments: ", err)
+	}
+
+	return results
+}
+
+// recordFailure counts the failed attempt on the payment and emails the user when it is the first one.
+func (s *publishService) recordFailure(payment *models.Payment, reason error) {
+	if err := s.payment.RecordPublishFailure(payment.ID, reason.Error()); err != nil {
+		log.Printf("Failed to record publish failure of payment %s: %v\n", payment.Code, err)
+		return
+	}
+
+	if payment.PublishAttempts == 0 {
+		s.sendFailureEmail(payment, reason)
+	}
+}
+
+func (s *publishService) sendFailureEmail(payment *models.Payment, reason error) {
+	user, err := s.user.FindByID(payment.UserID)
+	if err != nil {
+		log.Printf("Failed to find user %d of payment %s: %v\n", payment.UserID, payment.Code, err)
+		return
+	}
+
+	formatter := userFormatter(user)
+
+	name := payment.Code
+	if payment.Name != nil && *payment.Name != "" {
+		name = *payment.Name
+	}
+
+	amount := int64(0)
+	if payment.Amount != nil {
+		amount = *payment.Amount
+	}
+
+	err = utils.SendEmail(user.Email, "Scheduled payment "+name+" was not published", map[string]any{
+		"Name":        user.Name,
+		"PaymentName": name,
+		"Code":        payment.Code,
+		"Date":        formatter.Date(payment.Date),
+		"Amount":      formatter.Money(amount, payment.Currency),
+		"Reason":      reason.Error(),
+		"MaxAttempts": models.MaxPublishAttempts,
+	}, "resources/views/emails/main.html", "resources/views/emails/publish_failed.html")
+
+	if err != nil {
+		log.Printf("Failed to send publish failure email to user %d: %v\n", user.ID, err)
+	}
+}
+
+func (s *publishService) publish(paymentID uint, userId uint, userName string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		payment, err := s.payment.FindForUpdate(tx, paymentID)
+		if err != nil {
+			return errors.New("Payment not found")
+		}
+
+		if isBooked(payment) {
+			return errors.New("Payment has already been published")
+		}
+
+		if err := s.balance.Release(tx, userId, userName, payment); err != nil {
+			return err
+		}
+
+		if err := s.balance.Apply(tx, userId, userName, payment); err != nil {
+			return err
+		}
+
+		if err := s.payment.UpdateFields(tx, payment.ID, userId, userName, map[string]interface{}{
+			"is_scheduled":      false,
+			"is_draft":          false,
+			"publish_attempts":  0,
+			"publish_failed_at": nil,
+			"publish_error":     nil,
+		}); err != nil {
+			log.Println("Failed to publish payment: ", err)
+			return errors.New("Failed to publish payment, please try again")
+		}
+
+		return nil
+	})
+}
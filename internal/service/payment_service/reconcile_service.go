@@ -0,0 +1,270 @@
+package payment_service
+
+import (
+	"errors"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type ReconcileService interface {
+	Preview(c *fiber.Ctx) error
+	Reconcile(c *fiber.Ctx) error
+	History(c *fiber.Ctx) error
+}
+
+type reconcileService struct {
+	payment        *repositories.PaymentRepository
+	paymentAccount *repositories.PaymentAccountRepository
+	reconciliation *repositories.PaymentReconciliationRepository
+	store          *storeService
+	balance        *balanceService
+	db             *gorm.DB
+}
+
+func NewReconcileService(db *gorm.DB) ReconcileService {
+	return &reconcileService{
+		payment:        repositories.NewPaymentRepository(db),
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		reconciliation: repositories.NewPaymentReconciliationRepository(db),
+		store:          newStoreService(db),
+		balance:        newBalanceService(db),
+		db:             db,
+	}
+}
+
+// Preview compares the optional statement_balance query with the account balance and lists the payments
+// no reconciliation cleared yet.
+func (s *reconcileService) Preview(c *fiber.Ctx) error {
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	payments, err := s.payment.FindUnreconciledByAccount(paymentAccount.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve unreconciled payments")
+	}
+
+	response := dto.ReconciliationPreviewResponse{
+		PaymentAccountID:     paymentAccount.ID,
+		BookBalance:          paymentAccount.Deposit,
+		UnreconciledPayments: payments,
+	}
+
+	if last, err := s.reconciliation.Latest(paymentAccount.ID); err == nil {
+		response.LastReconciliation = last
+	}
+
+	if value := c.Query("statement_balance"); value != "" {
+		statementBalance, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return utils.ValidationError(c, map[string][]string{
+				"statement_balance": {"The statement balance field must be a number"},
+			})
+		}
+
+		difference := statementBalance - paymentAccount.Deposit
+		response.StatementBalance = &statementBalance
+		response.Difference = &difference
+	}
+
+	return utils.SuccessResponse(c, "Reconciliation preview retrieved successfully", response)
+}
+
+// Reconcile records the statement balance, clears the selected payments and, when asked, books an income or
+// expense adjustment for the difference between the statement and the account balance.
+func (s *reconcileService) Reconcile(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil || !policies.PaymentAccount.Update(userId, paymentAccount) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	var payload dto.ReconcileRequest
+
+	rules := govalidator.MapData{
+		"statement_balance": []string{"required", "numeric"},
+		"create_adjustment": []string{"bool"},
+		"date":              []string{"date:yyyy-mm-dd"},
+	}
+
+	errs := utils.ValidateJSON(c, &payload, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if !s.clearable(paymentAccount.ID, payload.ClearedPaymentIDs) {
+		return utils.ValidationError(c, map[string][]string{
+			"cleared_payment_ids": {"The selected payments must be unreconciled booked payments of this payment account"},
+		})
+	}
+
+	if payload.Date == "" {
+		payload.Date = time.Now().Format("2006-01-02")
+	}
+
+	var response dto.ReconcileResponse
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		current, err := s.paymentAccount.SelectForUpdate(tx, paymentAccount.ID, []string{"id", "user_id", "name", "deposit"})
+		if err != nil {
+			return errors.New("Payment account not found")
+		}
+
+		reconciliation := models.PaymentReconciliation{
+			UserID:           userId,
+			PaymentAccountID: paymentAccount.ID,
+			StatementBalance: payload.StatementBalance,
+			BookBalance:      current.Deposit,
+			Difference:       payload.StatementBalance - current.Deposit,
+			ClearedCount:     len(payload.ClearedPaymentIDs),
+		}
+
+		cleared := payload.ClearedPaymentIDs
+
+		if payload.CreateAdjustment && reconciliation.Difference != 0 {
+			adjustment, err := s.createAdjustment(tx, userId, userName, paymentAccount.ID, reconciliation.Difference, payload.Date)
+			if err != nil {
+				return err
+			}
+
+			reconciliation.AdjustmentPaymentID = &adjustment.ID
+			reconciliation.ClearedCount++
+			cleared = append(cleared, adjustment.ID)
+			response.AdjustmentPayment = adjustment
+		}
+
+		if err := s.reconciliation.Create(tx, &reconciliation); err != nil {
+			log.Println("Failed to create reconciliation: ", err)
+			return errors.New("Failed to reconcile payment account, please try again")
+		}
+
+		if err := s.payment.MarkReconciled(tx, cleared, reconciliation.ID); err != nil {
+			log.Println("Failed to clear payments: ", err)
+			return errors.New("Failed to clear payments, please try again")
+		}
+
+		response.Reconciliation = reconciliation
+		return nil
+	})
+
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return utils.SuccessResponse(c, "Payment account reconciled successfully", response)
+}
+
+// History lists the reconciliations of the account, newest first.
+func (s *reconcileService) History(c *fiber.Ctx) error {
+	paymentAccount, err := s.findPaymentAccount(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment account not found")
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	perPage, _ := strconv.Atoi(c.Query("per_page", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+
+	if perPage < 1 {
+		perPage = 10
+	} else if perPage > 100 {
+		perPage = 100
+	}
+
+	total, err := s.reconciliation.Count(paymentAccount.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to count reconciliations")
+	}
+
+	reconciliations, err := s.reconciliation.FindAllPaginated(paymentAccount.ID, page, perPage)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve reconciliations")
+	}
+
+	return utils.PaginatedSuccessResponse(c, "Reconciliations retrieved successfully", reconciliations, page, perPage, total, len(reconciliations))
+}
+
+func (s *reconcileService) createAdjustment(tx *gorm.DB, userId uint, userName string, paymentAccountID uint, difference int64, date string) (*models.Payment, error) {
+	name := "Reconciliation adjustment"
+	typeID := models.PaymentTypeIncome
+	amount := difference
+
+	if difference < 0 {
+		typeID = models.PaymentTypeExpense
+		amount = -difference
+	}
+
+	adjustment, err := s.store.createPayment(tx, userId, userName, &dto.StorePaymentRequest{
+		Name:             &name,
+		Amount:           &amount,
+		TypeID:           typeID,
+		Date:             date,
+		PaymentAccountID: paymentAccountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.balance.Apply(tx, userId, userName, adjustment); err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+// clearable reports whether every payment is an unreconciled booked payment of the account.
+func (s *reconcileService) clearable(paymentAccountID uint, paymentIDs []uint) bool {
+	if len(paymentIDs) == 0 {
+		return true
+	}
+
+	payments, err := s.payment.FindUnreconciledByAccount(paymentAccountID)
+	if err != nil {
+		return false
+	}
+
+	unreconciled := make(map[uint]bool, len(payments))
+	for _, payment := range payments {
+		unreconciled[payment.ID] = true
+	}
+
+	seen := make(map[uint]bool, len(paymentIDs))
+	for _, id := range paymentIDs {
+		if !unreconciled[id] || seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+
+	return true
+}
+
+func (s *reconcileService) findPaymentAccount(c *fiber.Ctx) (*models.PaymentAccount, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentAccount, err := s.paymentAccount.FindByID(uint(id))
+	if err != nil || !policies.PaymentAccount.View(c.Locals("user_id").(uint), paymentAccount) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return paymentAccount, nil
+}
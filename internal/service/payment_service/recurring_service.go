@@ -0,0 +1,708 @@
+package payment_service
+
+import (
+	"errors"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+// recurringLookaheadDays is how far ahead occurrences are materialized as scheduled payments,
+// so upcoming bills are visible before they are booked by the publisher.
+const recurringLookaheadDays = 7
+
+// recurringPaymentMaxLookup bounds how many upcoming occurrences are searched when an exception is saved.
+const recurringPaymentMaxLookup = 1000
+
+type RecurringService interface {
+	Store(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Destroy(c *fiber.Ctx) error
+	Occurrences(c *fiber.Ctx) error
+	StoreOccurrence(c *fiber.Ctx) error
+	DestroyOccurrence(c *fiber.Ctx) error
+	GenerateDue() map[string]int
+}
+
+type recurringService struct {
+	recurring      *repositories.RecurringPaymentRepository
+	payment        *repositories.PaymentRepository
+	paymentItem    *repositories.PaymentItemRepository
+	paymentAccount *repositories.PaymentAccountRepository
+	generate       *repositories.GenerateRepository
+	items          *attachItemsService
+	exchange       *exchangeService
+	db             *gorm.DB
+}
+
+func NewRecurringService(db *gorm.DB) RecurringService {
+	return &recurringService{
+		recurring:      repositories.NewRecurringPaymentRepository(db),
+		payment:        repositories.NewPaymentRepository(db),
+		paymentItem:    repositories.NewPaymentItemRepository(db),
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		generate:       repositories.NewGenerateRepository(db),
+		items:          newAttachItemsService(db),
+		exchange:       newExchangeService(db),
+		db:             db,
+	}
+}
+
+// Store creates a recurring payment rule, upcoming occurrences are generated as scheduled payments by GenerateDue.
+func (s *recurringService) Store(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	var request dto.StoreRecurringPaymentRequest
+
+	rules := govalidator.MapData{
+		"name":                  []string{"max:255"},
+		"amount":                []string{"numeric"},
+		"type_id":               []string{"required", "numeric"},
+		"payment_account_id":    []string{"required", "numeric"},
+		"payment_account_to_id": []string{"numeric"},
+		"frequency":             []string{"required", "in:daily,weekly,monthly,yearly"},
+		"interval":              []string{"numeric"},
+		"day_of_month":          []string{"numeric"},
+		"start_date":            []string{"required", "date:yyyy-mm-dd"},
+		"end_date":              []string{"date:yyyy-mm-dd"},
+		"count":                 []string{"numeric"},
+		"is_active":             []string{"bool"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if request.Interval == 0 {
+		request.Interval = 1
+	}
+
+	startDate, _ := time.ParseInLocation("2006-01-02", request.StartDate, time.Local)
+
+	recurringPayment := models.RecurringPayment{
+		UserID:             userID,
+		Name:               strings.TrimSpace(request.Name),
+		Amount:             request.Amount,
+		TypeID:             request.TypeID,
+		PaymentAccountID:   request.PaymentAccountID,
+		PaymentAccountToID: request.PaymentAccountToID,
+		Items:              recurringPaymentItems(request.Items),
+		Frequency:          request.Frequency,
+		Interval:           request.Interval,
+		DayOfMonth:         request.DayOfMonth,
+		StartDate:          startDate,
+		Count:              request.Count,
+		IsActive:           request.IsActive == nil || *request.IsActive,
+	}
+
+	if request.EndDate != nil {
+		endDate, _ := time.ParseInLocation("2006-01-02", *request.EndDate, time.Local)
+		recurringPayment.EndDate = &endDate
+	}
+
+	if validationErrs := s.validate(userID, &recurringPayment); validationErrs != nil {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	recurringPayment.AlignStartDate()
+	recurringPayment.NextDate = recurringPayment.NextOccurrence()
+
+	if err := s.recurring.Create(&recurringPayment); err != nil {
+		log.Println("Failed to create recurring payment: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to create recurring payment")
+	}
+
+	return utils.SuccessResponse(c, "Recurring payment created successfully", recurringPayment)
+}
+
+// Update changes the template, end date, count or active state of a rule, the schedule itself stays as it is.
+func (s *recurringService) Update(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	recurringPayment, err := s.find(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Recurring payment not found")
+	}
+
+	var request dto.UpdateRecurringPaymentRequest
+
+	rules := govalidator.MapData{
+		"name":                  []string{"max:255"},
+		"amount":                []string{"numeric"},
+		"type_id":               []string{"numeric"},
+		"payment_account_id":    []string{"numeric"},
+		"payment_account_to_id": []string{"numeric"},
+		"end_date":              []string{"date:yyyy-mm-dd"},
+		"count":                 []string{"numeric"},
+		"is_active":             []string{"bool"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	if request.Name != nil {
+		recurringPayment.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.Amount != nil {
+		recurringPayment.Amount = *request.Amount
+	}
+	if request.TypeID != 0 {
+		recurringPayment.TypeID = request.TypeID
+	}
+	if request.PaymentAccountID != 0 {
+		recurringPayment.PaymentAccountID = request.PaymentAccountID
+	}
+	if request.PaymentAccountToID != nil {
+		recurringPayment.PaymentAccountToID = request.PaymentAccountToID
+	}
+	if request.Items != nil {
+		recurringPayment.Items = recurringPaymentItems(request.Items)
+	}
+	if request.EndDate != nil {
+		endDate, _ := time.ParseInLocation("2006-01-02", *request.EndDate, time.Local)
+		recurringPayment.EndDate = &endDate
+	}
+	if request.Count != nil {
+		recurringPayment.Count = request.Count
+	}
+	if request.IsActive != nil {
+		recurringPayment.IsActive = *request.IsActive
+	}
+
+	if validationErrs := s.validate(userID, recurringPayment); validationErrs != nil {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	recurringPayment.NextDate = recurringPayment.NextOccurrence()
+
+	if err := s.recurring.Update(nil, recurringPayment); err != nil {
+		log.Println("Failed to update recurring payment: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to update recurring payment")
+	}
+
+	return utils.SuccessResponse(c, "Recurring payment updated successfully", recurringPayment)
+}
+
+// Destroy deletes a rule with the payments it generated that are still scheduled, booked payments are kept.
+func (s *recurringService) Destroy(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	recurringPayment, err := s.find(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Recurring payment not found")
+	}
+
+	occurrences, err := s.recurring.FindOccurrences(recurringPayment.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve recurring payment occurrences")
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, occurrence := range occurrences {
+			if occurrence.PaymentID == nil {
+				continue
+			}
+
+			payment, err := s.payment.FindByID(int(*occurrence.PaymentID))
+			if err != nil || !payment.IsScheduled || payment.IsDraft {
+				continue
+			}
+
+			if err := s.paymentItem.DeleteByPaymentID(tx, payment.ID); err != nil {
+				log.Println("Failed to delete payment items: ", err)
+				return errors.New("Failed to delete scheduled payments, please try again")
+			}
+
+			if err := s.payment.Delete(tx, payment, userID, userName); err != nil {
+				log.Println("Failed to delete payment: ", err)
+				return errors.New("Failed to delete scheduled payments, please try again")
+			}
+		}
+
+		return s.recurring.Delete(tx, recurringPayment.ID)
+	})
+
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	return utils.SimpleSuccessResponse(c, "Recurring payment deleted successfully")
+}
+
+// Occurrences previews the upcoming occurrences of a rule with their skips and overrides applied.
+func (s *recurringService) Occurrences(c *fiber.Ctx) error {
+	recurringPayment, err := s.find(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Recurring payment not found")
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 {
+		limit = 10
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	exceptions, err := s.recurring.FindOccurrences(recurringPayment.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve recurring payment occurrences")
+	}
+
+	exceptionsByDate := make(map[string]models.RecurringPaymentOccurrence, len(exceptions))
+	for _, exception := range exceptions {
+		exceptionsByDate[exception.Date.Format("2006-01-02")] = exception
+	}
+
+	result := []dto.RecurringPaymentOccurrenceResponse{}
+	currencies := map[uint]string{recurringPayment.PaymentAccountID: recurringPayment.Currency}
+
+	for _, date := range recurringPayment.Upcoming(limit) {
+		occurrence := dto.RecurringPaymentOccurrenceResponse{
+			Date:             date.Format("2006-01-02"),
+			Name:             recurringPayment.Name,
+			Amount:           recurringPayment.Amount,
+			Currency:         recurringPayment.Currency,
+			PaymentAccountID: recurringPayment.PaymentAccountID,
+		}
+
+		if exception, ok := exceptionsByDate[occurrence.Date]; ok {
+			occurrence.ExceptionID = &exception.ID
+			occurrence.Action = exception.Action
+			occurrence.PaymentID = exception.PaymentID
+
+			if exception.Name != nil {
+				occurrence.Name = *exception.Name
+			}
+			occurrence.Amount = recurringPayment.OccurrenceAmount(&exception)
+			if exception.PaymentAccountID != nil {
+				occurrence.PaymentAccountID = *exception.PaymentAccountID
+				if _, ok := currencies[occurrence.PaymentAccountID]; !ok {
+					currencies[occurrence.PaymentAccountID] = s.currencyOf(occurrence.PaymentAccountID)
+				}
+				occurrence.Currency = currencies[occurrence.PaymentAccountID]
+			}
+		}
+
+		result = append(result, occurrence)
+	}
+
+	return utils.SuccessResponse(c, "Recurring payment occurrences retrieved successfully", result)
+}
+
+// StoreOccurrence skips or overrides a single upcoming occurrence of a rule.
+func (s *recurringService) StoreOccurrence(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	recurringPayment, err := s.find(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Recurring payment not found")
+	}
+
+	var request dto.RecurringPaymentOccurrenceRequest
+
+	rules := govalidator.MapData{
+		"date":               []string{"required", "date:yyyy-mm-dd"},
+		"action":             []string{"required", "in:skip,override"},
+		"name":               []string{"max:255"},
+		"amount":             []string{"numeric"},
+		"payment_account_id": []string{"numeric"},
+	}
+
+	errs := utils.ValidateJSON(c, &request, rules)
+	if errs != nil {
+		return utils.ValidationError(c, errs)
+	}
+
+	date, _ := time.ParseInLocation("2006-01-02", request.Date, time.Local)
+
+	isUpcoming := false
+	for _, upcoming := range recurringPayment.Upcoming(recurringPaymentMaxLookup) {
+		if upcoming.Equal(date) {
+			isUpcoming = true
+			break
+		}
+	}
+
+	if !isUpcoming {
+		return utils.ValidationError(c, map[string][]string{
+			"date": {"The date must be an upcoming occurrence of this recurring payment"},
+		})
+	}
+
+	if validationErrs := s.validateOccurrence(userID, recurringPayment, &request); validationErrs != nil {
+		return utils.ValidationError(c, validationErrs)
+	}
+
+	occurrence, err := s.recurring.FindOccurrenceByDate(nil, recurringPayment.ID, date)
+	if err != nil {
+		occurrence = &models.RecurringPaymentOccurrence{
+			RecurringPaymentID: recurringPayment.ID,
+			Date:               date,
+		}
+	}
+
+	action := request.Action
+	occurrence.Action = &action
+	occurrence.Name = nil
+	occurrence.Amount = nil
+	occurrence.PaymentAccountID = nil
+
+	if action == models.RecurringOccurrenceOverride {
+		occurrence.Name = request.Name
+		occurrence.Amount = request.Amount
+		occurrence.PaymentAccountID = request.PaymentAccountID
+	}
+
+	if err := s.recurring.SaveOccurrence(nil, occurrence); err != nil {
+		log.Println("Failed to save recurring payment occurrence: ", err)
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to save recurring payment occurrence")
+	}
+
+	return utils.SuccessResponse(c, "Recurring payment occurrence saved successfully", occurrence)
+}
+
+// DestroyOccurrence removes a skip or override that was not generated yet.
+func (s *recurringService) DestroyOccurrence(c *fiber.Ctx) error {
+	recurringPayment, err := s.find(c)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Recurring payment not found")
+	}
+
+	occurrenceID, err := strconv.Atoi(c.Params("occurrenceId"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid occurrence ID")
+	}
+
+	occurrences, err := s.recurring.FindOccurrences(recurringPayment.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve recurring payment occurrences")
+	}
+
+	for _, occurrence := range occurrences {
+		if occurrence.ID != uint(occurrenceID) {
+			continue
+		}
+
+		if occurrence.GeneratedAt != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "This occurrence has already been generated")
+		}
+
+		if err := s.recurring.DeleteOccurrence(occurrence.ID); err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to delete recurring payment occurrence")
+		}
+
+		return utils.SimpleSuccessResponse(c, "Recurring payment occurrence deleted successfully")
+	}
+
+	return utils.ErrorResponse(c, fiber.StatusNotFound, "Recurring payment occurrence not found")
+}
+
+// GenerateDue materializes every occurrence within the lookahead window as a scheduled payment.
+// Skipped occurrences are consumed without creating a payment.
+func (s *recurringService) GenerateDue() map[string]int {
+	results := map[string]int{
+		"total":     0,
+		"generated": 0,
+		"skipped":   0,
+		"failed":    0,
+	}
+
+	now := time.Now()
+	horizon := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, recurringLookaheadDays)
+
+	err := s.recurring.ProcessDue(horizon, 50, func(recurringPayments []models.RecurringPayment) error {
+		for i := range recurringPayments {
+			recurringPayment := &recurringPayments[i]
+
+			for {
+				next := recurringPayment.NextOccurrence()
+				if next == nil || next.After(horizon) {
+					break
+				}
+
+				results["total"]++
+
+				skipped, err := s.generateOccurrence(recurringPayment, *next)
+				if err != nil {
+					log.Printf("Failed to generate recurring payment %d for %s: %v\n", recurringPayment.ID, next.Format("2006-01-02"), err)
+					results["failed"]++
+					break
+				}
+
+				if skipped {
+					results["skipped"]++
+				} else {
+					results["generated"]++
+				}
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Println("Failed to process recurring payments: ", err)
+	}
+
+	return results
+}
+
+func (s *recurringService) generateOccurrence(recurringPayment *models.RecurringPayment, date time.Time) (bool, error) {
+	skipped := false
+	generatedCount := recurringPayment.GeneratedCount
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		occurrence, err := s.recurring.FindOccurrenceByDate(tx, recurringPayment.ID, date)
+		if err != nil {
+			occurrence = &models.RecurringPaymentOccurrence{
+				RecurringPaymentID: recurringPayment.ID,
+				Date:               date,
+			}
+		}
+
+		if occurrence.Action != nil && *occurrence.Action == models.RecurringOccurrenceSkip {
+			skipped = true
+		} else if occurrence.PaymentID == nil {
+			payment, err := s.createPayment(tx, recurringPayment, occurrence)
+			if err != nil {
+				return err
+			}
+			occurrence.PaymentID = &payment.ID
+		}
+
+		now := time.Now()
+		occurrence.GeneratedAt = &now
+
+		if err := s.recurring.SaveOccurrence(tx, occurrence); err != nil {
+			log.Println("Failed to save recurring payment occurrence: ", err)
+			return errors.New("Failed to save recurring payment occurrence")
+		}
+
+		recurringPayment.GeneratedCount++
+		recurringPayment.NextDate = recurringPayment.NextOccurrence()
+
+		return s.recurring.UpdateProgress(tx, recurringPayment)
+	})
+
+	if err != nil {
+		recurringPayment.GeneratedCount = generatedCount
+		return false, err
+	}
+
+	return skipped, nil
+}
+
+func (s *recurringService) createPayment(tx *gorm.DB, recurringPayment *models.RecurringPayment, occurrence *models.RecurringPaymentOccurrence) (*models.Payment, error) {
+	name := recurringPayment.Name
+	amount := recurringPayment.OccurrenceAmount(occurrence)
+	paymentAccountID := recurringPayment.PaymentAccountID
+
+	if occurrence.Name != nil {
+		name = *occurrence.Name
+	}
+	if occurrence.PaymentAccountID != nil {
+		paymentAccountID = *occurrence.PaymentAccountID
+	}
+
+	payment := &models.Payment{
+		UserID:             recurringPayment.UserID,
+		Code:               s.generate.GetCode("payment", true),
+		Name:               &name,
+		Date:               occurrence.Date,
+		Amount:             &amount,
+		TypeID:             recurringPayment.TypeID,
+		PaymentAccountID:   paymentAccountID,
+		PaymentAccountToID: recurringPayment.PaymentAccountToID,
+		HasItems:           len(recurringPayment.Items) > 0,
+		IsScheduled:        true,
+	}
+
+	// The payment takes the currency of its account, transfers between currencies also the converted amount.
+	if err := s.exchange.Resolve(tx, payment, nil); err != nil {
+		return nil, err
+	}
+
+	payment, err := s.payment.Create(tx, recurringPayment.UserID, schedulerCauserName, payment)
+	if err != nil {
+		log.Println("Failed to create recurring payment: ", err)
+		return nil, errors.New("Failed to create payment")
+	}
+
+	if len(recurringPayment.Items) == 0 {
+		return payment, nil
+	}
+
+	items := make([]dto.AttachMultipleItemsItem, 0, len(recurringPayment.Items))
+	for _, item := range recurringPayment.Items {
+		items = append(items, dto.AttachMultipleItemsItem{
+			ItemID: item.ItemID,
+			Name:   item.Name,
+			Amount: item.Amount,
+			Qty:    item.Qty,
+		})
+	}
+
+	paymentItems, _, err := s.items.resolveAndCreateItems(tx, payment.ID, items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.paymentItem.CreateBatch(tx, paymentItems); err != nil {
+		return nil, errors.New("Failed to attach items")
+	}
+
+	return payment, nil
+}
+
+func (s *recurringService) find(c *fiber.Ctx) (*models.RecurringPayment, error) {
+	userID := c.Locals("user_id").(uint)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.recurring.FindByID(id, userID)
+}
+
+func (s *recurringService) validate(userID uint, recurringPayment *models.RecurringPayment) map[string][]string {
+	validationErrs := make(map[string][]string)
+
+	if recurringPayment.TypeID < models.PaymentTypeExpense || recurringPayment.TypeID > models.PaymentTypeWithdrawal {
+		validationErrs["type_id"] = []string{"The selected type is invalid"}
+	}
+
+	if len(recurringPayment.Items) > 0 {
+		if recurringPayment.TypeID != models.PaymentTypeExpense {
+			validationErrs["type_id"] = []string{"Only expense payments can have items attached"}
+		}
+
+		for i, item := range recurringPayment.Items {
+			prefix := "items." + strconv.Itoa(i) + "."
+			if strings.TrimSpace(item.Name) == "" {
+				validationErrs[prefix+"name"] = []string{"The name field is required"}
+			}
+			if item.Qty < 1 {
+				validationErrs[prefix+"qty"] = []string{"The qty field must be at least 1"}
+			}
+			if item.Amount < 0 {
+				validationErrs[prefix+"amount"] = []string{"The amount field must be a positive number"}
+			}
+		}
+		recurringPayment.Amount = recurringPayment.ItemsTotal()
+	} else if recurringPayment.Amount < 1 {
+		validationErrs["amount"] = []string{"This field is required when the payment has no items", "This field must be greater than 0"}
+	}
+
+	if recurringPayment.Name == "" {
+		validationErrs["name"] = []string{"This field is required"}
+	}
+
+	if recurringPayment.TypeID == models.PaymentTypeTransfer || recurringPayment.TypeID == models.PaymentTypeWithdrawal {
+		if recurringPayment.PaymentAccountToID == nil {
+			validationErrs["payment_account_to_id"] = []string{"This field is required when the category is transfer or widrawal."}
+		} else if !policies.PaymentAccount.UseByID(s.paymentAccount, userID, recurringPayment.PaymentAccountToID) {
+			validationErrs["payment_account_to_id"] = []string{"The selected payment account is invalid"}
+		}
+	} else {
+		// Only transfers and withdrawals move money to another account, changing the type away from them clears it.
+		recurringPayment.PaymentAccountToID = nil
+	}
+
+	if !policies.PaymentAccount.UseByID(s.paymentAccount, userID, &recurringPayment.PaymentAccountID) {
+		validationErrs["payment_account_id"] = []string{"The selected payment account is invalid"}
+	} else {
+		recurringPayment.Currency = s.currencyOf(recurringPayment.PaymentAccountID)
+	}
+
+	if recurringPayment.Interval < 1 {
+		validationErrs["interval"] = []string{"The interval must be at least 1"}
+	}
+
+	if recurringPayment.DayOfMonth != nil {
+		if recurringPayment.Frequency != models.RecurringFrequencyMonthly {
+			validationErrs["day_of_month"] = []string{"The day of month can only be set for monthly payments"}
+		} else if *recurringPayment.DayOfMonth < 1 || *recurringPayment.DayOfMonth > 31 {
+			validationErrs["day_of_month"] = []string{"The day of month must be between 1 and 31"}
+		}
+	}
+
+	if recurringPayment.EndDate != nil && recurringPayment.EndDate.Before(recurringPayment.StartDate) {
+		validationErrs["end_date"] = []string{"The end date must be after the start date"}
+	}
+
+	if recurringPayment.Count != nil && *recurringPayment.Count < 1 {
+		validationErrs["count"] = []string{"The count must be at least 1"}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
+
+func (s *recurringService) validateOccurrence(userID uint, recurringPayment *models.RecurringPayment, request *dto.RecurringPaymentOccurrenceRequest) map[string][]string {
+	if request.Action != models.RecurringOccurrenceOverride {
+		return nil
+	}
+
+	validationErrs := make(map[string][]string)
+
+	if request.Name == nil && request.Amount == nil && request.PaymentAccountID == nil {
+		validationErrs["action"] = []string{"An override needs a name, amount or payment account"}
+	}
+
+	if request.Amount != nil {
+		if len(recurringPayment.Items) > 0 {
+			validationErrs["amount"] = []string{"The amount of a recurring payment with items cannot be overridden"}
+		} else if *request.Amount < 1 {
+			validationErrs["amount"] = []string{"This field must be greater than 0"}
+		}
+	}
+
+	if !policies.PaymentAccount.UseByID(s.paymentAccount, userID, request.PaymentAccountID) {
+		validationErrs["payment_account_id"] = []string{"The selected payment account is invalid"}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
+
+func (s *recurringService) currencyOf(paymentAccountID uint) string {
+	paymentAccount, err := s.paymentAccount.SelectByID(nil, paymentAccountID, []string{"id", "currency"})
+	if err != nil || paymentAccount.Currency == "" {
+		return utils.DefaultCurrency
+	}
+	return paymentAccount.Currency
+}
+
+func recurringPaymentItems(items []dto.AttachMultipleItemsItem) []models.RecurringPaymentItem {
+	result := make([]models.RecurringPaymentItem, 0, len(items))
+
+	for _, item := range items {
+		result = append(result, models.RecurringPaymentItem{
+			ItemID: item.ItemID,
+			Name:   strings.TrimSpace(item.Name),
+			Amount: item.Amount,
+			Qty:    item.Qty,
+		})
+	}
+
+	return result
+}
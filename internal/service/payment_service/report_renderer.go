@@ -0,0 +1,244 @@
+package payment_service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"golang-api/internal/models"
+	"golang-api/pkg/utils"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+type reportColumn struct {
+	title string
+	width float64
+	align string
+}
+
+var reportPaymentColumns = []reportColumn{
+	{"No", 10, "C"},
+	{"Date", 24, "L"},
+	{"Code", 30, "L"},
+	{"Name", 56, "L"},
+	{"Type", 20, "L"},
+	{"Account", 25, "L"},
+	{"Amount", 25, "R"},
+}
+
+var reportItemColumns = []reportColumn{
+	{"Payment", 30, "L"},
+	{"Item", 80, "L"},
+	{"Qty", 15, "C"},
+	{"Price", 32, "R"},
+	{"Total", 33, "R"},
+}
+
+var reportAccountColumns = []reportColumn{
+	{"Account", 130, "L"},
+	{"Balance", 60, "R"},
+}
+
+func renderReportPDF(report *paymentReport, path string) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetMargins(10, 12, 10)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 6, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 9, "Payment Report", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr("Period: "+reportPeriodLabel(report)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, tr("Generated for "+report.UserName+" on "+utils.FormatDateID(report.GeneratedAt, "2 January 2006, 15.04 WIB")), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	reportPDFSection(pdf, "Summary")
+	var summary [][2]string
+	for _, totals := range report.Totals {
+		// Only a report with payments in several currencies labels the totals with their currency.
+		suffix := ""
+		if len(report.Totals) > 1 {
+			suffix = " (" + totals.Currency + ")"
+		}
+
+		summary = append(summary,
+			[2]string{"Income" + suffix, utils.FormatMoney(totals.Income, totals.Currency)},
+			[2]string{"Expense" + suffix, utils.FormatMoney(totals.Expense, totals.Currency)},
+			[2]string{"Transfer" + suffix, utils.FormatMoney(totals.Transfer, totals.Currency)},
+			[2]string{"Withdrawal" + suffix, utils.FormatMoney(totals.Withdrawal, totals.Currency)},
+			[2]string{"Net (income - expense)" + suffix, utils.FormatMoney(totals.Income-totals.Expense, totals.Currency)},
+		)
+	}
+	summary = append(summary, [2]string{"Transactions", strconv.Itoa(len(report.Payments))})
+	pdf.SetFont("Helvetica", "", 9)
+	for _, row := range summary {
+		pdf.CellFormat(130, 6, row[0], "1", 0, "L", false, 0, "")
+		pdf.CellFormat(60, 6, row[1], "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	reportPDFSection(pdf, "Transactions")
+	reportPDFHeader(pdf, reportPaymentColumns)
+	if len(report.Payments) == 0 {
+		pdf.CellFormat(0, 6, "No transactions in this period", "1", 1, "C", false, 0, "")
+	}
+	for i, payment := range report.Payments {
+		reportPDFRow(pdf, tr, reportPaymentColumns, []string{
+			strconv.Itoa(i + 1),
+			payment.Date.Format("2006-01-02"),
+			payment.Code,
+			reportPaymentName(&payment),
+			reportPaymentType(&payment),
+			reportAccountName(payment.PaymentAccount),
+			utils.FormatMoney(paymentAmount(&payment), payment.Currency),
+		})
+	}
+	pdf.Ln(4)
+
+	if len(report.Items) > 0 {
+		reportPDFSection(pdf, "Items")
+		reportPDFHeader(pdf, reportItemColumns)
+		for _, payment := range report.Payments {
+			for _, paymentItem := range report.Items[payment.ID] {
+				reportPDFRow(pdf, tr, reportItemColumns, []string{
+					payment.Code,
+					reportItemName(&paymentItem),
+					strconv.Itoa(paymentItem.Quantity),
+					utils.FormatMoney(paymentItem.Price, payment.Currency),
+					utils.FormatMoney(paymentItem.Total, payment.Currency),
+				})
+			}
+		}
+		pdf.Ln(4)
+	}
+
+	reportPDFSection(pdf, "Account Balances")
+	reportPDFHeader(pdf, reportAccountColumns)
+	for _, account := range report.Accounts {
+		reportPDFRow(pdf, tr, reportAccountColumns, []string{
+			account.Name,
+			utils.FormatMoney(account.Deposit, account.Currency),
+		})
+	}
+
+	return pdf.OutputFileAndClose(path)
+}
+
+func reportPDFSection(pdf *gofpdf.Fpdf, title string) {
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 7, title, "", 1, "L", false, 0, "")
+}
+
+func reportPDFHeader(pdf *gofpdf.Fpdf, columns []reportColumn) {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for _, column := range columns {
+		pdf.CellFormat(column.width, 7, column.title, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont("Helvetica", "", 9)
+}
+
+func reportPDFRow(pdf *gofpdf.Fpdf, tr func(string) string, columns []reportColumn, values []string) {
+	for i, column := range columns {
+		pdf.CellFormat(column.width, 6, reportFitText(pdf, tr(values[i]), column.width-2), "1", 0, column.align, false, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// reportFitText shortens text with an ellipsis so it fits inside a table cell.
+func reportFitText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "..."
+}
+
+func renderReportCSV(report *paymentReport, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+
+	writer.Write([]string{"Date", "Code", "Name", "Type", "Account", "Account To", "Amount", "Currency", "Items"})
+
+	for _, payment := range report.Payments {
+		var items []string
+		for _, paymentItem := range report.Items[payment.ID] {
+			items = append(items, fmt.Sprintf("%s (x%d)", reportItemName(&paymentItem), paymentItem.Quantity))
+		}
+
+		writer.Write([]string{
+			payment.Date.Format("2006-01-02"),
+			payment.Code,
+			reportPaymentName(&payment),
+			reportPaymentType(&payment),
+			reportAccountName(payment.PaymentAccount),
+			reportAccountName(payment.PaymentAccountTo),
+			strconv.FormatInt(paymentAmount(&payment), 10),
+			utils.GetCurrency(payment.Currency).Code,
+			strings.Join(items, "; "),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func reportPeriodLabel(report *paymentReport) string {
+	start := utils.FormatDateID(report.StartDate, "2 January 2006")
+	if report.StartDate.Equal(report.EndDate) {
+		return start
+	}
+	return start + " - " + utils.FormatDateID(report.EndDate, "2 January 2006")
+}
+
+func reportPaymentName(payment *models.Payment) string {
+	if payment.Name == nil {
+		return ""
+	}
+	return *payment.Name
+}
+
+func reportPaymentType(payment *models.Payment) string {
+	if payment.PaymentType == nil {
+		return ""
+	}
+	return payment.PaymentType.Name
+}
+
+func reportAccountName(account *models.PaymentAccount) string {
+	if account == nil {
+		return ""
+	}
+	return account.Name
+}
+
+func reportItemName(paymentItem *models.PaymentItem) string {
+	if paymentItem.Item == nil {
+		return paymentItem.ItemCode
+	}
+	return paymentItem.Item.Name
+}
@@ -0,0 +1,383 @@
+package payment_service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"golang-api/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statementLine is a bank statement transaction, Amount is negative for money leaving the account.
+type statementLine struct {
+	Date      time.Time
+	Name      string
+	Amount    int64
+	Reference string
+}
+
+var (
+	ofxTransactionRegex = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+	ofxFieldRegex       = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+)
+
+func parseCSVStatement(data []byte, mapping *models.PaymentImportMapping, decimals int) ([]statementLine, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	if mapping.Delimiter != "" {
+		reader.Comma = []rune(mapping.Delimiter)[0]
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.New("The CSV file could not be read: " + err.Error())
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("The CSV file is empty")
+	}
+
+	var header []string
+	if mapping.HasHeader {
+		header = records[0]
+		records = records[1:]
+	}
+
+	dateIndex, err := csvColumnIndex(header, mapping.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	nameIndex, err := csvColumnIndex(header, mapping.NameColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	amountIndex, debitIndex, creditIndex := -1, -1, -1
+
+	if mapping.AmountColumn != nil && *mapping.AmountColumn != "" {
+		if amountIndex, err = csvColumnIndex(header, *mapping.AmountColumn); err != nil {
+			return nil, err
+		}
+	} else {
+		if mapping.DebitColumn != nil && *mapping.DebitColumn != "" {
+			if debitIndex, err = csvColumnIndex(header, *mapping.DebitColumn); err != nil {
+				return nil, err
+			}
+		}
+		if mapping.CreditColumn != nil && *mapping.CreditColumn != "" {
+			if creditIndex, err = csvColumnIndex(header, *mapping.CreditColumn); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	layout := statementDateLayout(mapping.DateFormat)
+	var lines []statementLine
+
+	for i, record := range records {
+		if csvRecordEmpty(record) {
+			continue
+		}
+
+		row := i + 1
+		if mapping.HasHeader {
+			row++
+		}
+
+		date, err := time.ParseInLocation(layout, csvField(record, dateIndex), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("Row %d: the date %q does not match the format %s", row, csvField(record, dateIndex), mapping.DateFormat)
+		}
+
+		var amount int64
+
+		if amountIndex >= 0 {
+			if amount, err = parseStatementAmount(csvField(record, amountIndex), decimals); err != nil {
+				return nil, fmt.Errorf("Row %d: %s", row, err.Error())
+			}
+		} else {
+			debit, err := parseStatementAmount(csvField(record, debitIndex), decimals)
+			if err != nil {
+				return nil, fmt.Errorf("Row %d: %s", row, err.Error())
+			}
+
+			credit, err := parseStatementAmount(csvField(record, creditIndex), decimals)
+			if err != nil {
+				return nil, fmt.Errorf("Row %d: %s", row, err.Error())
+			}
+
+			amount = absAmount(credit) - absAmount(debit)
+		}
+
+		lines = append(lines, statementLine{
+			Date:   date,
+			Name:   strings.TrimSpace(csvField(record, nameIndex)),
+			Amount: amount,
+		})
+	}
+
+	return lines, nil
+}
+
+func parseOFXStatement(data []byte, decimals int) ([]statementLine, error) {
+	matches := ofxTransactionRegex.FindAllSubmatch(data, -1)
+	if len(matches) == 0 {
+		return nil, errors.New("No transactions were found in the OFX file")
+	}
+
+	var lines []statementLine
+
+	for i, match := range matches {
+		fields := make(map[string]string)
+		for _, field := range ofxFieldRegex.FindAllSubmatch(match[1], -1) {
+			fields[strings.ToUpper(string(field[1]))] = strings.TrimSpace(string(field[2]))
+		}
+
+		posted := fields["DTPOSTED"]
+		if len(posted) < 8 {
+			return nil, fmt.Errorf("Transaction %d: the posted date is missing", i+1)
+		}
+
+		date, err := time.ParseInLocation("20060102", posted[:8], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("Transaction %d: the posted date %q is invalid", i+1, posted)
+		}
+
+		amount, err := parseStatementAmount(fields["TRNAMT"], decimals)
+		if err != nil {
+			return nil, fmt.Errorf("Transaction %d: %s", i+1, err.Error())
+		}
+
+		name := fields["NAME"]
+		if name == "" {
+			name = fields["MEMO"]
+		} else if fields["MEMO"] != "" && !strings.EqualFold(fields["MEMO"], name) {
+			name = name + " - " + fields["MEMO"]
+		}
+
+		lines = append(lines, statementLine{
+			Date:      date,
+			Name:      name,
+			Amount:    amount,
+			Reference: fields["FITID"],
+		})
+	}
+
+	return lines, nil
+}
+
+func parseQIFStatement(data []byte, dateFormat string, decimals int) ([]statementLine, error) {
+	layout := statementDateLayout(dateFormat)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	var lines []statementLine
+	var current statementLine
+	var hasDate, hasAmount bool
+	var payee, memo string
+
+	flush := func() error {
+		if !hasDate && !hasAmount {
+			return nil
+		}
+		if !hasDate || !hasAmount {
+			return fmt.Errorf("Transaction %d: the date and amount are required", len(lines)+1)
+		}
+
+		current.Name = payee
+		if current.Name == "" {
+			current.Name = memo
+		}
+
+		lines = append(lines, current)
+		current = statementLine{}
+		hasDate, hasAmount = false, false
+		payee, memo = "", ""
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		value := strings.TrimSpace(line[1:])
+
+		switch line[0] {
+		case 'D':
+			date, err := time.ParseInLocation(layout, strings.ReplaceAll(value, "'", "/"), time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("Transaction %d: the date %q does not match the format %s", len(lines)+1, value, dateFormat)
+			}
+			current.Date = date
+			hasDate = true
+		case 'T', 'U':
+			amount, err := parseStatementAmount(value, decimals)
+			if err != nil {
+				return nil, fmt.Errorf("Transaction %d: %s", len(lines)+1, err.Error())
+			}
+			current.Amount = amount
+			hasAmount = true
+		case 'P':
+			payee = value
+		case 'M':
+			memo = value
+		case 'N':
+			current.Reference = value
+		case '^':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New("The QIF file could not be read")
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("No transactions were found in the QIF file")
+	}
+
+	return lines, nil
+}
+
+// parseStatementAmount parses amounts written with either "." or "," as the decimal separator,
+// e.g. "1.250.000,00", "-1,250,000.00", "(15000)" or "Rp 15.000", into minor units of a currency with the given
+// number of decimals. Finer fractions are rounded, to whole rupiah for IDR.
+func parseStatementAmount(value string, decimals int) (int64, error) {
+	original := value
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return 0, nil
+	}
+
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = strings.Trim(value, "()")
+	}
+
+	var cleaned strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == ',':
+			cleaned.WriteRune(r)
+		case r == '-':
+			negative = !negative
+		}
+	}
+	value = cleaned.String()
+
+	if value == "" {
+		return 0, fmt.Errorf("the amount %q is invalid", original)
+	}
+
+	decimalSeparator := ""
+	lastDot := strings.LastIndex(value, ".")
+	lastComma := strings.LastIndex(value, ",")
+
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastDot > lastComma {
+			decimalSeparator = "."
+		} else {
+			decimalSeparator = ","
+		}
+	case lastDot >= 0 && strings.Count(value, ".") == 1 && len(value)-lastDot-1 != 3:
+		decimalSeparator = "."
+	case lastComma >= 0 && strings.Count(value, ",") == 1 && len(value)-lastComma-1 != 3:
+		decimalSeparator = ","
+	}
+
+	integerPart, fractionPart := value, ""
+	if decimalSeparator != "" {
+		index := strings.LastIndex(value, decimalSeparator)
+		integerPart, fractionPart = value[:index], value[index+1:]
+	}
+
+	integerPart = strings.NewReplacer(".", "", ",", "").Replace(integerPart)
+	if integerPart == "" {
+		integerPart = "0"
+	}
+
+	amount, err := strconv.ParseInt(integerPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("the amount %q is invalid", original)
+	}
+
+	for i := 0; i < decimals; i++ {
+		digit := int64(0)
+		if i < len(fractionPart) {
+			digit = int64(fractionPart[i] - '0')
+		}
+		amount = amount*10 + digit
+	}
+
+	if len(fractionPart) > decimals && fractionPart[decimals] >= '5' {
+		amount++
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// statementDateLayout converts a date format such as "DD/MM/YYYY" into a Go time layout.
+// Days and months accept one or two digits, since many banks do not pad them.
+func statementDateLayout(format string) string {
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "1", "DD", "2").Replace(strings.ToUpper(format))
+}
+
+func csvColumnIndex(header []string, column string) (int, error) {
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")), strings.TrimSpace(column)) {
+			return i, nil
+		}
+	}
+
+	index, err := strconv.Atoi(column)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("The column %q was not found in the CSV file", column)
+	}
+
+	return index, nil
+}
+
+func csvField(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+func csvRecordEmpty(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func absAmount(amount int64) int64 {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
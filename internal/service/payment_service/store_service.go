@@ -4,6 +4,7 @@ import (
 	"errors"
 	"golang-api/internal/dto"
 	"golang-api/internal/models"
+	"golang-api/internal/policies"
 	"golang-api/internal/repositories"
 	"golang-api/pkg/utils"
 	"log"
@@ -23,15 +24,27 @@ type storeService struct {
 	paymentAccount *repositories.PaymentAccountRepository
 	generate       *repositories.GenerateRepository
 	activityLog    *repositories.ActivityLogRepository
+	balance        *balanceService
+	exchange       *exchangeService
+	classify       *classifyService
+	budget         *budgetService
 	db             *gorm.DB
 }
 
 func NewStoreService(db *gorm.DB) StoreService {
+	return newStoreService(db)
+}
+
+func newStoreService(db *gorm.DB) *storeService {
 	return &storeService{
 		payment:        repositories.NewPaymentRepository(db),
 		paymentAccount: repositories.NewPaymentAccountRepository(db),
 		generate:       repositories.NewGenerateRepository(db),
 		activityLog:    repositories.NewActivityLogRepository(db),
+		balance:        newBalanceService(db),
+		exchange:       newExchangeService(db),
+		classify:       newClassifyService(db),
+		budget:         newBudgetService(db),
 		db:             db,
 	}
 }
@@ -56,13 +69,8 @@ func (s *storeService) Store(c *fiber.Ctx) error {
 			return err
 		}
 
-		draft := false
-		if payload.IsDraft || payload.IsScheduled {
-			draft = true
-		}
-
-		if draft == false {
-			return s.updateBalances(tx, userId, userName, &payload)
+		if isBooked(result) {
+			return s.balance.Apply(tx, userId, userName, result)
 		}
 
 		return nil
@@ -72,6 +80,8 @@ func (s *storeService) Store(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
 	}
 
+	go s.budget.Alert(result)
+
 	return utils.SuccessResponse(c, "Payment created successfully", result)
 }
 
@@ -88,7 +98,7 @@ func (s *storeService) createPayment(tx *gorm.DB, userId uint, userName string,
 	code := s.generate.GetCode("payment", true)
 	date, _ := time.Parse("2006-01-02", payload.Date)
 
-	payment, err := s.payment.Create(tx, userId, userName, &models.Payment{
+	payment := &models.Payment{
 		UserID:             userId,
 		Code:               code,
 		Name:               payload.Name,
@@ -100,101 +110,33 @@ func (s *storeService) createPayment(tx *gorm.DB, userId uint, userName string,
 		HasItems:           payload.HasItems,
 		IsScheduled:        payload.IsScheduled,
 		IsDraft:            payload.IsDraft,
-	})
-
-	if err != nil {
-		log.Println("Failed to create payment: ", err)
-		return nil, errors.New("Failed to create payment, please try again")
+		CategoryID:         categoryID(payload.CategoryID),
 	}
 
-	return payment, nil
-}
-
-func (s *storeService) updateBalances(tx *gorm.DB, userId uint, userName string, payload *dto.StorePaymentRequest) error {
-	switch payload.TypeID {
-	case models.PaymentTypeExpense, models.PaymentTypeIncome:
-		return s.handleIncomeOrExpense(tx, userId, userName, payload)
-	case models.PaymentTypeTransfer, models.PaymentTypeWithdrawal:
-		return s.handleTransferOrWithdrawal(tx, userId, userName, payload)
+	if err := s.exchange.Resolve(tx, payment, payload.AmountTo); err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-func (s *storeService) handleIncomeOrExpense(tx *gorm.DB, userId uint, userName string, payload *dto.StorePaymentRequest) error {
-	paymentAccount, err := s.paymentAccount.SelectByID(tx, payload.PaymentAccountID, []string{"id", "user_id", "name", "deposit"})
+	payment, err := s.payment.Create(tx, userId, userName, payment)
 
 	if err != nil {
-		return errors.New("Payment account not found")
+		log.Println("Failed to create payment: ", err)
+		return nil, errors.New("Failed to create payment, please try again")
 	}
 
-	depositChange := paymentAccount.Deposit
-
-	if payload.TypeID == models.PaymentTypeExpense {
-		if *payload.Amount > depositChange {
-			return errors.New("Insufficient balance for this payment account (e01)")
+	if len(payload.Tags) > 0 {
+		if err := s.classify.Tag(tx, userId, payment, payload.Tags); err != nil {
+			return nil, err
 		}
-		depositChange -= *payload.Amount
-	} else {
-		depositChange += *payload.Amount
-	}
-
-	_, err = s.paymentAccount.Update(tx, userId, userName, &models.PaymentAccount{
-		ID:      payload.PaymentAccountID,
-		Deposit: depositChange,
-	}, paymentAccount)
-
-	if err != nil {
-		return errors.New("Failed to update payment account, please try again")
-	}
-
-	return nil
-}
-
-func (s *storeService) handleTransferOrWithdrawal(tx *gorm.DB, userId uint, userName string, payload *dto.StorePaymentRequest) error {
-	paymentAccount, err := s.paymentAccount.SelectByID(tx, payload.PaymentAccountID, []string{"id", "user_id", "name", "deposit"})
-	if err != nil {
-		return errors.New("Payment account not found")
-	}
-
-	paymentAccountTo, err := s.paymentAccount.SelectByID(tx, *payload.PaymentAccountToID, []string{"id", "user_id", "name", "deposit"})
-	if err != nil {
-		return errors.New("Payment account destination not found")
-	}
-
-	balanceOrigin := paymentAccount.Deposit
-	balanceTo := paymentAccountTo.Deposit
-
-	if balanceOrigin < *payload.Amount {
-		return errors.New("Insufficient balance for this payment account (e02)")
-	}
-
-	balanceOrigin -= *payload.Amount
-	balanceTo += *payload.Amount
-
-	_, err = s.paymentAccount.Update(tx, userId, userName, &models.PaymentAccount{
-		ID:      payload.PaymentAccountID,
-		Deposit: balanceOrigin,
-	}, paymentAccount)
-
-	if err != nil {
-		return errors.New("Failed to update payment account, please try again")
-	}
-
-	_, err = s.paymentAccount.Update(tx, userId, userName, &models.PaymentAccount{
-		ID:      *payload.PaymentAccountToID,
-		Deposit: balanceTo,
-	}, paymentAccountTo)
-
-	if err != nil {
-		return errors.New("Failed to update payment account destination, please try again")
 	}
 
-	return nil
+	return payment, nil
 }
 
 func (s *storeService) validate(c *fiber.Ctx, payload *dto.StorePaymentRequest) map[string][]string {
 	rules := govalidator.MapData{
 		"amount":                []string{"numeric"},
+		"amount_to":             []string{"numeric"},
 		"date":                  []string{"required", "date:yyyy-mm-dd"},
 		"name":                  []string{"max:255"},
 		"type_id":               []string{"required", "numeric"},
@@ -204,6 +146,7 @@ func (s *storeService) validate(c *fiber.Ctx, payload *dto.StorePaymentRequest)
 		"is_scheduled":          []string{"bool"},
 		"is_draft":              []string{"bool"},
 		"request_view":          []string{"bool"},
+		"category_id":           []string{"numeric"},
 	}
 
 	errs := utils.ValidateJSON(c, payload, rules)
@@ -226,9 +169,17 @@ func (s *storeService) validate(c *fiber.Ctx, payload *dto.StorePaymentRequest)
 	if payload.TypeID == 3 || payload.TypeID == 4 {
 		if payload.PaymentAccountToID == nil {
 			validationErrs["payment_account_to_id"] = []string{"This field is required when the category is transfer or widrawal."}
+		} else if !policies.PaymentAccount.UseByID(s.paymentAccount, c.Locals("user_id").(uint), payload.PaymentAccountToID) {
+			validationErrs["payment_account_to_id"] = []string{"The selected payment account is invalid"}
 		}
 	}
 
+	if !policies.PaymentAccount.UseByID(s.paymentAccount, c.Locals("user_id").(uint), &payload.PaymentAccountID) {
+		validationErrs["payment_account_id"] = []string{"The selected payment account is invalid"}
+	}
+
+	s.classify.validate(c.Locals("user_id").(uint), payload.CategoryID, payload.Tags, validationErrs)
+
 	if len(validationErrs) > 0 {
 		return validationErrs
 	}
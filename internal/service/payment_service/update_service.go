@@ -0,0 +1,249 @@
+package payment_service
+
+import (
+	"errors"
+	"golang-api/internal/dto"
+	"golang-api/internal/models"
+	"golang-api/internal/policies"
+	"golang-api/internal/repositories"
+	"golang-api/pkg/utils"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/thedevsaddam/govalidator"
+	"gorm.io/gorm"
+)
+
+type UpdateService interface {
+	Update(c *fiber.Ctx) error
+}
+
+type updateService struct {
+	payment        *repositories.PaymentRepository
+	paymentAccount *repositories.PaymentAccountRepository
+	reconciliation *repositories.PaymentReconciliationRepository
+	balance        *balanceService
+	exchange       *exchangeService
+	classify       *classifyService
+	goal           *goalContributionService
+	db             *gorm.DB
+}
+
+func NewUpdateService(db *gorm.DB) UpdateService {
+	return &updateService{
+		payment:        repositories.NewPaymentRepository(db),
+		paymentAccount: repositories.NewPaymentAccountRepository(db),
+		reconciliation: repositories.NewPaymentReconciliationRepository(db),
+		balance:        newBalanceService(db),
+		exchange:       newExchangeService(db),
+		classify:       newClassifyService(db),
+		goal:           newGoalContributionService(db),
+		db:             db,
+	}
+}
+
+func (s *updateService) Update(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(uint)
+	userName := c.Locals("user_name").(string)
+
+	paymentID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid payment ID")
+	}
+
+	payment, err := s.payment.FindByID(paymentID)
+	if err != nil || !policies.Payment.Update(userId, payment) {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+	}
+
+	var payload dto.UpdatePaymentRequest
+
+	validateErrors := s.validate(c, payment, &payload)
+	if validateErrors != nil {
+		return utils.ValidationError(c, validateErrors)
+	}
+
+	updated := s.buildPayment(payment, &payload)
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.exchange.Resolve(tx, updated, s.amountTo(payment, updated, &payload)); err != nil {
+			return err
+		}
+
+		if isBooked(payment) {
+			if err := s.balance.Revert(tx, userId, userName, payment); err != nil {
+				return err
+			}
+
+			if err := s.balance.Apply(tx, userId, userName, updated); err != nil {
+				return err
+			}
+		} else if err := s.balance.Release(tx, userId, userName, payment); err != nil {
+			return err
+		}
+
+		if err := s.payment.UpdateFields(tx, payment.ID, userId, userName, map[string]interface{}{
+			"name":                  updated.Name,
+			"amount":                updated.Amount,
+			"currency":              updated.Currency,
+			"amount_to":             updated.AmountTo,
+			"exchange_rate":         updated.ExchangeRate,
+			"date":                  updated.Date,
+			"type_id":               updated.TypeID,
+			"payment_account_id":    updated.PaymentAccountID,
+			"payment_account_to_id": updated.PaymentAccountToID,
+			"category_id":           updated.CategoryID,
+			// An edited payment gets a fresh set of attempts from the scheduler.
+			"publish_attempts":  0,
+			"publish_failed_at": nil,
+			"publish_error":     nil,
+		}); err != nil {
+			log.Println("Failed to update payment: ", err)
+			return errors.New("Failed to update payment, please try again")
+		}
+
+		if payload.Tags != nil {
+			if err := s.classify.Tag(tx, userId, updated, *payload.Tags); err != nil {
+				return err
+			}
+		}
+
+		if err := s.goal.Revise(tx, userId, userName, payment.ID, paymentAmount(updated), false); err != nil {
+			return err
+		}
+
+		if payment.IsReconciled() {
+			if err := s.reconciliation.Flag(tx, *payment.ReconciliationID); err != nil {
+				log.Println("Failed to flag reconciliation: ", err)
+				return errors.New("Failed to update payment, please try again")
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	result, err := s.payment.FindByID(paymentID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Payment not found")
+	}
+
+	if payment.IsReconciled() {
+		return utils.SuccessResponse(c, "Payment updated successfully, the reconciliation it was cleared in has been flagged", result)
+	}
+
+	return utils.SuccessResponse(c, "Payment updated successfully", result)
+}
+
+// buildPayment returns a copy of the stored payment with the requested changes applied,
+// so the new balance effect can be computed before anything is written.
+func (s *updateService) buildPayment(payment *models.Payment, payload *dto.UpdatePaymentRequest) *models.Payment {
+	updated := *payment
+	updated.Date, _ = time.Parse("2006-01-02", payload.Date)
+	updated.TypeID = payload.TypeID
+	updated.PaymentAccountID = payload.PaymentAccountID
+	updated.PaymentAccountToID = nil
+
+	if payload.TypeID == models.PaymentTypeTransfer || payload.TypeID == models.PaymentTypeWithdrawal {
+		updated.PaymentAccountToID = payload.PaymentAccountToID
+	}
+
+	if payload.Name != nil {
+		updated.Name = payload.Name
+	}
+
+	if !payment.HasItems {
+		updated.Amount = payload.Amount
+	}
+
+	if payload.CategoryID != nil {
+		updated.CategoryID = categoryID(payload.CategoryID)
+	}
+
+	return &updated
+}
+
+// amountTo returns the destination amount to keep for a cross-currency transfer. Without a new amount_to the
+// stored one is kept as long as the amount and the accounts did not change, so editing the name or date does not
+// convert the payment again.
+func (s *updateService) amountTo(payment *models.Payment, updated *models.Payment, payload *dto.UpdatePaymentRequest) *int64 {
+	if payload.AmountTo != nil {
+		return payload.AmountTo
+	}
+
+	unchanged := paymentAmount(payment) == paymentAmount(updated) &&
+		payment.PaymentAccountID == updated.PaymentAccountID &&
+		payment.PaymentAccountToID != nil && updated.PaymentAccountToID != nil &&
+		*payment.PaymentAccountToID == *updated.PaymentAccountToID
+
+	if unchanged {
+		return payment.AmountTo
+	}
+
+	return nil
+}
+
+func (s *updateService) validate(c *fiber.Ctx, payment *models.Payment, payload *dto.UpdatePaymentRequest) map[string][]string {
+	rules := govalidator.MapData{
+		"amount":                []string{"numeric"},
+		"amount_to":             []string{"numeric"},
+		"date":                  []string{"required", "date:yyyy-mm-dd"},
+		"name":                  []string{"max:255"},
+		"type_id":               []string{"required", "numeric"},
+		"payment_account_id":    []string{"required", "numeric"},
+		"payment_account_to_id": []string{"numeric"},
+		"category_id":           []string{"numeric"},
+	}
+
+	errs := utils.ValidateJSON(c, payload, rules)
+	if errs != nil {
+		return errs
+	}
+
+	validationErrs := make(map[string][]string)
+
+	if payment.HasItems {
+		if payload.TypeID != models.PaymentTypeExpense {
+			validationErrs["type_id"] = []string{"Only expense payments can have items attached"}
+		}
+	} else {
+		if payload.Amount == nil || *payload.Amount < 1 {
+			validationErrs["amount"] = []string{"This field is required when the payment has no items", "This field must be greater than 0"}
+		}
+
+		if payload.Name == nil || *payload.Name == "" {
+			validationErrs["name"] = []string{"This field is required when the payment has no items"}
+		}
+	}
+
+	if payload.TypeID == models.PaymentTypeTransfer || payload.TypeID == models.PaymentTypeWithdrawal {
+		if payload.PaymentAccountToID == nil {
+			validationErrs["payment_account_to_id"] = []string{"This field is required when the category is transfer or widrawal."}
+		} else if *payload.PaymentAccountToID == payload.PaymentAccountID {
+			validationErrs["payment_account_to_id"] = []string{"The destination account must be different from the origin account."}
+		} else if !policies.PaymentAccount.UseByID(s.paymentAccount, payment.UserID, payload.PaymentAccountToID) {
+			validationErrs["payment_account_to_id"] = []string{"The selected payment account is invalid"}
+		}
+	}
+
+	if !policies.PaymentAccount.UseByID(s.paymentAccount, payment.UserID, &payload.PaymentAccountID) {
+		validationErrs["payment_account_id"] = []string{"The selected payment account is invalid"}
+	}
+
+	var tags []string
+	if payload.Tags != nil {
+		tags = *payload.Tags
+	}
+	s.classify.validate(payment.UserID, payload.CategoryID, tags, validationErrs)
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+ * Project Name: tokencache
+ * File: cache.go
+ * Created Date: Sunday October 18th 2026
+ *
+ * Author: Nova Ardiansyah admin@novaardiansyah.id
+ * Website: https://novaardiansyah.id
+ * MIT License: https://github.com/novaardiansyah/golang-api/blob/main/LICENSE
+ *
+ * Copyright (c) 2026 Nova Ardiansyah, Org
+ */
+
+// Package tokencache keeps validated access tokens in memory so the auth middleware does not hit the database
+// on every request, and batches the last_used_at writes of those tokens.
+package tokencache
+
+import (
+	"sync"
+	"time"
+
+	"golang-api/internal/models"
+)
+
+// Entry is a validated access token together with the user fields the auth middleware needs.
+type Entry struct {
+	Token        models.PersonalAccessToken
+	UserName     string
+	UserRole     string
+	UserLocale   *string
+	UserTimezone *string
+}
+
+type cachedEntry struct {
+	entry       Entry
+	hashedToken string
+	expiresAt   time.Time
+}
+
+// Cache is an in-process TTL cache of validated access tokens keyed by token ID.
+// Tokens revoked by another process (the main app) stay usable until their entry expires.
+type Cache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[uint64]cachedEntry
+}
+
+// Tokens is the cache used by the auth middleware, its TTL is set from config on startup.
+var Tokens = NewCache(time.Minute)
+
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		entries: make(map[uint64]cachedEntry),
+	}
+}
+
+func (c *Cache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Get returns the entry of the token when it is cached, not expired and the hashed token matches.
+func (c *Cache) Get(id uint64, hashedToken string) (*Entry, bool) {
+	c.mu.RLock()
+	cached, ok := c.entries[id]
+	c.mu.RUnlock()
+
+	if !ok || cached.hashedToken != hashedToken || time.Now().After(cached.expiresAt) {
+		return nil, false
+	}
+
+	return &cached.entry, true
+}
+
+func (c *Cache) Put(id uint64, hashedToken string, entry Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[id] = cachedEntry{
+		entry:       entry,
+		hashedToken: hashedToken,
+		expiresAt:   time.Now().Add(c.ttl),
+	}
+}
+
+// Forget drops the given tokens, it is called whenever a token is deleted or changed.
+func (c *Cache) Forget(ids ...uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		delete(c.entries, uint64(id))
+	}
+}
+
+// ForgetUser drops every token of the given users, it is called when their tokens are revoked or the user changes.
+func (c *Cache) ForgetUser(userIDs ...uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, cached := range c.entries {
+		for _, userID := range userIDs {
+			if cached.entry.Token.TokenableID == userID {
+				delete(c.entries, id)
+				break
+			}
+		}
+	}
+}
+
+// Sweep removes the expired entries and returns how many were removed.
+func (c *Cache) Sweep() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	removed := 0
+
+	for id, cached := range c.entries {
+		if now.After(cached.expiresAt) {
+			delete(c.entries, id)
+			removed++
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,82 @@
+package tokencache
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"golang-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// LastUsedWriter collects the tokens used since the last flush and writes their last_used_at in one query,
+// so a busy token costs at most one UPDATE per flush interval instead of one per request.
+type LastUsedWriter struct {
+	mu      sync.Mutex
+	pending map[uint]struct{}
+}
+
+// LastUsed is the writer fed by the auth middleware.
+var LastUsed = NewLastUsedWriter()
+
+func NewLastUsedWriter() *LastUsedWriter {
+	return &LastUsedWriter{
+		pending: make(map[uint]struct{}),
+	}
+}
+
+func (w *LastUsedWriter) Touch(tokenID uint) {
+	w.mu.Lock()
+	w.pending[tokenID] = struct{}{}
+	w.mu.Unlock()
+}
+
+// Flush writes the pending tokens and returns how many were written. Tokens that fail to write are retried on the next flush.
+func (w *LastUsedWriter) Flush(db *gorm.DB) int {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = make(map[uint]struct{})
+	w.mu.Unlock()
+
+	if len(pending) == 0 {
+		return 0
+	}
+
+	ids := make([]uint, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+
+	err := db.Model(&models.PersonalAccessToken{}).
+		Where("id IN ?", ids).
+		Update("last_used_at", time.Now()).Error
+
+	if err != nil {
+		log.Println("Failed to write token last_used_at: ", err)
+
+		w.mu.Lock()
+		for _, id := range ids {
+			w.pending[id] = struct{}{}
+		}
+		w.mu.Unlock()
+
+		return 0
+	}
+
+	return len(ids)
+}
+
+// Start flushes the writer and sweeps the token cache every interval until the process exits.
+// It runs in every API process, whether or not the scheduler is enabled, because the pending writes live in memory.
+func Start(db *gorm.DB, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			LastUsed.Flush(db)
+			Tokens.Sweep()
+		}
+	}()
+}
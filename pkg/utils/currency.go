@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 )
 
@@ -72,3 +74,77 @@ func maskNumbers(s string) string {
 func FormatPercent(value int) string {
 	return fmt.Sprintf("%d%%", value)
 }
+
+// DefaultCurrency is the currency of accounts and payments that do not name one, amounts of every currency
+// are stored as int64 in its minor unit.
+const DefaultCurrency = "IDR"
+
+type Currency struct {
+	Code        string
+	Symbol      string
+	Decimals    int
+	Thousands   string
+	DecimalMark string
+}
+
+var Currencies = map[string]Currency{
+	"IDR": {Code: "IDR", Symbol: "Rp", Decimals: 0, Thousands: ".", DecimalMark: ","},
+	"USD": {Code: "USD", Symbol: "$", Decimals: 2, Thousands: ",", DecimalMark: "."},
+	"SGD": {Code: "SGD", Symbol: "S$", Decimals: 2, Thousands: ",", DecimalMark: "."},
+	"EUR": {Code: "EUR", Symbol: "€", Decimals: 2, Thousands: ".", DecimalMark: ","},
+	"MYR": {Code: "MYR", Symbol: "RM", Decimals: 2, Thousands: ",", DecimalMark: "."},
+	"JPY": {Code: "JPY", Symbol: "¥", Decimals: 0, Thousands: ",", DecimalMark: "."},
+}
+
+// CurrencyCodes returns the supported currency codes, comma separated for validation rules.
+func CurrencyCodes() string {
+	codes := make([]string, 0, len(Currencies))
+	for code := range Currencies {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return strings.Join(codes, ",")
+}
+
+// GetCurrency returns the currency for the code, falling back to the default currency for unknown codes.
+func GetCurrency(code string) Currency {
+	if currency, ok := Currencies[strings.ToUpper(code)]; ok {
+		return currency
+	}
+	return Currencies[DefaultCurrency]
+}
+
+// FormatMoney formats an amount in minor units of the given currency with the separators of the currency, e.g.
+// 123456 USD as $1,234.56. Responses are formatted with the separators of the request locale, see Formatter.
+func FormatMoney(amount int64, code string) string {
+	currency := GetCurrency(code)
+	if currency.Code == DefaultCurrency {
+		return FormatRupiah(amount)
+	}
+
+	return formatMoney(amount, currency, currency.Thousands, currency.DecimalMark)
+}
+
+func formatMoney(amount int64, currency Currency, thousands string, decimalMark string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	scale := int64(math.Pow10(currency.Decimals))
+	whole := strings.ReplaceAll(formatWithDots(amount/scale), ".", thousands)
+
+	if currency.Decimals == 0 {
+		return sign + currency.Symbol + whole
+	}
+
+	return fmt.Sprintf("%s%s%s%s%0*d", sign, currency.Symbol, whole, decimalMark, currency.Decimals, amount%scale)
+}
+
+// ConvertMoney converts an amount in minor units of one currency into minor units of another, where rate is
+// the price of one major unit of from in major units of to. The result is rounded to the nearest minor unit.
+func ConvertMoney(amount int64, from string, to string, rate float64) int64 {
+	major := float64(amount) / math.Pow10(GetCurrency(from).Decimals)
+	return int64(math.Round(major * rate * math.Pow10(GetCurrency(to).Decimals)))
+}
@@ -46,8 +46,12 @@ var shortMonthNamesID = map[string]string{
 }
 
 func FormatDateID(t time.Time, format string) string {
-	result := t.Format(format)
+	return translateID(t.Format(format))
+}
 
+// translateID replaces the English day and month names of a formatted date with Indonesian ones, full names first
+// so "August" does not turn into "Agtust".
+func translateID(result string) string {
 	for en, id := range dayNamesID {
 		result = strings.ReplaceAll(result, en, id)
 	}
@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"golang-api/internal/config"
+	"strconv"
+	"strings"
+)
+
+// errInvalidPayload is returned for values that were not encrypted with the APP_KEY.
+var errInvalidPayload = errors.New("the encrypted value is invalid")
+
+// encryptedPayload is the payload of Laravel's encrypter, so values encrypted here are read by the main application's
+// decrypt() and the other way around.
+type encryptedPayload struct {
+	IV    string `json:"iv"`
+	Value string `json:"value"`
+	MAC   string `json:"mac"`
+	Tag   string `json:"tag"`
+}
+
+// Encrypt encrypts the value like Laravel's encrypt() with the default AES-256-CBC cipher: the PHP serialized value is
+// encrypted with APP_KEY and signed with an HMAC of the IV and ciphertext.
+func Encrypt(value string) (string, error) {
+	block, err := appCipher()
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	plain := pad([]byte(serializeString(value)))
+	ciphertext := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plain)
+
+	payload := encryptedPayload{
+		IV:    base64.StdEncoding.EncodeToString(iv),
+		Value: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+	payload.MAC = payloadMAC(payload)
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(encoded), nil
+}
+
+// Decrypt reads a value encrypted by Encrypt or by Laravel's encrypt().
+func Decrypt(value string) (string, error) {
+	block, err := appCipher()
+	if err != nil {
+		return "", err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", errInvalidPayload
+	}
+
+	var payload encryptedPayload
+	if err := json.Unmarshal(decoded, &payload); err != nil {
+		return "", errInvalidPayload
+	}
+
+	if !hmac.Equal([]byte(payloadMAC(payload)), []byte(payload.MAC)) {
+		return "", errInvalidPayload
+	}
+
+	iv, err := base64.StdEncoding.DecodeString(payload.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return "", errInvalidPayload
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(payload.Value)
+	if err != nil || len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return "", errInvalidPayload
+	}
+
+	plain := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, ciphertext)
+
+	plain, err = unpad(plain)
+	if err != nil {
+		return "", err
+	}
+
+	return unserializeString(string(plain)), nil
+}
+
+func appCipher() (cipher.Block, error) {
+	if len(config.AppKey) == 0 {
+		return nil, ErrMissingAppKey
+	}
+
+	if len(config.AppKey) != 32 {
+		return nil, errors.New("APP_KEY must be 32 bytes to encrypt values for AES-256-CBC")
+	}
+
+	return aes.NewCipher(config.AppKey)
+}
+
+// payloadMAC is the hex encoded HMAC-SHA256 Laravel signs the base64 IV and value with.
+func payloadMAC(payload encryptedPayload) string {
+	mac := hmac.New(sha256.New, config.AppKey)
+	mac.Write([]byte(payload.IV + payload.Value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func pad(data []byte) []byte {
+	padding := aes.BlockSize - len(data)%aes.BlockSize
+	return append(data, bytes.Repeat([]byte{byte(padding)}, padding)...)
+}
+
+func unpad(data []byte) ([]byte, error) {
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > aes.BlockSize || padding > len(data) {
+		return nil, errInvalidPayload
+	}
+
+	for _, b := range data[len(data)-padding:] {
+		if int(b) != padding {
+			return nil, errInvalidPayload
+		}
+	}
+
+	return data[:len(data)-padding], nil
+}
+
+// serializeString is PHP's serialize() of a string, which encrypt() applies before encrypting.
+func serializeString(value string) string {
+	return "s:" + strconv.Itoa(len(value)) + ":\"" + value + "\";"
+}
+
+// unserializeString undoes serializeString, values encrypted without serializing are returned as they are.
+func unserializeString(value string) string {
+	rest, ok := strings.CutPrefix(value, "s:")
+	if !ok {
+		return value
+	}
+
+	length, rest, ok := strings.Cut(rest, ":\"")
+	if !ok {
+		return value
+	}
+
+	n, err := strconv.Atoi(length)
+	if err != nil || n < 0 || len(rest) != n+2 || rest[n:] != "\";" {
+		return value
+	}
+
+	return rest[:n]
+}
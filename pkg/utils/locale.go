@@ -0,0 +1,356 @@
+package utils
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultLocale and DefaultTimezone are used for requests without a user preference or a supported
+// Accept-Language, and for output that is not tied to a request such as reports, exports and emails.
+const (
+	DefaultLocale   = "id-ID"
+	DefaultTimezone = "Asia/Jakarta"
+)
+
+type Locale struct {
+	Tag             string
+	Thousands       string
+	DecimalMark     string
+	DateLayout      string
+	DateTimeLayout  string
+	ShortDateLayout string
+
+	// translate replaces the English day and month names Go formats dates with, nil for English locales.
+	translate func(string) string
+}
+
+var Locales = map[string]Locale{
+	"id-ID": {
+		Tag:             "id-ID",
+		Thousands:       ".",
+		DecimalMark:     ",",
+		DateLayout:      "Monday, 2 Jan 2006",
+		DateTimeLayout:  "Monday, 2 Jan 2006, 15.04 MST",
+		ShortDateLayout: "02/01/2006",
+		translate:       translateID,
+	},
+	"en-US": {
+		Tag:             "en-US",
+		Thousands:       ",",
+		DecimalMark:     ".",
+		DateLayout:      "Monday, Jan 2, 2006",
+		DateTimeLayout:  "Monday, Jan 2, 2006, 3:04 PM MST",
+		ShortDateLayout: "01/02/2006",
+	},
+	"en-GB": {
+		Tag:             "en-GB",
+		Thousands:       ",",
+		DecimalMark:     ".",
+		DateLayout:      "Monday, 2 Jan 2006",
+		DateTimeLayout:  "Monday, 2 Jan 2006, 15:04 MST",
+		ShortDateLayout: "02/01/2006",
+	},
+}
+
+// languageLocales maps a bare language of Accept-Language to the locale used for it.
+var languageLocales = map[string]string{
+	"id": "id-ID",
+	"en": "en-US",
+}
+
+// LocaleCodes returns the supported locale tags, comma separated for validation rules.
+func LocaleCodes() string {
+	tags := make([]string, 0, len(Locales))
+	for tag := range Locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return strings.Join(tags, ",")
+}
+
+// FindLocale returns the supported locale matching the tag case-insensitively, accepting "_" as separator.
+func FindLocale(tag string) (Locale, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+
+	for key, locale := range Locales {
+		if strings.EqualFold(key, tag) {
+			return locale, true
+		}
+	}
+
+	return Locale{}, false
+}
+
+// GetLocale returns the locale for the tag, falling back to the default locale for unsupported tags.
+func GetLocale(tag string) Locale {
+	if locale, ok := FindLocale(tag); ok {
+		return locale
+	}
+	return Locales[DefaultLocale]
+}
+
+// NegotiateLocale picks the supported locale with the highest quality from an Accept-Language header, e.g.
+// "en-GB,en;q=0.8". A language without a supported region falls back to the locale of that language.
+// It returns an empty string when no language of the header is supported.
+func NegotiateLocale(acceptLanguage string) string {
+	best := ""
+	bestQuality := 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		quality := 1.0
+
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if value, ok := strings.CutPrefix(param, "q="); ok {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		if tag == "" || quality <= 0 || quality <= bestQuality {
+			continue
+		}
+
+		locale, ok := FindLocale(tag)
+		if !ok {
+			language, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+			fallback, found := languageLocales[strings.ToLower(language)]
+			if !found {
+				continue
+			}
+			locale = Locales[fallback]
+		}
+
+		best = locale.Tag
+		bestQuality = quality
+	}
+
+	return best
+}
+
+var locations sync.Map
+
+// LoadTimezone returns the location of an IANA timezone name, caching the loaded locations.
+func LoadTimezone(name string) (*time.Location, error) {
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	locations.Store(name, location)
+	return location, nil
+}
+
+// Formatter renders money and dates for one locale and timezone.
+type Formatter struct {
+	Locale   Locale
+	Location *time.Location
+}
+
+// NewFormatter returns a formatter for the locale and timezone, unsupported values fall back to the defaults.
+func NewFormatter(tag string, timezone string) *Formatter {
+	location, err := LoadTimezone(timezone)
+	if err != nil {
+		location, err = LoadTimezone(DefaultTimezone)
+		if err != nil {
+			location = time.FixedZone("WIB", 7*60*60)
+		}
+	}
+
+	return &Formatter{Locale: GetLocale(tag), Location: location}
+}
+
+// DefaultFormatter formats like the API did before locales were selectable: id-ID in Asia/Jakarta.
+func DefaultFormatter() *Formatter {
+	return NewFormatter(DefaultLocale, DefaultTimezone)
+}
+
+// FormatterFromContext returns the formatter of the request, built from the "locale" and "timezone" locals set
+// by the locale and auth middleware.
+func FormatterFromContext(c *fiber.Ctx) *Formatter {
+	if formatter, ok := c.Locals("formatter").(*Formatter); ok {
+		return formatter
+	}
+
+	tag, _ := c.Locals("locale").(string)
+	timezone, _ := c.Locals("timezone").(string)
+
+	if tag == "" {
+		tag = DefaultLocale
+	}
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+
+	formatter := NewFormatter(tag, timezone)
+	c.Locals("formatter", formatter)
+
+	return formatter
+}
+
+// Money formats an amount in minor units of the currency with the separators of the locale.
+func (f *Formatter) Money(amount int64, code string) string {
+	return formatMoney(amount, GetCurrency(code), f.Locale.Thousands, f.Locale.DecimalMark)
+}
+
+// Date formats a calendar date, it is not converted to the formatter timezone so the day never shifts.
+func (f *Formatter) Date(t time.Time) string {
+	return f.format(t, f.Locale.DateLayout)
+}
+
+// ShortDate formats a calendar date in the numeric form of the locale, e.g. 31/12/2026 or 12/31/2026.
+func (f *Formatter) ShortDate(t time.Time) string {
+	return f.format(t, f.Locale.ShortDateLayout)
+}
+
+// DateTime formats an instant in the formatter timezone.
+func (f *Formatter) DateTime(t time.Time) string {
+	return f.format(t.In(f.Location), f.Locale.DateTimeLayout)
+}
+
+func (f *Formatter) format(t time.Time, layout string) string {
+	result := t.Format(layout)
+	if f.Locale.translate != nil {
+		result = f.Locale.translate(result)
+	}
+	return result
+}
+
+// Localizable is implemented by response values with formatted_* fields, Localize fills them for the locale
+// and timezone of the request.
+type Localizable interface {
+	Localize(f *Formatter)
+}
+
+// maxLocalizeDepth bounds the walk over nested response values.
+const maxLocalizeDepth = 16
+
+// Localize calls Localize on every Localizable value found in data, following pointers, slices, arrays, maps
+// and exported struct fields that are not hidden from JSON. Values that cannot be changed in place, such as a
+// struct passed by value, are copied, so the returned value must be used instead of data.
+func Localize(data interface{}, f *Formatter) interface{} {
+	if data == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(data)
+	holder := reflect.New(value.Type()).Elem()
+	holder.Set(value)
+
+	localizer := localizer{formatter: f, visited: make(map[uintptr]bool)}
+	localizer.walk(holder, 0)
+
+	return holder.Interface()
+}
+
+type localizer struct {
+	formatter *Formatter
+	visited   map[uintptr]bool
+}
+
+var localizableType = reflect.TypeOf((*Localizable)(nil)).Elem()
+
+// walk localizes an addressable value.
+func (l *localizer) walk(value reflect.Value, depth int) {
+	if depth > maxLocalizeDepth || !mayLocalize(value.Type()) {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() || l.visited[value.Pointer()] {
+			return
+		}
+		l.visited[value.Pointer()] = true
+		l.walk(value.Elem(), depth+1)
+
+	case reflect.Interface:
+		if value.IsNil() || !mayLocalize(value.Elem().Type()) {
+			return
+		}
+		copied := reflect.New(value.Elem().Type()).Elem()
+		copied.Set(value.Elem())
+		l.walk(copied, depth+1)
+		value.Set(copied)
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			l.walk(value.Index(i), depth+1)
+		}
+
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			copied := reflect.New(iter.Value().Type()).Elem()
+			copied.Set(iter.Value())
+			l.walk(copied, depth+1)
+			value.SetMapIndex(iter.Key(), copied)
+		}
+
+	case reflect.Struct:
+		fields := value.Type()
+		for i := 0; i < fields.NumField(); i++ {
+			field := fields.Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			l.walk(value.Field(i), depth+1)
+		}
+
+		if value.CanAddr() && value.Addr().Type().Implements(localizableType) {
+			value.Addr().Interface().(Localizable).Localize(l.formatter)
+		}
+	}
+}
+
+var localizeCache sync.Map
+
+// mayLocalize reports whether values of the type can hold a Localizable value, so large slices of plain values
+// and types like time.Time are skipped.
+func mayLocalize(t reflect.Type) bool {
+	if cached, ok := localizeCache.Load(t); ok {
+		return cached.(bool)
+	}
+
+	// Guard against recursive types while the answer is computed.
+	localizeCache.Store(t, true)
+	result := computeMayLocalize(t)
+	localizeCache.Store(t, result)
+
+	return result
+}
+
+func computeMayLocalize(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return mayLocalize(t.Elem())
+	case reflect.Map:
+		return mayLocalize(t.Elem())
+	case reflect.Struct:
+		if reflect.PointerTo(t).Implements(localizableType) {
+			return true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.IsExported() && field.Tag.Get("json") != "-" && mayLocalize(field.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -51,7 +51,7 @@ func SuccessResponse(c *fiber.Ctx, message string, data interface{}) error {
 	return c.Status(fiber.StatusOK).JSON(Response{
 		Success: true,
 		Message: message,
-		Data:    data,
+		Data:    Localize(data, FormatterFromContext(c)),
 	})
 }
 
@@ -66,7 +66,7 @@ func CreatedResponse(c *fiber.Ctx, message string, data interface{}) error {
 	return c.Status(fiber.StatusCreated).JSON(Response{
 		Success: true,
 		Message: message,
-		Data:    data,
+		Data:    Localize(data, FormatterFromContext(c)),
 	})
 }
 
@@ -94,7 +94,7 @@ func PaginatedSuccessResponse(c *fiber.Ctx, message string, data interface{}, pa
 	return c.Status(fiber.StatusOK).JSON(PaginatedResponse{
 		Success: true,
 		Message: message,
-		Data:    data,
+		Data:    Localize(data, FormatterFromContext(c)),
 		Meta: Meta{
 			TotalRecords: total,
 			ItemsOnPage:  itemsOnPage,
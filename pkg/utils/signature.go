@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"golang-api/internal/config"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrMissingAppKey is returned instead of signing or encrypting anything with an empty APP_KEY.
+var ErrMissingAppKey = errors.New("APP_KEY is not set")
+
+// SignValues adds an "expires" timestamp and an HMAC "signature" of every value, keyed with APP_KEY.
+func SignValues(values url.Values, expiresAt time.Time) (url.Values, error) {
+	if len(config.AppKey) == 0 {
+		return nil, ErrMissingAppKey
+	}
+
+	signed := url.Values{}
+	for key, value := range values {
+		signed[key] = value
+	}
+
+	signed.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	signed.Del("signature")
+	signed.Set("signature", signature(signed))
+
+	return signed, nil
+}
+
+// ValidSignature reports whether values were produced by SignValues and have not expired.
+func ValidSignature(values url.Values) bool {
+	if len(config.AppKey) == 0 {
+		return false
+	}
+
+	expected := values.Get("signature")
+
+	unsigned := url.Values{}
+	for key, value := range values {
+		if key != "signature" {
+			unsigned[key] = value
+		}
+	}
+
+	if !hmac.Equal([]byte(expected), []byte(signature(unsigned))) {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(values.Get("expires"), 10, 64)
+	return err == nil && time.Now().Unix() <= expires
+}
+
+// signature signs the url encoded values, which Encode sorts by key.
+func signature(values url.Values) string {
+	mac := hmac.New(sha256.New, config.AppKey)
+	mac.Write([]byte(values.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}